@@ -5,11 +5,11 @@ go 1.15
 require (
 	github.com/apparentlymart/go-cidr v1.1.0 // indirect
 	github.com/aws/aws-sdk-go v1.37.0 // indirect
+	github.com/hashicorp/go-retryablehttp v0.7.0
 	github.com/hashicorp/hcl/v2 v2.8.2 // indirect
 	github.com/hashicorp/terraform-plugin-sdk/v2 v2.7.1
 	github.com/mitchellh/go-homedir v1.1.0
 	github.com/newrelic/go-agent/v3 v3.15.0
-	github.com/newrelic/go-insights v1.0.3
 	github.com/newrelic/newrelic-client-go v0.63.0
 	github.com/stretchr/testify v1.7.0
 	golang.org/x/tools v0.0.0-20201028111035-eafbe7b904eb // indirect
@@ -5,6 +5,11 @@ import (
 	"flag"
 	"log"
 
+	// Embeds the IANA time zone database, so that the time zones of muting
+	// rule schedules are validated the same way on hosts without one, like
+	// Windows or minimal containers.
+	_ "time/tzdata"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
 
 	"github.com/newrelic/terraform-provider-newrelic/v2/newrelic"
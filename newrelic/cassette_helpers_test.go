@@ -0,0 +1,424 @@
+//go:build integration || unit
+// +build integration unit
+
+package newrelic
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// cassetteMode is whether a cassette records requests made to New Relic or
+// replays the requests it recorded earlier.
+type cassetteMode int
+
+const (
+	cassetteRecord cassetteMode = iota
+	cassetteReplay
+)
+
+const cassetteRedacted = "REDACTED"
+
+// cassetteSensitiveHeaders are the headers whose values are never written to
+// a cassette.
+var cassetteSensitiveHeaders = []string{
+	"Api-Key",
+	"Authorization",
+	"X-Api-Key",
+	"X-Insert-Key",
+	"X-License-Key",
+	"X-Query-Key",
+}
+
+// cassetteKeyPattern matches New Relic API keys, such as the keys returned by
+// the API when creating them.
+var cassetteKeyPattern = regexp.MustCompile(`\bNR[A-Z]{2}-[A-Za-z0-9]{20,}`)
+
+// cassetteGUIDPattern matches strings which might be entity GUIDs. An entity
+// GUID is the base64 encoding of the entity's account ID, domain, type and
+// domain ID separated by pipes.
+var cassetteGUIDPattern = regexp.MustCompile(`[A-Za-z0-9+/]{12,}={0,2}`)
+
+// cassette holds the request and response pairs exchanged with New Relic.
+//
+// Account IDs are written as placeholders, which are replaced with the
+// account IDs of the current run when replaying. API keys are always
+// redacted. Requests are replayed in the order in which they were recorded,
+// preferring an interaction with the same body when several requests were
+// sent to the same URL.
+type cassette struct {
+	sync.Mutex
+	path         string
+	mode         cassetteMode
+	accountIDs   map[string]int
+	placeholders []string
+	interactions []*cassetteInteraction
+	replayed     []bool
+}
+
+// cassetteFile is the format in which a cassette is stored.
+type cassetteFile struct {
+	Interactions []*cassetteInteraction `json:"interactions"`
+}
+
+type cassetteInteraction struct {
+	Request  cassetteRequest  `json:"request"`
+	Response cassetteResponse `json:"response"`
+}
+
+type cassetteRequest struct {
+	Method  string      `json:"method"`
+	URL     string      `json:"url"`
+	Headers http.Header `json:"headers,omitempty"`
+	Body    string      `json:"body,omitempty"`
+}
+
+type cassetteResponse struct {
+	StatusCode int         `json:"status_code"`
+	Headers    http.Header `json:"headers,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// newCassette returns a cassette stored at path. accountIDs maps the
+// placeholder of each account ID to the account ID used by the current run.
+// A cassette being replayed is loaded from path.
+func newCassette(path string, mode cassetteMode, accountIDs map[string]int) (*cassette, error) {
+	c := &cassette{
+		path:       path,
+		mode:       mode,
+		accountIDs: map[string]int{},
+	}
+
+	for placeholder, id := range accountIDs {
+		if id != 0 {
+			c.accountIDs[placeholder] = id
+			c.placeholders = append(c.placeholders, placeholder)
+		}
+	}
+
+	sort.Strings(c.placeholders)
+
+	if mode == cassetteReplay {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("error reading cassette: %s", err)
+		}
+
+		var f cassetteFile
+		if err := json.Unmarshal(data, &f); err != nil {
+			return nil, fmt.Errorf("error parsing cassette %s: %s", path, err)
+		}
+
+		c.interactions = f.Interactions
+		c.replayed = make([]bool, len(c.interactions))
+	}
+
+	return c, nil
+}
+
+// save writes the recorded interactions to the cassette's path.
+func (c *cassette) save() error {
+	c.Lock()
+	defer c.Unlock()
+
+	data, err := json.MarshalIndent(cassetteFile{Interactions: c.interactions}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.path), 0755); err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(c.path, append(data, '\n'), 0644)
+}
+
+// transport returns a transport which records or replays the requests made
+// with t. secrets are values, such as the configured API keys, which are
+// redacted wherever they appear.
+func (c *cassette) transport(t http.RoundTripper, secrets ...string) http.RoundTripper {
+	return newCassetteTransport(t, func() *cassette { return c }, secrets...)
+}
+
+// redact replaces the account IDs and secrets in s with their placeholders.
+func (c *cassette) redact(s string, secrets []string) string {
+	for _, secret := range secrets {
+		s = strings.ReplaceAll(s, secret, cassetteRedacted)
+	}
+
+	s = cassetteKeyPattern.ReplaceAllStringFunc(s, func(key string) string {
+		return key[:5] + cassetteRedacted
+	})
+
+	for _, placeholder := range c.placeholders {
+		s = replaceAccountID(s, strconv.Itoa(c.accountIDs[placeholder]), placeholder)
+	}
+
+	return s
+}
+
+// restore replaces the account ID placeholders in s with the account IDs of
+// the current run.
+func (c *cassette) restore(s string) string {
+	for _, placeholder := range c.placeholders {
+		s = replaceAccountID(s, placeholder, strconv.Itoa(c.accountIDs[placeholder]))
+	}
+
+	return s
+}
+
+func (c *cassette) redactHeaders(h http.Header, secrets []string) http.Header {
+	redacted := http.Header{}
+
+	for k, values := range h {
+		for _, v := range values {
+			redacted.Add(k, c.redact(v, secrets))
+		}
+	}
+
+	for _, k := range cassetteSensitiveHeaders {
+		if redacted.Get(k) != "" {
+			redacted.Set(k, cassetteRedacted)
+		}
+	}
+
+	// Bodies are stored decoded.
+	redacted.Del("Content-Encoding")
+	redacted.Del("Content-Length")
+
+	return redacted
+}
+
+func (c *cassette) record(i *cassetteInteraction) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.interactions = append(c.interactions, i)
+}
+
+// take returns the first interaction not yet replayed for the request, whose
+// body matches as well when body is set, and marks it as replayed.
+func (c *cassette) take(req cassetteRequest, body bool) *cassetteInteraction {
+	c.Lock()
+	defer c.Unlock()
+
+	for i, interaction := range c.interactions {
+		if c.replayed[i] || interaction.Request.Method != req.Method || interaction.Request.URL != req.URL {
+			continue
+		}
+
+		if body && interaction.Request.Body != req.Body {
+			continue
+		}
+
+		c.replayed[i] = true
+
+		return interaction
+	}
+
+	return nil
+}
+
+// replay returns the first interaction not yet replayed for the request,
+// preferring one whose body matches as well.
+func (c *cassette) replay(req cassetteRequest) (*cassetteInteraction, error) {
+	for _, body := range []bool{true, false} {
+		if interaction := c.take(req, body); interaction != nil {
+			return interaction, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no interaction recorded in cassette %s for %s %s", c.path, req.Method, req.URL)
+}
+
+// cassetteTransport records the requests and responses passing through it to
+// the current cassette when it is recording, or answers requests with the
+// responses recorded in the current cassette without sending them when it is
+// replaying. Requests are sent as is when there is no current cassette.
+type cassetteTransport struct {
+	transport http.RoundTripper
+	cassette  func() *cassette
+	secrets   []string
+}
+
+func newCassetteTransport(t http.RoundTripper, cassette func() *cassette, secrets ...string) *cassetteTransport {
+	var s []string
+	for _, secret := range secrets {
+		if secret != "" {
+			s = append(s, secret)
+		}
+	}
+
+	return &cassetteTransport{
+		transport: t,
+		cassette:  cassette,
+		secrets:   s,
+	}
+}
+
+func (t *cassetteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	c := t.cassette()
+	if c == nil {
+		return t.transport.RoundTrip(req)
+	}
+
+	body, err := readCassetteRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// The secrets are those of the recording, replayed requests hold none.
+	var secrets []string
+	if c.mode == cassetteRecord {
+		secrets = t.secrets
+	}
+
+	recorded := cassetteRequest{
+		Method:  req.Method,
+		URL:     c.redact(req.URL.String(), secrets),
+		Headers: c.redactHeaders(req.Header, secrets),
+		Body:    c.redact(body, secrets),
+	}
+
+	if c.mode == cassetteReplay {
+		interaction, err := c.replay(recorded)
+		if err != nil {
+			return nil, err
+		}
+
+		respBody := c.restore(interaction.Response.Body)
+
+		header := http.Header{}
+		for k, values := range interaction.Response.Headers {
+			for _, v := range values {
+				header.Add(k, c.restore(v))
+			}
+		}
+
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          ioutil.NopCloser(strings.NewReader(respBody)),
+			ContentLength: int64(len(respBody)),
+			Request:       req,
+		}, nil
+	}
+
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+
+	c.record(&cassetteInteraction{
+		Request: recorded,
+		Response: cassetteResponse{
+			StatusCode: resp.StatusCode,
+			Headers:    c.redactHeaders(resp.Header, secrets),
+			Body:       c.redact(string(respBody), secrets),
+		},
+	})
+
+	log.Printf("[DEBUG] Recorded %s %s in cassette %s", req.Method, req.URL.Redacted(), c.path)
+
+	return resp, nil
+}
+
+// readCassetteRequestBody returns the decoded body of req, leaving the body
+// in place to be sent.
+func readCassetteRequestBody(req *http.Request) (string, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return "", nil
+	}
+
+	body, err := ioutil.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return "", err
+	}
+	req.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	if req.Header.Get("Content-Encoding") != "gzip" {
+		return string(body), nil
+	}
+
+	zr, err := gzip.NewReader(bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	defer zr.Close()
+
+	decoded, err := ioutil.ReadAll(zr)
+	if err != nil {
+		return "", err
+	}
+
+	return string(decoded), nil
+}
+
+// replaceAccountID replaces the account ID from with to in s, both where it
+// appears as a number and where it is encoded in an entity GUID.
+func replaceAccountID(s string, from string, to string) string {
+	var b strings.Builder
+
+	for {
+		i := strings.Index(s, from)
+		if i < 0 {
+			b.WriteString(s)
+			break
+		}
+
+		end := i + len(from)
+
+		// Only whole numbers are replaced, not the digits of a longer number.
+		if (i > 0 && isDigit(s[i-1])) || (end < len(s) && isDigit(s[end])) {
+			b.WriteString(s[:end])
+		} else {
+			b.WriteString(s[:i])
+			b.WriteString(to)
+		}
+
+		s = s[end:]
+	}
+
+	return cassetteGUIDPattern.ReplaceAllStringFunc(b.String(), func(token string) string {
+		encoding := base64.RawStdEncoding
+		if strings.HasSuffix(token, "=") {
+			encoding = base64.StdEncoding
+		}
+
+		decoded, err := encoding.DecodeString(token)
+		if err != nil || !strings.HasPrefix(string(decoded), from+"|") {
+			return token
+		}
+
+		return encoding.EncodeToString([]byte(to + strings.TrimPrefix(string(decoded), from)))
+	})
+}
+
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
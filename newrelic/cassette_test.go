@@ -0,0 +1,163 @@
+//go:build unit
+// +build unit
+
+package newrelic
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testCassetteGUID(accountID string) string {
+	return base64.RawStdEncoding.EncodeToString([]byte(accountID + "|APM|APPLICATION|42"))
+}
+
+func testCassetteRoundTrip(t *testing.T, client *http.Client, url string, body string) (int, string) {
+	req, err := http.NewRequest(http.MethodPost, url, strings.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Api-Key", "secret-key")
+
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	return resp.StatusCode, string(respBody)
+}
+
+func TestCassette_RecordAndReplay(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"accountId":12345,"guid":"%s","request":%s,"key":"NRAK-ABCDEFGHIJKLMNOPQRSTUVWXYZ0"}`, testCassetteGUID("12345"), body)
+	}))
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	recorder, err := newCassette(path, cassetteRecord, map[string]int{"{{ACCOUNT_ID}}": 12345})
+	require.NoError(t, err)
+
+	client := &http.Client{Transport: recorder.transport(http.DefaultTransport, "secret-key")}
+
+	status, body := testCassetteRoundTrip(t, client, server.URL+"/accounts/12345", `{"id":1}`)
+	assert.Equal(t, http.StatusCreated, status)
+	assert.Contains(t, body, `"accountId":12345`)
+
+	_, _ = testCassetteRoundTrip(t, client, server.URL+"/accounts/12345", `{"id":2}`)
+
+	server.Close()
+	require.NoError(t, recorder.save())
+
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "12345")
+	assert.NotContains(t, string(data), "secret-key")
+	assert.NotContains(t, string(data), "NRAK-ABCDEFGHIJKLMNOPQRSTUVWXYZ0")
+	assert.Contains(t, string(data), "{{ACCOUNT_ID}}")
+
+	// The recorded responses are replayed for another account.
+	player, err := newCassette(path, cassetteReplay, map[string]int{"{{ACCOUNT_ID}}": 777})
+	require.NoError(t, err)
+
+	client = &http.Client{Transport: player.transport(http.DefaultTransport, "other-key")}
+
+	status, body = testCassetteRoundTrip(t, client, server.URL+"/accounts/777", `{"id":2}`)
+	assert.Equal(t, http.StatusCreated, status)
+	assert.Equal(t, fmt.Sprintf(`{"accountId":777,"guid":"%s","request":{"id":2},"key":"NRAK-REDACTED"}`, testCassetteGUID("777")), body)
+
+	_, body = testCassetteRoundTrip(t, client, server.URL+"/accounts/777", `{"id":1}`)
+	assert.Contains(t, body, `"request":{"id":1}`)
+
+	// Every interaction is replayed once.
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/accounts/777", strings.NewReader(`{"id":1}`))
+	require.NoError(t, err)
+
+	_, err = client.Do(req)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no interaction recorded")
+}
+
+func TestCassetteTransport_ReplaysFromCurrentCassette(t *testing.T) {
+	var name string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"policies":["%s"]}`, name)
+	}))
+
+	// Two tests send the same request, which can't be told apart by its body.
+	var players []*cassette
+	for _, name = range []string{"a", "b"} {
+		path := filepath.Join(t.TempDir(), name+".json")
+
+		recorder, err := newCassette(path, cassetteRecord, nil)
+		require.NoError(t, err)
+
+		client := &http.Client{Transport: recorder.transport(http.DefaultTransport)}
+		_, _ = testCassetteRoundTrip(t, client, server.URL+"/policies", "")
+		require.NoError(t, recorder.save())
+
+		player, err := newCassette(path, cassetteReplay, nil)
+		require.NoError(t, err)
+		players = append(players, player)
+	}
+
+	server.Close()
+
+	var current *cassette
+	client := &http.Client{Transport: newCassetteTransport(http.DefaultTransport, func() *cassette { return current })}
+
+	current = players[1]
+	_, body := testCassetteRoundTrip(t, client, server.URL+"/policies", "")
+	assert.Equal(t, `{"policies":["b"]}`, body)
+
+	current = players[0]
+	_, body = testCassetteRoundTrip(t, client, server.URL+"/policies", "")
+	assert.Equal(t, `{"policies":["a"]}`, body)
+}
+
+func TestReplaceAccountID(t *testing.T) {
+	cases := map[string]struct {
+		s        string
+		expected string
+	}{
+		"number": {
+			s:        `{"accountId":12345}`,
+			expected: `{"accountId":{{ACCOUNT_ID}}}`,
+		},
+		"longer number": {
+			s:        `{"id":123456,"other":912345}`,
+			expected: `{"id":123456,"other":912345}`,
+		},
+		"URL": {
+			s:        "https://api.newrelic.com/v2/accounts/12345/events",
+			expected: "https://api.newrelic.com/v2/accounts/{{ACCOUNT_ID}}/events",
+		},
+		"entity GUID": {
+			s:        fmt.Sprintf(`{"guid":"%s"}`, testCassetteGUID("12345")),
+			expected: fmt.Sprintf(`{"guid":"%s"}`, testCassetteGUID("{{ACCOUNT_ID}}")),
+		},
+		"GUID of another account": {
+			s:        fmt.Sprintf(`{"guid":"%s"}`, testCassetteGUID("54321")),
+			expected: fmt.Sprintf(`{"guid":"%s"}`, testCassetteGUID("54321")),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, replaceAccountID(tc.s, "12345", "{{ACCOUNT_ID}}"))
+			assert.Equal(t, tc.s, replaceAccountID(tc.expected, "{{ACCOUNT_ID}}", "12345"))
+		})
+	}
+}
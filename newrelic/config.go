@@ -1,24 +1,35 @@
 package newrelic
 
 import (
+	"bytes"
 	"compress/gzip"
+	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"net/http"
 	"net/url"
 	"os"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
 	"github.com/mitchellh/go-homedir"
 
-	insights "github.com/newrelic/go-insights/client"
 	nr "github.com/newrelic/newrelic-client-go/newrelic"
+	"github.com/newrelic/newrelic-client-go/pkg/region"
 )
 
-const serviceName = "terraform-provider-newrelic"
+const (
+	serviceName                  = "terraform-provider-newrelic"
+	insightsInsertRequestTimeout = 10 * time.Second
+
+	// requestTimeout is the timeout of each attempt of a request made with the
+	// New Relic client, which is the client's own default.
+	requestTimeout = 30 * time.Second
+)
 
 // Config contains New Relic provider settings
 type Config struct {
@@ -27,6 +38,8 @@ type Config struct {
 	Region               string
 	APIURL               string
 	CACertFile           string
+	ClientCertFile       string
+	ClientKeyFile        string
 	InfrastructureAPIURL string
 	InsecureSkipVerify   bool
 	InsightsAccountID    string
@@ -34,9 +47,14 @@ type Config struct {
 	InsightsInsertURL    string
 	InsightsQueryKey     string
 	InsightsQueryURL     string
+	MaxRetries           int
+	MaxRetryBackoff      time.Duration
 	NerdGraphAPIURL      string
+	ProxyURL             string
 	SyntheticsAPIURL     string
 	userAgent            string
+	requestLimiter       requestLimiter
+	wrapTransport        func(http.RoundTripper) http.RoundTripper
 }
 
 // Client returns a new client for accessing New Relic
@@ -51,32 +69,21 @@ func (c *Config) Client() (*nr.NewRelic, error) {
 		nr.ConfigRegion(c.Region),
 	)
 
-	tlsCfg := &tls.Config{}
-	var t = http.DefaultTransport
-
-	if c.CACertFile != "" {
-		caCert, _, err := read(c.CACertFile)
-		if err != nil {
-			log.Printf("Error reading CA Cert: %s", err)
-			return nil, err
-		}
-		caCertPool := x509.NewCertPool()
-		caCertPool.AppendCertsFromPEM([]byte(caCert))
-		tlsCfg.RootCAs = caCertPool
-
-		t = &http.Transport{TLSClientConfig: tlsCfg}
-	} else if c.InsecureSkipVerify {
-		tlsCfg.InsecureSkipVerify = true
-
-		t = &http.Transport{TLSClientConfig: tlsCfg}
+	t, err := c.transport("newrelic", requestTimeout)
+	if err != nil {
+		return nil, err
 	}
 
 	if logging.LogLevel() != "" {
 		options = append(options, nr.ConfigLogLevel(logging.LogLevel()))
-		t = logging.NewTransport("newrelic", t)
 	}
 
-	options = append(options, nr.ConfigHTTPTransport(t))
+	// The client's timeout covers a request and all of its retries, which are
+	// made by the transport.
+	options = append(options,
+		nr.ConfigHTTPTransport(t),
+		nr.ConfigHTTPTimeout(retryTimeout(requestTimeout, c.MaxRetries, c.MaxRetryBackoff)),
+	)
 
 	if c.APIURL != "" {
 		options = append(options, nr.ConfigBaseURL(c.APIURL))
@@ -106,37 +113,210 @@ func (c *Config) Client() (*nr.NewRelic, error) {
 }
 
 // ClientInsightsInsert returns a new Insights insert client
-func (c *Config) ClientInsightsInsert() (*insights.InsertClient, error) {
-	client := insights.NewInsertClient(c.InsightsInsertKey, c.InsightsAccountID)
+func (c *Config) ClientInsightsInsert() (*InsightsInsertClient, error) {
+	insightsInsertURL := c.InsightsInsertURL
+	if insightsInsertURL == "" {
+		insightsInsertURL = insightsRegionURLs[region.Default].insert
+	}
+
+	insightsURL, err := url.Parse(insightsInsertURL)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing Insights URL: %q", err)
+	}
+	insightsURL.Path = fmt.Sprintf("%s/%s/events", insightsURL.Path, c.InsightsAccountID)
+
+	t, err := c.transport("insights", insightsInsertRequestTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("[INFO] New Relic Insights insert client configured")
+
+	return &InsightsInsertClient{
+		URL:       insightsURL,
+		InsertKey: c.InsightsInsertKey,
+		httpClient: &http.Client{
+			Transport: t,
+			Timeout:   retryTimeout(insightsInsertRequestTimeout, c.MaxRetries, c.MaxRetryBackoff),
+		},
+	}, nil
+}
 
-	if c.InsightsInsertURL != "" {
-		insightsURL, err := url.Parse(c.InsightsInsertURL)
+// transport returns the HTTP transport used by an API client. The transport
+// applies the TLS and proxy settings, the concurrency limit shared by all of
+// the provider's clients, and retries the requests as configured by
+// max_retries and max_retry_backoff, each attempt timing out after
+// attemptTimeout.
+//
+// The New Relic client cannot be told not to retry through its public
+// configuration: it still retries rate limited requests and server errors
+// which the transport gave up on, or did not retry, up to three times.
+func (c *Config) transport(name string, attemptTimeout time.Duration) (http.RoundTripper, error) {
+	tlsCfg := &tls.Config{}
+	customTLS := false
+
+	if c.CACertFile != "" {
+		caCert, _, err := read(c.CACertFile)
 		if err != nil {
-			return nil, fmt.Errorf("error parsing Insights URL: %q", err)
+			log.Printf("Error reading CA Cert: %s", err)
+			return nil, err
 		}
-		insightsURL.Path = fmt.Sprintf("%s/%s/events", insightsURL.Path, c.InsightsAccountID)
-		client.URL = insightsURL
+		caCertPool := x509.NewCertPool()
+		caCertPool.AppendCertsFromPEM([]byte(caCert))
+		tlsCfg.RootCAs = caCertPool
+		customTLS = true
+	} else if c.InsecureSkipVerify {
+		tlsCfg.InsecureSkipVerify = true
+		customTLS = true
 	}
 
-	client.SetCompression(gzip.DefaultCompression)
-
-	if len(c.InsightsInsertKey) > 1 {
-		if err := client.Validate(); err != nil {
+	if c.ClientCertFile != "" || c.ClientKeyFile != "" {
+		cert, err := loadClientCertificate(c.ClientCertFile, c.ClientKeyFile)
+		if err != nil {
 			return nil, err
 		}
+		tlsCfg.Certificates = []tls.Certificate{cert}
+		customTLS = true
 	}
 
-	log.Printf("[INFO] New Relic Insights insert client configured")
+	var t = http.DefaultTransport
 
-	return client, nil
+	if customTLS || c.ProxyURL != "" {
+		// Cloning the default transport keeps its proxy settings from the environment.
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+
+		if customTLS {
+			transport.TLSClientConfig = tlsCfg
+		}
+
+		if c.ProxyURL != "" {
+			proxyURL, err := url.Parse(c.ProxyURL)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing proxy URL: %q", err)
+			}
+			transport.Proxy = http.ProxyURL(proxyURL)
+		}
+
+		t = transport
+	}
+
+	if logging.LogLevel() != "" {
+		t = logging.NewTransport(name, t)
+	}
+
+	// Every attempt of a retried request is logged, and holds a request slot
+	// only while it is in flight, not while backing off.
+	t = newLimitTransport(t, c.requestLimiter)
+
+	t = newRetryTransport(t, attemptTimeout, c.MaxRetries, c.MaxRetryBackoff)
+
+	if c.wrapTransport != nil {
+		t = c.wrapTransport(t)
+	}
+
+	return t, nil
+}
+
+// loadClientCertificate loads a PEM-encoded client certificate and private key
+// used for mutual TLS. Either argument may be a path or the PEM contents.
+func loadClientCertificate(certFile, keyFile string) (tls.Certificate, error) {
+	if certFile == "" || keyFile == "" {
+		return tls.Certificate{}, fmt.Errorf("both a client certificate and a client key are required for mutual TLS")
+	}
+
+	cert, _, err := read(certFile)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("error reading client certificate: %s", err)
+	}
+
+	key, _, err := read(keyFile)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("error reading client key: %s", err)
+	}
+
+	pair, err := tls.X509KeyPair([]byte(cert), []byte(key))
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("error loading client certificate: %s", err)
+	}
+
+	return pair, nil
+}
+
+// InsightsInsertClient posts custom events to the Insights insert API with the
+// provider's own transport.
+type InsightsInsertClient struct {
+	URL        *url.URL
+	InsertKey  string
+	httpClient *http.Client
+}
+
+type insightsInsertResponse struct {
+	Success bool   `json:"success"`
+	Error   string `json:"error"`
+}
+
+// PostEventWithContext posts a single event or a slice of events.
+func (c *InsightsInsertClient) PostEventWithContext(ctx context.Context, data interface{}) error {
+	body, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err = zw.Write(body); err != nil {
+		return err
+	}
+	if err = zw.Close(); err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.URL.String(), &buf)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Content-Encoding", "gzip")
+	req.Header.Set("X-Insert-Key", c.InsertKey)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %s", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("bad response from Insights: %d \n\t%s", resp.StatusCode, respBody)
+	}
+
+	var insertResp insightsInsertResponse
+	if err := json.Unmarshal(respBody, &insertResp); err != nil {
+		return fmt.Errorf("failed to unmarshal insights response: %v", err)
+	}
+
+	if !insertResp.Success {
+		if insertResp.Error == "" {
+			insertResp.Error = "Error unknown"
+		}
+		return fmt.Errorf("%d: %s", resp.StatusCode, insertResp.Error)
+	}
+
+	return nil
 }
 
 // ProviderConfig for the custom provider
 type ProviderConfig struct {
 	NewClient            *nr.NewRelic
-	InsightsInsertClient *insights.InsertClient
+	InsightsInsertClient *InsightsInsertClient
 	AccountID            int
 	PersonalAPIKey       string
+	DefaultTags          map[string]string
 }
 
 func (c *ProviderConfig) hasNerdGraphCredentials() bool {
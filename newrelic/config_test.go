@@ -0,0 +1,165 @@
+//go:build unit
+// +build unit
+
+package newrelic
+
+import (
+	"compress/gzip"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testClientCertificatePEM(t *testing.T) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "terraform-provider-newrelic"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+
+	return string(certPEM), string(keyPEM)
+}
+
+func TestConfigTransport_ProxyURL(t *testing.T) {
+	var proxiedHost string
+
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxiedHost = r.URL.Host
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer proxy.Close()
+
+	c := Config{ProxyURL: proxy.URL}
+
+	transport, err := c.transport("test", time.Second)
+	require.NoError(t, err)
+
+	resp, err := (&http.Client{Transport: transport}).Get("http://api.newrelic.example/v2/applications.json")
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, "api.newrelic.example", proxiedHost)
+}
+
+func TestConfigTransport_ClientCertificate(t *testing.T) {
+	certPEM, keyPEM := testClientCertificatePEM(t)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TLS.PeerCertificates) != 1 {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	server.StartTLS()
+	defer server.Close()
+
+	c := Config{
+		InsecureSkipVerify: true,
+		ClientCertFile:     certPEM,
+		ClientKeyFile:      keyPEM,
+	}
+
+	transport, err := c.transport("test", time.Second)
+	require.NoError(t, err)
+
+	resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestConfigTransport_ClientCertificateRequiresKey(t *testing.T) {
+	certPEM, _ := testClientCertificatePEM(t)
+
+	c := Config{ClientCertFile: certPEM}
+
+	_, err := c.transport("test", time.Second)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "both a client certificate and a client key are required")
+}
+
+func TestInsightsInsertClient_PostEventWithContext(t *testing.T) {
+	var events []map[string]interface{}
+	var insertKey string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		insertKey = r.Header.Get("X-Insert-Key")
+
+		zr, err := gzip.NewReader(r.Body)
+		require.NoError(t, err)
+		require.NoError(t, json.NewDecoder(zr).Decode(&events))
+
+		_, _ = w.Write([]byte(`{"success":true}`))
+	}))
+	defer server.Close()
+
+	insertURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	c := Config{
+		InsightsAccountID: "1",
+		InsightsInsertKey: "abc123",
+		ProxyURL:          server.URL,
+	}
+
+	client, err := c.ClientInsightsInsert()
+	require.NoError(t, err)
+
+	// Events are sent to the default collector URL through the proxy.
+	assert.Equal(t, "https://insights-collector.newrelic.com/v1/accounts/1/events", client.URL.String())
+	client.URL = insertURL
+
+	err = client.PostEventWithContext(context.Background(), []map[string]interface{}{{"eventType": "Test"}})
+	require.NoError(t, err)
+
+	assert.Equal(t, "abc123", insertKey)
+	assert.Equal(t, []map[string]interface{}{{"eventType": "Test"}}, events)
+}
+
+func TestInsightsInsertClient_PostEventWithContextError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"success":false,"error":"invalid event"}`))
+	}))
+	defer server.Close()
+
+	insertURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	client := &InsightsInsertClient{URL: insertURL, httpClient: http.DefaultClient}
+
+	err = client.PostEventWithContext(context.Background(), map[string]interface{}{"eventType": "Test"})
+	require.Error(t, err)
+	assert.Equal(t, "200: invalid event", err.Error())
+}
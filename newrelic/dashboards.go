@@ -0,0 +1,142 @@
+package newrelic
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/newrelic/newrelic-client-go/newrelic"
+	"github.com/newrelic/newrelic-client-go/pkg/dashboards"
+)
+
+// newrelic-client-go has no support for dashboard variables. Dashboards are
+// created and updated with its own mutation documents and an input carrying
+// the variables, which are read back with their own query.
+
+const getDashboardVariablesQuery = `query($guid: EntityGuid!) {
+	actor {
+		entity(guid: $guid) {
+			... on DashboardEntity {
+				variables {
+					name
+					title
+					type
+					isMultiSelection
+					replacementStrategy
+					defaultValues {
+						value {
+							string
+						}
+					}
+					items {
+						title
+						value
+					}
+					nrqlQuery {
+						accountIds
+						query
+					}
+				}
+			}
+		}
+	}
+}`
+
+// dashboardInput is a dashboards.DashboardInput with variables.
+type dashboardInput struct {
+	dashboards.DashboardInput
+	Variables []dashboardVariable `json:"variables"`
+}
+
+// dashboardVariable is both the input and the output of a dashboard variable.
+type dashboardVariable struct {
+	Name                string                         `json:"name"`
+	Title               string                         `json:"title,omitempty"`
+	Type                string                         `json:"type"`
+	DefaultValues       []dashboardVariableDefaultItem `json:"defaultValues,omitempty"`
+	IsMultiSelection    bool                           `json:"isMultiSelection"`
+	Items               []dashboardVariableEnumItem    `json:"items,omitempty"`
+	NRQLQuery           *dashboardVariableNRQLQuery    `json:"nrqlQuery,omitempty"`
+	ReplacementStrategy string                         `json:"replacementStrategy,omitempty"`
+}
+
+type dashboardVariableDefaultItem struct {
+	Value dashboardVariableDefaultValue `json:"value"`
+}
+
+type dashboardVariableDefaultValue struct {
+	String string `json:"string"`
+}
+
+type dashboardVariableEnumItem struct {
+	Title string `json:"title,omitempty"`
+	Value string `json:"value"`
+}
+
+type dashboardVariableNRQLQuery struct {
+	AccountIDs []int  `json:"accountIds"`
+	Query      string `json:"query"`
+}
+
+func createDashboard(ctx context.Context, client *newrelic.NewRelic, accountID int, dashboard dashboardInput) (*dashboards.DashboardCreateResult, error) {
+	resp := dashboards.DashboardCreateQueryResponse{}
+	vars := map[string]interface{}{
+		"accountId": accountID,
+		"dashboard": dashboard,
+	}
+
+	if err := client.NerdGraph.QueryWithResponseAndContext(ctx, dashboards.DashboardCreateMutation, vars, &resp); err != nil {
+		return nil, err
+	}
+
+	if len(resp.DashboardCreateResult.Errors) > 0 {
+		errs := fmt.Errorf("query error")
+		for _, err := range resp.DashboardCreateResult.Errors {
+			errs = fmt.Errorf("%w; %s", errs, err.Description)
+		}
+		return nil, errs
+	}
+
+	return &resp.DashboardCreateResult, nil
+}
+
+func updateDashboard(ctx context.Context, client *newrelic.NewRelic, guid string, dashboard dashboardInput) (*dashboards.DashboardUpdateResult, error) {
+	resp := dashboards.DashboardUpdateQueryResponse{}
+	vars := map[string]interface{}{
+		"dashboard": dashboard,
+		"guid":      guid,
+	}
+
+	if err := client.NerdGraph.QueryWithResponseAndContext(ctx, dashboards.DashboardUpdateMutation, vars, &resp); err != nil {
+		return nil, err
+	}
+
+	if len(resp.DashboardUpdateResult.Errors) > 0 {
+		errs := fmt.Errorf("query error")
+		for _, err := range resp.DashboardUpdateResult.Errors {
+			errs = fmt.Errorf("%w; %s", errs, err.Description)
+		}
+		return nil, errs
+	}
+
+	return &resp.DashboardUpdateResult, nil
+}
+
+func getDashboardVariables(ctx context.Context, client *newrelic.NewRelic, guid string) ([]dashboardVariable, error) {
+	var resp struct {
+		Actor struct {
+			Entity struct {
+				Variables []dashboardVariable `json:"variables"`
+			} `json:"entity"`
+		} `json:"actor"`
+	}
+
+	vars := map[string]interface{}{
+		"guid": guid,
+	}
+
+	if err := client.NerdGraph.QueryWithResponseAndContext(ctx, getDashboardVariablesQuery, vars, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Actor.Entity.Variables, nil
+}
@@ -0,0 +1,474 @@
+package newrelic
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/newrelic/newrelic-client-go/pkg/alerts"
+	"github.com/newrelic/newrelic-client-go/pkg/errors"
+)
+
+// pluginsConditionType is the type used by the conversion data source for the
+// conditions of newrelic_plugins_alert_condition, which have no type.
+const pluginsConditionType = "plugins_metric"
+
+// defaultNrqlViolationTimeLimitSeconds is the violation time limit of a
+// converted condition whose legacy condition has no violation_close_timer.
+const defaultNrqlViolationTimeLimitSeconds = 259200
+
+// legacyConditionQuery is the NRQL equivalent of a legacy condition metric.
+type legacyConditionQuery struct {
+	Function string
+	From     string
+	Where    string
+}
+
+// legacyConditionEntityAttributes are the NRQL attributes holding the IDs of
+// the entities targeted by each type of legacy condition.
+var legacyConditionEntityAttributes = map[string]string{
+	"apm_app_metric": "appId",
+	"apm_jvm_metric": "appId",
+	"browser_metric": "appId",
+	"mobile_metric":  "appId",
+}
+
+// legacyConditionQueries maps the metrics of alertConditionTypes to their NRQL
+// equivalents. Metrics without an equivalent in NRDB, such as the metrics of
+// key transactions and legacy servers, are not converted. The user_defined
+// metric of every type is converted from its metric timeslice.
+var legacyConditionQueries = map[string]map[string]legacyConditionQuery{
+	"apm_app_metric": {
+		"apdex":                    {Function: "apdex(apm.service.apdex)", From: "Metric"},
+		"error_percentage":         {Function: "(count(apm.service.error.count) / count(apm.service.transaction.duration)) * 100", From: "Metric"},
+		"response_time_background": {Function: "average(apm.service.transaction.duration)", From: "Metric", Where: "transactionType = 'Other'"},
+		"response_time_web":        {Function: "average(apm.service.transaction.duration)", From: "Metric", Where: "transactionType = 'Web'"},
+		"throughput_background":    {Function: "rate(count(apm.service.transaction.duration), 1 minute)", From: "Metric", Where: "transactionType = 'Other'"},
+		"throughput_web":           {Function: "rate(count(apm.service.transaction.duration), 1 minute)", From: "Metric", Where: "transactionType = 'Web'"},
+	},
+	"apm_jvm_metric": {
+		"cpu_utilization_time": {Function: "average(apm.service.cpu.usertime.utilization) * 100", From: "Metric"},
+		"deadlocked_threads":   {Function: "max(newrelic.timeslice.value)", From: "Metric", Where: "metricTimesliceName = 'Threads/Deadlocked/all'"},
+		"heap_memory_usage":    {Function: "average(newrelic.timeslice.value) * 100", From: "Metric", Where: "metricTimesliceName = 'Memory/Heap/Utilization'"},
+	},
+	"browser_metric": {
+		"ajax_response_time":   {Function: "average(timeToLoadEventStart)", From: "AjaxRequest"},
+		"ajax_throughput":      {Function: "rate(count(*), 1 minute)", From: "AjaxRequest"},
+		"dom_processing":       {Function: "average(domProcessingDuration)", From: "PageView"},
+		"end_user_apdex":       {Function: "apdex(duration, t: 7)", From: "PageView"},
+		"network":              {Function: "average(networkDuration)", From: "PageView"},
+		"page_rendering":       {Function: "average(pageRenderingDuration)", From: "PageView"},
+		"page_view_throughput": {Function: "rate(count(*), 1 minute)", From: "PageView"},
+		"request_queuing":      {Function: "average(queueDuration)", From: "PageView"},
+		"total_page_load":      {Function: "average(duration)", From: "PageView"},
+		"web_application":      {Function: "average(backendDuration)", From: "PageView"},
+	},
+	"mobile_metric": {
+		"mobile_crash_rate": {Function: "percentage(uniqueCount(sessionId), WHERE sessionCrashed IS true)", From: "MobileSession"},
+		"network":           {Function: "average(responseTime)", From: "MobileRequest"},
+	},
+}
+
+// legacyValueFunctions maps the value functions of user defined and plugins
+// metrics to NRQL aggregator functions.
+var legacyValueFunctions = map[string]string{
+	"average":     "average",
+	"max":         "max",
+	"min":         "min",
+	"sample_size": "count",
+	"total":       "sum",
+}
+
+var legacyConditionOperators = map[string]string{
+	"above": "above",
+	"below": "below",
+	"equal": "equals",
+}
+
+var legacyConditionTimeFunctions = map[string]string{
+	"all": "ALL",
+	"any": "AT_LEAST_ONCE",
+}
+
+func dataSourceNewRelicAlertConditionNrqlConversion() *schema.Resource {
+	validTypes := []string{pluginsConditionType}
+	for k := range alertConditionTypes {
+		validTypes = append(validTypes, k)
+	}
+	sort.Strings(validTypes)
+
+	nrqlTermSchema := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"operator": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"threshold": {
+				Type:     schema.TypeFloat,
+				Computed: true,
+			},
+			"threshold_duration": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"threshold_occurrences": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+
+	return &schema.Resource{
+		ReadContext: dataSourceNewRelicAlertConditionNrqlConversionRead,
+		Schema: map[string]*schema.Schema{
+			"condition_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"condition_id", "type"},
+				Description:  "The ID of an existing newrelic_alert_condition or newrelic_plugins_alert_condition to convert, in the format <policy_id>:<condition_id>.",
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"entities", "metric", "term"},
+				ValidateFunc: validation.StringInSlice(validTypes, false),
+				Description:  fmt.Sprintf("The type of the legacy condition to convert. One of: (%s). Use %s for a newrelic_plugins_alert_condition.", strings.Join(validTypes, ", "), pluginsConditionType),
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The title of the legacy condition.",
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the legacy condition is enabled.",
+			},
+			"entities": {
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Optional:    true,
+				Description: "The IDs of the entities targeted by the legacy condition.",
+			},
+			"metric": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The metric of the legacy condition.",
+			},
+			"runbook_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Runbook URL of the legacy condition.",
+			},
+			"condition_scope": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The condition_scope of the legacy condition.",
+			},
+			"violation_close_timer": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "The violation_close_timer of the legacy condition, in hours.",
+			},
+			"gc_metric": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The gc_metric of the legacy condition.",
+			},
+			"term": {
+				Type:        schema.TypeSet,
+				Elem:        resourceNewRelicAlertCondition().Schema["term"].Elem,
+				Optional:    true,
+				Description: "The terms of the legacy condition.",
+			},
+			"user_defined_metric": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The user_defined_metric of the legacy condition.",
+			},
+			"user_defined_value_function": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The user_defined_value_function of the legacy condition.",
+			},
+			"value_function": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The value_function of a newrelic_plugins_alert_condition.",
+			},
+			"nrql_condition": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The attributes of the equivalent newrelic_nrql_alert_condition.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"enabled": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"runbook_url": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"value_function": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"violation_time_limit_seconds": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"aggregation_window": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"nrql": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"query": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+								},
+							},
+						},
+						"critical": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     nrqlTermSchema,
+						},
+						"warning": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     nrqlTermSchema,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceNewRelicAlertConditionNrqlConversionRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var condition *alerts.Condition
+
+	if conditionID, ok := d.GetOk("condition_id"); ok {
+		client := meta.(*ProviderConfig).NewClient
+
+		ids, err := parseIDs(conditionID.(string), 2)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		log.Printf("[INFO] Reading New Relic alert condition %s to convert", conditionID)
+
+		condition, err = client.Alerts.GetConditionWithContext(ctx, ids[0], ids[1])
+		if _, ok := err.(*errors.NotFound); ok {
+			// The ID may be the one of a newrelic_plugins_alert_condition
+			var pluginsCondition *alerts.PluginsCondition
+
+			pluginsCondition, err = client.Alerts.GetPluginsConditionWithContext(ctx, ids[0], ids[1])
+			if err == nil {
+				condition = expandPluginsConditionForConversion(pluginsCondition)
+			}
+		}
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		d.SetId(conditionID.(string))
+	} else {
+		var err error
+
+		condition, err = expandAlertCondition(d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if condition.Type == pluginsConditionType {
+			condition.UserDefined.ValueFunction = alerts.ValueFunctionType(d.Get("value_function").(string))
+		}
+	}
+
+	nrqlCondition, warnings, err := convertAlertConditionToNrql(condition)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.Id() == "" {
+		d.SetId(strconv.Itoa(schema.HashString(nrqlCondition["nrql"].([]interface{})[0].(map[string]interface{})["query"])))
+	}
+
+	if err := d.Set("nrql_condition", []interface{}{nrqlCondition}); err != nil {
+		return diag.FromErr(err)
+	}
+
+	var diags diag.Diagnostics
+	for _, w := range warnings {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  w,
+		})
+	}
+
+	return diags
+}
+
+// expandPluginsConditionForConversion returns a plugins condition as the
+// legacy condition of type pluginsConditionType converted by the data source.
+func expandPluginsConditionForConversion(condition *alerts.PluginsCondition) *alerts.Condition {
+	return &alerts.Condition{
+		ID:         condition.ID,
+		Type:       pluginsConditionType,
+		Name:       condition.Name,
+		Enabled:    condition.Enabled,
+		Entities:   condition.Entities,
+		Metric:     alerts.MetricType(condition.Metric),
+		RunbookURL: condition.RunbookURL,
+		Terms:      condition.Terms,
+		UserDefined: alerts.ConditionUserDefined{
+			ValueFunction: alerts.ValueFunctionType(condition.ValueFunction),
+		},
+	}
+}
+
+// convertAlertConditionToNrql returns the attributes of the static NRQL
+// condition equivalent to a legacy condition, along with warnings about parts
+// of the condition which could not be converted exactly.
+func convertAlertConditionToNrql(condition *alerts.Condition) (map[string]interface{}, []string, error) {
+	var warnings []string
+
+	conditionType := string(condition.Type)
+	metric := string(condition.Metric)
+
+	var query legacyConditionQuery
+
+	switch {
+	case conditionType == pluginsConditionType || metric == "user_defined":
+		timeslice := condition.UserDefined.Metric
+		if conditionType == pluginsConditionType {
+			timeslice = metric
+			warnings = append(warnings, "plugin component IDs have no NRQL equivalent, the converted query is not filtered by entity")
+		}
+
+		function, ok := legacyValueFunctions[string(condition.UserDefined.ValueFunction)]
+		if !ok {
+			return nil, nil, fmt.Errorf("value function %q of %s conditions has no NRQL equivalent", condition.UserDefined.ValueFunction, conditionType)
+		}
+
+		query = legacyConditionQuery{
+			Function: fmt.Sprintf("%s(newrelic.timeslice.value)", function),
+			From:     "Metric",
+			Where:    fmt.Sprintf("metricTimesliceName = '%s'", escapeNrqlString(timeslice)),
+		}
+	case conditionType == "apm_jvm_metric" && metric == "gc_cpu_time":
+		query = legacyConditionQuery{
+			Function: "average(newrelic.timeslice.value) * 100",
+			From:     "Metric",
+			Where:    fmt.Sprintf("metricTimesliceName = '%s'", escapeNrqlString(condition.GCMetric)),
+		}
+	default:
+		var ok bool
+		if query, ok = legacyConditionQueries[conditionType][metric]; !ok {
+			return nil, nil, fmt.Errorf("metric %q of %s conditions has no NRQL equivalent", metric, conditionType)
+		}
+	}
+
+	var where []string
+
+	if attribute, ok := legacyConditionEntityAttributes[conditionType]; ok && len(condition.Entities) > 0 {
+		entities := make([]int, 0, len(condition.Entities))
+		for _, e := range condition.Entities {
+			id, err := strconv.Atoi(e)
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid entity ID %q: %s", e, err)
+			}
+			entities = append(entities, id)
+		}
+		sort.Ints(entities)
+
+		ids := make([]string, len(entities))
+		for i, id := range entities {
+			ids[i] = strconv.Itoa(id)
+		}
+
+		where = append(where, fmt.Sprintf("%s IN (%s)", attribute, strings.Join(ids, ", ")))
+	}
+
+	if query.Where != "" {
+		where = append(where, query.Where)
+	}
+
+	nrql := fmt.Sprintf("SELECT %s FROM %s", query.Function, query.From)
+	if len(where) > 0 {
+		nrql += " WHERE " + strings.Join(where, " AND ")
+	}
+
+	if conditionType == "apm_app_metric" && condition.Scope == "instance" {
+		nrql += " FACET host"
+		warnings = append(warnings, "instance scoped conditions are converted to a query faceted by host")
+	}
+
+	violationTimeLimit := defaultNrqlViolationTimeLimitSeconds
+	if condition.ViolationCloseTimer > 0 {
+		violationTimeLimit = condition.ViolationCloseTimer * 3600
+	}
+
+	nrqlCondition := map[string]interface{}{
+		"name":                         condition.Name,
+		"enabled":                      condition.Enabled,
+		"runbook_url":                  condition.RunbookURL,
+		"type":                         "static",
+		"value_function":               "single_value",
+		"violation_time_limit_seconds": violationTimeLimit,
+		"aggregation_window":           60,
+		"nrql": []interface{}{
+			map[string]interface{}{"query": nrql},
+		},
+		"critical": []interface{}{},
+		"warning":  []interface{}{},
+	}
+
+	for _, term := range condition.Terms {
+		priority := string(term.Priority)
+		if priority == "" {
+			priority = "critical"
+		}
+
+		if len(nrqlCondition[priority].([]interface{})) > 0 {
+			return nil, nil, fmt.Errorf("a NRQL condition can only have one %s term", priority)
+		}
+
+		nrqlCondition[priority] = []interface{}{
+			map[string]interface{}{
+				"operator":              legacyConditionOperators[string(term.Operator)],
+				"threshold":             term.Threshold,
+				"threshold_duration":    term.Duration * 60,
+				"threshold_occurrences": legacyConditionTimeFunctions[string(term.TimeFunction)],
+			},
+		}
+	}
+
+	return nrqlCondition, warnings, nil
+}
+
+func escapeNrqlString(s string) string {
+	return strings.ReplaceAll(s, "'", "\\'")
+}
@@ -0,0 +1,210 @@
+//go:build unit
+// +build unit
+
+package newrelic
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/newrelic/newrelic-client-go/pkg/alerts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertAlertConditionToNrql(t *testing.T) {
+	cases := map[string]struct {
+		Condition alerts.Condition
+		Query     string
+		Warnings  int
+		ErrorMsg  string
+	}{
+		"apm_app_metric": {
+			Condition: alerts.Condition{Type: "apm_app_metric", Metric: "response_time_web", Entities: []string{"20", "3"}},
+			Query:     "SELECT average(apm.service.transaction.duration) FROM Metric WHERE appId IN (3, 20) AND transactionType = 'Web'",
+		},
+		"instance scope": {
+			Condition: alerts.Condition{Type: "apm_app_metric", Metric: "apdex", Entities: []string{"1"}, Scope: "instance"},
+			Query:     "SELECT apdex(apm.service.apdex) FROM Metric WHERE appId IN (1) FACET host",
+			Warnings:  1,
+		},
+		"browser_metric": {
+			Condition: alerts.Condition{Type: "browser_metric", Metric: "total_page_load", Entities: []string{"1"}},
+			Query:     "SELECT average(duration) FROM PageView WHERE appId IN (1)",
+		},
+		"user_defined": {
+			Condition: alerts.Condition{
+				Type:        "apm_app_metric",
+				Metric:      "user_defined",
+				Entities:    []string{"1"},
+				UserDefined: alerts.ConditionUserDefined{Metric: "Custom/It's", ValueFunction: "total"},
+			},
+			Query: "SELECT sum(newrelic.timeslice.value) FROM Metric WHERE appId IN (1) AND metricTimesliceName = 'Custom/It\\'s'",
+		},
+		"gc_cpu_time": {
+			Condition: alerts.Condition{Type: "apm_jvm_metric", Metric: "gc_cpu_time", Entities: []string{"1"}, GCMetric: "GC/G1 Young Generation"},
+			Query:     "SELECT average(newrelic.timeslice.value) * 100 FROM Metric WHERE appId IN (1) AND metricTimesliceName = 'GC/G1 Young Generation'",
+		},
+		"plugins": {
+			Condition: alerts.Condition{
+				Type:        pluginsConditionType,
+				Metric:      "Component/Connections[connections]",
+				Entities:    []string{"1"},
+				UserDefined: alerts.ConditionUserDefined{ValueFunction: "average"},
+			},
+			Query:    "SELECT average(newrelic.timeslice.value) FROM Metric WHERE metricTimesliceName = 'Component/Connections[connections]'",
+			Warnings: 1,
+		},
+		"key transaction": {
+			Condition: alerts.Condition{Type: "apm_kt_metric", Metric: "apdex", Entities: []string{"1"}},
+			ErrorMsg:  "metric \"apdex\" of apm_kt_metric conditions has no NRQL equivalent",
+		},
+		"duplicate priority": {
+			Condition: alerts.Condition{
+				Type:     "apm_app_metric",
+				Metric:   "apdex",
+				Entities: []string{"1"},
+				Terms: []alerts.ConditionTerm{
+					{Duration: 5, Operator: "below", Priority: "critical", Threshold: 0.7, TimeFunction: "all"},
+					{Duration: 10, Operator: "below", Threshold: 0.5, TimeFunction: "all"},
+				},
+			},
+			ErrorMsg: "a NRQL condition can only have one critical term",
+		},
+		"plugins percent": {
+			Condition: alerts.Condition{
+				Type:        pluginsConditionType,
+				Metric:      "Component/Connections[connections]",
+				UserDefined: alerts.ConditionUserDefined{ValueFunction: "percent"},
+			},
+			ErrorMsg: "value function \"percent\" of plugins_metric conditions has no NRQL equivalent",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			nrqlCondition, warnings, err := convertAlertConditionToNrql(&tc.Condition)
+
+			if tc.ErrorMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.ErrorMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.Query, nrqlCondition["nrql"].([]interface{})[0].(map[string]interface{})["query"])
+			assert.Len(t, warnings, tc.Warnings)
+		})
+	}
+}
+
+func TestDataSourceNewRelicAlertConditionNrqlConversion(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, dataSourceNewRelicAlertConditionNrqlConversion().Schema, map[string]interface{}{
+		"name":                  "High response time",
+		"type":                  "apm_app_metric",
+		"metric":                "response_time_web",
+		"entities":              []interface{}{1234},
+		"condition_scope":       "instance",
+		"violation_close_timer": 24,
+		"term": []interface{}{
+			map[string]interface{}{
+				"duration":      5,
+				"operator":      "above",
+				"priority":      "critical",
+				"threshold":     2.0,
+				"time_function": "all",
+			},
+			map[string]interface{}{
+				"duration":      10,
+				"operator":      "equal",
+				"priority":      "warning",
+				"threshold":     1.0,
+				"time_function": "any",
+			},
+		},
+	})
+
+	diags := dataSourceNewRelicAlertConditionNrqlConversionRead(context.Background(), d, nil)
+	require.False(t, diags.HasError(), "%+v", diags)
+	require.Len(t, diags, 1)
+
+	assert.NotEmpty(t, d.Id())
+	assert.Equal(t, "High response time", d.Get("nrql_condition.0.name"))
+	assert.Equal(t, true, d.Get("nrql_condition.0.enabled"))
+	assert.Equal(t, "static", d.Get("nrql_condition.0.type"))
+	assert.Equal(t, "single_value", d.Get("nrql_condition.0.value_function"))
+	assert.Equal(t, 86400, d.Get("nrql_condition.0.violation_time_limit_seconds"))
+	assert.Equal(t, "SELECT average(apm.service.transaction.duration) FROM Metric WHERE appId IN (1234) AND transactionType = 'Web' FACET host", d.Get("nrql_condition.0.nrql.0.query"))
+
+	assert.Equal(t, "above", d.Get("nrql_condition.0.critical.0.operator"))
+	assert.Equal(t, 2.0, d.Get("nrql_condition.0.critical.0.threshold"))
+	assert.Equal(t, 300, d.Get("nrql_condition.0.critical.0.threshold_duration"))
+	assert.Equal(t, "ALL", d.Get("nrql_condition.0.critical.0.threshold_occurrences"))
+
+	assert.Equal(t, "equals", d.Get("nrql_condition.0.warning.0.operator"))
+	assert.Equal(t, 600, d.Get("nrql_condition.0.warning.0.threshold_duration"))
+	assert.Equal(t, "AT_LEAST_ONCE", d.Get("nrql_condition.0.warning.0.threshold_occurrences"))
+}
+
+func TestDataSourceNewRelicAlertConditionNrqlConversion_ConditionID_FakeBackend(t *testing.T) {
+	f := newFakeBackend(t)
+	meta := testFakeBackendMeta(t, f)
+
+	f.addAlertCondition("apm", 1, map[string]interface{}{
+		"name":     "apdex",
+		"enabled":  true,
+		"type":     "apm_app_metric",
+		"metric":   "apdex",
+		"entities": []string{"1234"},
+		"terms": []interface{}{
+			map[string]interface{}{"duration": "5", "operator": "below", "priority": "critical", "threshold": "0.7", "time_function": "all"},
+		},
+	})
+	f.addAlertCondition("plugins", 1, map[string]interface{}{
+		"name":           "connections",
+		"enabled":        true,
+		"metric":         "Component/Connections[connections]",
+		"value_function": "average",
+		"entities":       []string{"5678"},
+		"terms": []interface{}{
+			map[string]interface{}{"duration": "5", "operator": "above", "priority": "critical", "threshold": "100", "time_function": "all"},
+		},
+	})
+
+	cases := map[string]struct {
+		conditionID string
+		query       string
+	}{
+		"APM condition": {
+			conditionID: "1:101",
+			query:       "SELECT apdex(apm.service.apdex) FROM Metric WHERE appId IN (1234)",
+		},
+		"plugins condition": {
+			conditionID: "1:102",
+			query:       "SELECT average(newrelic.timeslice.value) FROM Metric WHERE metricTimesliceName = 'Component/Connections[connections]'",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, dataSourceNewRelicAlertConditionNrqlConversion().Schema, map[string]interface{}{
+				"condition_id": tc.conditionID,
+			})
+
+			diags := dataSourceNewRelicAlertConditionNrqlConversionRead(context.Background(), d, meta)
+			require.False(t, diags.HasError(), "%+v", diags)
+
+			assert.Equal(t, tc.conditionID, d.Id())
+			assert.Equal(t, tc.query, d.Get("nrql_condition.0.nrql.0.query"))
+		})
+	}
+
+	d := schema.TestResourceDataRaw(t, dataSourceNewRelicAlertConditionNrqlConversion().Schema, map[string]interface{}{
+		"condition_id": "1:999",
+	})
+
+	diags := dataSourceNewRelicAlertConditionNrqlConversionRead(context.Background(), d, meta)
+	require.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, "no condition found")
+}
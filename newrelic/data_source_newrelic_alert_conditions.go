@@ -0,0 +1,261 @@
+package newrelic
+
+import (
+	"context"
+	"log"
+	"sort"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/newrelic/newrelic-client-go/newrelic"
+	"github.com/newrelic/newrelic-client-go/pkg/alerts"
+)
+
+// The families of alert conditions, named after the resource managing them.
+// Only NRQL conditions can be searched through NerdGraph, the other families
+// are listed through the REST API.
+const (
+	alertConditionFamilyNrql                    = "nrql"
+	alertConditionFamilyApm                     = "apm"
+	alertConditionFamilyInfra                   = "infra"
+	alertConditionFamilySynthetics              = "synthetics"
+	alertConditionFamilySyntheticsMultiLocation = "synthetics_multilocation"
+	alertConditionFamilyPlugins                 = "plugins"
+)
+
+// Types of the conditions whose API has no type.
+const (
+	syntheticsConditionType              = "synthetics"
+	syntheticsMultiLocationConditionType = "synthetics_multilocation"
+)
+
+// alertConditionSummary is a condition of any family.
+type alertConditionSummary struct {
+	ID         string
+	Family     string
+	Type       string
+	Name       string
+	Enabled    bool
+	RunbookURL string
+}
+
+func dataSourceNewRelicAlertConditions() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceNewRelicAlertConditionsRead,
+		Schema: map[string]*schema.Schema{
+			"policy_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				Description: "The ID of the alert policy.",
+			},
+			"account_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "The New Relic account ID to operate on.",
+			},
+			"conditions": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The conditions of the alert policy, of every family.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the condition.",
+						},
+						"family": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The family of the condition, one of nrql, apm, infra, synthetics, synthetics_multilocation or plugins.",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the condition.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the condition.",
+						},
+						"enabled": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the condition is enabled.",
+						},
+						"runbook_url": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The runbook URL of the condition.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceNewRelicAlertConditionsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+
+	if !providerConfig.hasNerdGraphCredentials() {
+		return diag.Errorf("err: NerdGraph support not present, but required for Read")
+	}
+
+	client := providerConfig.NewClient
+	accountID := selectAccountID(providerConfig, d)
+	policyID := d.Get("policy_id").(int)
+
+	log.Printf("[INFO] Reading New Relic alert conditions of policy %d", policyID)
+
+	conditions, err := listAlertConditions(ctx, client, accountID, policyID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(strconv.Itoa(policyID))
+
+	if err := d.Set("account_id", accountID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.FromErr(d.Set("conditions", flattenAlertConditionSummaries(conditions)))
+}
+
+// listAlertConditions returns the conditions of every family of a policy,
+// sorted by family, name and ID.
+func listAlertConditions(ctx context.Context, client *newrelic.NewRelic, accountID int, policyID int) ([]alertConditionSummary, error) {
+	var conditions []alertConditionSummary
+
+	nrqlConditions, err := client.Alerts.SearchNrqlConditionsQueryWithContext(ctx, accountID, alerts.NrqlConditionsSearchCriteria{
+		PolicyID: strconv.Itoa(policyID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range nrqlConditions {
+		conditions = append(conditions, alertConditionSummary{
+			ID:         c.ID,
+			Family:     alertConditionFamilyNrql,
+			Type:       string(c.Type),
+			Name:       c.Name,
+			Enabled:    c.Enabled,
+			RunbookURL: c.RunbookURL,
+		})
+	}
+
+	apmConditions, err := client.Alerts.ListConditionsWithContext(ctx, policyID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range apmConditions {
+		conditions = append(conditions, alertConditionSummary{
+			ID:         strconv.Itoa(c.ID),
+			Family:     alertConditionFamilyApm,
+			Type:       string(c.Type),
+			Name:       c.Name,
+			Enabled:    c.Enabled,
+			RunbookURL: c.RunbookURL,
+		})
+	}
+
+	infraConditions, err := client.Alerts.ListInfrastructureConditionsWithContext(ctx, policyID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range infraConditions {
+		conditions = append(conditions, alertConditionSummary{
+			ID:         strconv.Itoa(c.ID),
+			Family:     alertConditionFamilyInfra,
+			Type:       c.Type,
+			Name:       c.Name,
+			Enabled:    c.Enabled,
+			RunbookURL: c.RunbookURL,
+		})
+	}
+
+	syntheticsConditions, err := client.Alerts.ListSyntheticsConditionsWithContext(ctx, policyID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range syntheticsConditions {
+		conditions = append(conditions, alertConditionSummary{
+			ID:         strconv.Itoa(c.ID),
+			Family:     alertConditionFamilySynthetics,
+			Type:       syntheticsConditionType,
+			Name:       c.Name,
+			Enabled:    c.Enabled,
+			RunbookURL: c.RunbookURL,
+		})
+	}
+
+	multiLocationConditions, err := client.Alerts.ListMultiLocationSyntheticsConditionsWithContext(ctx, policyID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range multiLocationConditions {
+		conditions = append(conditions, alertConditionSummary{
+			ID:         strconv.Itoa(c.ID),
+			Family:     alertConditionFamilySyntheticsMultiLocation,
+			Type:       syntheticsMultiLocationConditionType,
+			Name:       c.Name,
+			Enabled:    c.Enabled,
+			RunbookURL: c.RunbookURL,
+		})
+	}
+
+	pluginsConditions, err := client.Alerts.ListPluginsConditionsWithContext(ctx, policyID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range pluginsConditions {
+		conditions = append(conditions, alertConditionSummary{
+			ID:         strconv.Itoa(c.ID),
+			Family:     alertConditionFamilyPlugins,
+			Type:       pluginsConditionType,
+			Name:       c.Name,
+			Enabled:    c.Enabled,
+			RunbookURL: c.RunbookURL,
+		})
+	}
+
+	sort.SliceStable(conditions, func(i, j int) bool {
+		if conditions[i].Family != conditions[j].Family {
+			return conditions[i].Family < conditions[j].Family
+		}
+
+		if conditions[i].Name != conditions[j].Name {
+			return conditions[i].Name < conditions[j].Name
+		}
+
+		return conditions[i].ID < conditions[j].ID
+	})
+
+	return conditions, nil
+}
+
+func flattenAlertConditionSummaries(conditions []alertConditionSummary) []interface{} {
+	flattened := make([]interface{}, len(conditions))
+
+	for i, c := range conditions {
+		flattened[i] = map[string]interface{}{
+			"id":          c.ID,
+			"family":      c.Family,
+			"type":        c.Type,
+			"name":        c.Name,
+			"enabled":     c.Enabled,
+			"runbook_url": c.RunbookURL,
+		}
+	}
+
+	return flattened
+}
@@ -0,0 +1,87 @@
+//go:build integration
+// +build integration
+
+package newrelic
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccNewRelicAlertConditionsDataSource_Basic(t *testing.T) {
+	rName := testAccRandString(t, 5)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNewRelicAlertConditionsDataSourceConfig(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.newrelic_alert_conditions.foo", "conditions.#", "2"),
+					resource.TestCheckResourceAttr("data.newrelic_alert_conditions.foo", "conditions.0.family", "infra"),
+					resource.TestCheckResourceAttr("data.newrelic_alert_conditions.foo", "conditions.0.type", "infra_metric"),
+					resource.TestCheckResourceAttrPair("data.newrelic_alert_conditions.foo", "conditions.0.name", "newrelic_infra_alert_condition.foo", "name"),
+					resource.TestCheckResourceAttr("data.newrelic_alert_conditions.foo", "conditions.0.runbook_url", "https://foo.example.com"),
+					resource.TestCheckResourceAttr("data.newrelic_alert_conditions.foo", "conditions.1.family", "nrql"),
+					resource.TestCheckResourceAttr("data.newrelic_alert_conditions.foo", "conditions.1.type", "STATIC"),
+					resource.TestCheckResourceAttrPair("data.newrelic_alert_conditions.foo", "conditions.1.name", "newrelic_nrql_alert_condition.foo", "name"),
+					resource.TestCheckResourceAttr("data.newrelic_alert_conditions.foo", "conditions.1.enabled", "false"),
+				),
+			},
+		},
+	})
+}
+
+func testAccNewRelicAlertConditionsDataSourceConfig(name string) string {
+	return fmt.Sprintf(`
+resource "newrelic_alert_policy" "foo" {
+  name = "tf-test-%[1]s"
+}
+
+resource "newrelic_nrql_alert_condition" "foo" {
+  policy_id   = newrelic_alert_policy.foo.id
+  name        = "tf-test-%[1]s"
+  enabled     = false
+  runbook_url = "https://foo.example.com"
+
+  nrql {
+    query = "SELECT uniqueCount(hostname) FROM ComputeSample"
+  }
+
+  critical {
+    operator              = "above"
+    threshold             = 0.75
+    threshold_duration    = 120
+    threshold_occurrences = "ALL"
+  }
+}
+
+resource "newrelic_infra_alert_condition" "foo" {
+  policy_id   = newrelic_alert_policy.foo.id
+  name        = "tf-test-%[1]s"
+  runbook_url = "https://foo.example.com"
+  type        = "infra_metric"
+  event       = "StorageSample"
+  select      = "diskFreePercent"
+  comparison  = "below"
+
+  critical {
+    duration      = 10
+    value         = 10
+    time_function = "any"
+  }
+}
+
+data "newrelic_alert_conditions" "foo" {
+  policy_id = newrelic_alert_policy.foo.id
+
+  depends_on = [
+    newrelic_nrql_alert_condition.foo,
+    newrelic_infra_alert_condition.foo,
+  ]
+}
+`, name)
+}
@@ -0,0 +1,57 @@
+//go:build unit
+// +build unit
+
+package newrelic
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataSourceNewRelicAlertConditions_FakeBackend(t *testing.T) {
+	f := newFakeBackend(t)
+	meta := testFakeBackendMeta(t, f)
+
+	f.addAlertCondition("nrql", 1, map[string]interface{}{"name": "b", "enabled": true, "runbookUrl": "https://example.com/b", "type": "STATIC"})
+	f.addAlertCondition("nrql", 1, map[string]interface{}{"name": "a", "enabled": false, "type": "BASELINE"})
+	f.addAlertCondition("nrql", 1, map[string]interface{}{"name": "c", "enabled": true, "type": "OUTLIER"})
+	f.addAlertCondition("nrql", 2, map[string]interface{}{"name": "other policy", "enabled": true, "type": "STATIC"})
+	f.addAlertCondition("apm", 1, map[string]interface{}{"name": "apdex", "enabled": true, "type": "apm_app_metric"})
+	f.addAlertCondition("infra", 1, map[string]interface{}{"name": "cpu", "enabled": true, "type": "infra_metric", "runbook_url": "https://example.com/cpu"})
+	f.addAlertCondition("synthetics", 1, map[string]interface{}{"name": "monitor", "enabled": true})
+	f.addAlertCondition("synthetics_multilocation", 1, map[string]interface{}{"name": "locations", "enabled": false})
+	f.addAlertCondition("plugins", 1, map[string]interface{}{"name": "connections", "enabled": true})
+
+	ds := dataSourceNewRelicAlertConditions()
+	d := schema.TestResourceDataRaw(t, ds.Schema, map[string]interface{}{"policy_id": 1})
+	diags := ds.ReadContext(context.Background(), d, meta)
+	require.False(t, diags.HasError(), "%+v", diags)
+
+	assert.Equal(t, "1", d.Id())
+	assert.Equal(t, fakeBackendAccountID, d.Get("account_id"))
+
+	conditions := d.Get("conditions").([]interface{})
+	require.Len(t, conditions, 8)
+
+	var summaries [][]interface{}
+	for _, raw := range conditions {
+		c := raw.(map[string]interface{})
+		require.NotEmpty(t, c["id"])
+		summaries = append(summaries, []interface{}{c["family"], c["type"], c["name"], c["enabled"], c["runbook_url"]})
+	}
+
+	assert.Equal(t, [][]interface{}{
+		{"apm", "apm_app_metric", "apdex", true, ""},
+		{"infra", "infra_metric", "cpu", true, "https://example.com/cpu"},
+		{"nrql", "BASELINE", "a", false, ""},
+		{"nrql", "STATIC", "b", true, "https://example.com/b"},
+		{"nrql", "OUTLIER", "c", true, ""},
+		{"plugins", "plugins_metric", "connections", true, ""},
+		{"synthetics", "synthetics", "monitor", true, ""},
+		{"synthetics_multilocation", "synthetics_multilocation", "locations", false, ""},
+	}, summaries)
+}
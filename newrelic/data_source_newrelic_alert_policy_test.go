@@ -8,14 +8,13 @@ import (
 	"regexp"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccNewRelicAlertPolicyDataSource_Basic(t *testing.T) {
 	resourceName := "newrelic_alert_policy.foo"
-	rName := acctest.RandString(5)
+	rName := testAccRandString(t, 5)
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:  func() { testAccPreCheck(t) },
@@ -33,7 +32,7 @@ func TestAccNewRelicAlertPolicyDataSource_Basic(t *testing.T) {
 }
 
 func TestAccNewRelicAlertPolicyDataSource_NameExactMatchOnly(t *testing.T) {
-	rName := acctest.RandString(5)
+	rName := testAccRandString(t, 5)
 	expectedErrorMsg := regexp.MustCompile(`the name '.*' does not match any New Relic alert policy`)
 
 	resource.ParallelTest(t, resource.TestCase{
@@ -0,0 +1,90 @@
+package newrelic
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceNewRelicNotificationChannel() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceNewRelicNotificationChannelRead,
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "The New Relic account ID of the channel.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the channel.",
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The type of the channel.",
+			},
+			"destination_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the destination the channel notifies.",
+			},
+			"product": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The product the channel belongs to.",
+			},
+			"active": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the channel is active.",
+			},
+			"property": notificationPropertyDataSourceSchema(),
+		},
+	}
+}
+
+func dataSourceNewRelicNotificationChannelRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+
+	if !providerConfig.hasNerdGraphCredentials() {
+		return diag.Errorf("err: NerdGraph support not present, but required for Read")
+	}
+
+	client := providerConfig.NewClient
+	accountID := selectAccountID(providerConfig, d)
+	name := d.Get("name").(string)
+
+	log.Printf("[INFO] Reading New Relic notification channel %s", name)
+
+	channels, err := getNotificationChannels(ctx, client, accountID, map[string]interface{}{"name": name})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The name filter matches partially.
+	var channel *notificationChannel
+	for i := range channels {
+		if channels[i].Name == name {
+			channel = &channels[i]
+			break
+		}
+	}
+
+	if channel == nil {
+		return diag.FromErr(fmt.Errorf("the name '%s' does not match any New Relic notification channel", name))
+	}
+
+	d.SetId(channel.ID)
+
+	if err := d.Set("account_id", accountID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.FromErr(flattenNotificationChannel(channel, d))
+}
@@ -0,0 +1,118 @@
+package newrelic
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// notificationPropertyDataSourceSchema is the computed counterpart of
+// notificationPropertySchema.
+func notificationPropertyDataSourceSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeSet,
+		Computed:    true,
+		Description: "The key/value properties configuring the notification.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"key": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"value": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"label": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"display_value": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceNewRelicNotificationDestination() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceNewRelicNotificationDestinationRead,
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "The New Relic account ID of the destination.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the destination.",
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The type of the destination.",
+			},
+			"active": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the destination is active.",
+			},
+			"property": notificationPropertyDataSourceSchema(),
+		},
+	}
+}
+
+func dataSourceNewRelicNotificationDestinationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+
+	if !providerConfig.hasNerdGraphCredentials() {
+		return diag.Errorf("err: NerdGraph support not present, but required for Read")
+	}
+
+	client := providerConfig.NewClient
+	accountID := selectAccountID(providerConfig, d)
+	name := d.Get("name").(string)
+
+	log.Printf("[INFO] Reading New Relic notification destination %s", name)
+
+	destinations, err := getNotificationDestinations(ctx, client, accountID, map[string]interface{}{"name": name})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The name filter matches partially.
+	var destination *notificationDestination
+	for i := range destinations {
+		if destinations[i].Name == name {
+			destination = &destinations[i]
+			break
+		}
+	}
+
+	if destination == nil {
+		return diag.FromErr(fmt.Errorf("the name '%s' does not match any New Relic notification destination", name))
+	}
+
+	d.SetId(destination.ID)
+
+	if err := d.Set("account_id", accountID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("type", destination.Type); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("active", destination.Active); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.FromErr(d.Set("property", flattenNotificationProperties(destination.Properties)))
+}
@@ -7,13 +7,12 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 var (
-	expectedMonitorName = fmt.Sprintf("tf-test-synthetic-%s", acctest.RandString(5))
+	expectedMonitorName = fmt.Sprintf("tf-test-synthetic-%s", testAccRandStringFor("expectedMonitorName", 5))
 )
 
 func TestAccNewRelicSyntheticsMonitorDataSource_Basic(t *testing.T) {
@@ -8,12 +8,11 @@ import (
 	"strings"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
 func TestAccNewRelicSyntheticsSecureCredentialDataSource_Basic(t *testing.T) {
-	rand := acctest.RandString(5)
+	rand := testAccRandString(t, 5)
 	rName := fmt.Sprintf("tf_test_%s", rand)
 	resourceName := "data.newrelic_synthetics_secure_credential.foo"
 
@@ -0,0 +1,105 @@
+package newrelic
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceNewRelicWorkflow() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceNewRelicWorkflowRead,
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "The New Relic account ID of the workflow.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the workflow.",
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the workflow is enabled.",
+			},
+			"destinations_enabled": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the destinations of the workflow are notified.",
+			},
+			"enrichments_enabled": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the enrichments of the workflow are run.",
+			},
+			"muting_rules_handling": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "How muted issues are handled.",
+			},
+			"issues_filter_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the filter selecting the issues the workflow handles.",
+			},
+			"channel_ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The IDs of the notification channels the workflow notifies.",
+			},
+			"last_run": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The last time the workflow ran.",
+			},
+		},
+	}
+}
+
+func dataSourceNewRelicWorkflowRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+
+	if !providerConfig.hasNerdGraphCredentials() {
+		return diag.Errorf("err: NerdGraph support not present, but required for Read")
+	}
+
+	client := providerConfig.NewClient
+	accountID := selectAccountID(providerConfig, d)
+	name := d.Get("name").(string)
+
+	log.Printf("[INFO] Reading New Relic workflow %s", name)
+
+	workflows, err := getWorkflows(ctx, client, accountID, map[string]interface{}{"name": name})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The name filter matches partially.
+	var w *workflow
+	for i := range workflows {
+		if workflows[i].Name == name {
+			w = &workflows[i]
+			break
+		}
+	}
+
+	if w == nil {
+		return diag.FromErr(fmt.Errorf("the name '%s' does not match any New Relic workflow", name))
+	}
+
+	d.SetId(w.ID)
+
+	if err := d.Set("account_id", accountID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.FromErr(flattenWorkflowDataSource(w, d))
+}
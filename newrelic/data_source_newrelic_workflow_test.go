@@ -0,0 +1,67 @@
+//go:build integration
+// +build integration
+
+package newrelic
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccNewRelicWorkflowDataSource_Basic(t *testing.T) {
+	rName := testAccRandString(t, 5)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNewRelicWorkflowDataSourceConfig(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.newrelic_notification_destination.foo", "id", "newrelic_notification_destination.foo", "id"),
+					resource.TestCheckResourceAttrPair("data.newrelic_notification_channel.foo", "id", "newrelic_notification_channel.foo", "id"),
+					resource.TestCheckResourceAttrPair("data.newrelic_notification_channel.foo", "destination_id", "newrelic_notification_destination.foo", "id"),
+					resource.TestCheckResourceAttrPair("data.newrelic_workflow.foo", "id", "newrelic_workflow.foo", "id"),
+					resource.TestCheckResourceAttrPair("data.newrelic_workflow.foo", "channel_ids.0", "newrelic_notification_channel.foo", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccNewRelicWorkflowDataSource_NameExactMatchOnly(t *testing.T) {
+	rName := testAccRandString(t, 5)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNewRelicWorkflowConfig(rName, "SELECT count(*) FROM Log") + `
+data "newrelic_workflow" "foo" {
+	name = "${newrelic_workflow.foo.name}-missing"
+}
+`,
+				ExpectError: regexp.MustCompile(`the name '.*' does not match any New Relic workflow`),
+			},
+		},
+	})
+}
+
+func testAccNewRelicWorkflowDataSourceConfig(name string) string {
+	return testAccNewRelicWorkflowConfig(name, "SELECT count(*) FROM Log") + `
+data "newrelic_notification_destination" "foo" {
+	name = newrelic_notification_destination.foo.name
+}
+
+data "newrelic_notification_channel" "foo" {
+	name = newrelic_notification_channel.foo.name
+}
+
+data "newrelic_workflow" "foo" {
+	name = newrelic_workflow.foo.name
+}
+`
+}
@@ -0,0 +1,227 @@
+package newrelic
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/newrelic/newrelic-client-go/pkg/common"
+	"github.com/newrelic/newrelic-client-go/pkg/entities"
+)
+
+// defaultTagsTimeout is how long to wait for changes to the default tags to be
+// indexed before reading the entity back.
+const defaultTagsTimeout = 1 * time.Minute
+
+// defaultTagsSchema returns the computed attribute holding the provider's
+// default tags applied to the entity of a resource. Resources using it must
+// also use customizeDiffDefaultTags.
+func defaultTagsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeMap,
+		Computed:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Description: "The tags from the provider's default_tags that are applied to the entity.",
+	}
+}
+
+func expandProviderDefaultTags(cfg []interface{}) map[string]string {
+	tags := map[string]string{}
+
+	if len(cfg) == 0 || cfg[0] == nil {
+		return tags
+	}
+
+	for k, v := range cfg[0].(map[string]interface{})["tags"].(map[string]interface{}) {
+		tags[k] = v.(string)
+	}
+
+	return tags
+}
+
+// customizeDiffDefaultTags plans the provider's default tags for the entity,
+// which shows drift of the tags on the entity as a diff to reconcile.
+func customizeDiffDefaultTags(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	providerConfig, ok := meta.(*ProviderConfig)
+	if !ok {
+		return nil
+	}
+
+	current := d.Get("default_tags").(map[string]interface{})
+	if defaultTagsEqual(current, providerConfig.DefaultTags) {
+		return nil
+	}
+
+	planned := make(map[string]interface{}, len(providerConfig.DefaultTags))
+	for k, v := range providerConfig.DefaultTags {
+		planned[k] = v
+	}
+
+	return d.SetNew("default_tags", planned)
+}
+
+func defaultTagsEqual(current map[string]interface{}, tags map[string]string) bool {
+	if len(current) != len(tags) {
+		return false
+	}
+
+	for k, v := range tags {
+		if value, ok := current[k]; !ok || value.(string) != v {
+			return false
+		}
+	}
+
+	return true
+}
+
+// readDefaultTags sets the default tags found on the entity. Only the keys of
+// the provider's default tags and of previously applied default tags are read,
+// since any other tag is not owned by the provider.
+func readDefaultTags(ctx context.Context, providerConfig *ProviderConfig, guid common.EntityGUID, d *schema.ResourceData) error {
+	keys := map[string]bool{}
+	for k := range providerConfig.DefaultTags {
+		keys[k] = true
+	}
+	for k := range d.Get("default_tags").(map[string]interface{}) {
+		keys[k] = true
+	}
+
+	applied := map[string]interface{}{}
+
+	if len(keys) > 0 {
+		tags, err := providerConfig.NewClient.Entities.GetTagsForEntityWithContext(ctx, guid)
+		if err != nil {
+			return err
+		}
+
+		for _, t := range tags {
+			if keys[t.Key] {
+				applied[t.Key] = strings.Join(t.Values, ",")
+			}
+		}
+	}
+
+	return d.Set("default_tags", applied)
+}
+
+// updateDefaultTags applies the planned default tags to the entity. Tags
+// which were removed from default_tags or whose value changed are deleted
+// first, the other tags on the entity are left untouched.
+func updateDefaultTags(ctx context.Context, providerConfig *ProviderConfig, guid common.EntityGUID, d *schema.ResourceData) error {
+	if !d.HasChange("default_tags") {
+		return nil
+	}
+
+	client := providerConfig.NewClient
+
+	o, n := d.GetChange("default_tags")
+	oldTags := o.(map[string]interface{})
+	newTags := n.(map[string]interface{})
+
+	var deleteKeys []string
+	for k, v := range oldTags {
+		if value, ok := newTags[k]; !ok || value != v {
+			deleteKeys = append(deleteKeys, k)
+		}
+	}
+
+	var addTags []entities.TaggingTagInput
+	for k, v := range newTags {
+		if value, ok := oldTags[k]; !ok || value != v {
+			addTags = append(addTags, entities.TaggingTagInput{Key: k, Values: []string{v.(string)}})
+		}
+	}
+
+	sort.Strings(deleteKeys)
+	sort.Slice(addTags, func(i, j int) bool { return addTags[i].Key < addTags[j].Key })
+
+	log.Printf("[INFO] Updating default tags for entity guid %s", guid)
+
+	// A new entity might not be indexed yet, so failed mutations are retried.
+	if len(deleteKeys) > 0 {
+		err := resource.RetryContext(ctx, defaultTagsTimeout, func() *resource.RetryError {
+			result, err := client.Entities.TaggingDeleteTagFromEntityWithContext(ctx, guid, deleteKeys)
+			if err := taggingMutationError(result, err); err != nil {
+				return resource.RetryableError(fmt.Errorf("error deleting default tags from entity guid %s: %s", guid, err))
+			}
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(addTags) > 0 {
+		err := resource.RetryContext(ctx, defaultTagsTimeout, func() *resource.RetryError {
+			result, err := client.Entities.TaggingAddTagsToEntityWithContext(ctx, guid, addTags)
+			if err := taggingMutationError(result, err); err != nil {
+				return resource.RetryableError(fmt.Errorf("error adding default tags to entity guid %s: %s", guid, err))
+			}
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return resource.RetryContext(ctx, defaultTagsTimeout, func() *resource.RetryError {
+		t, err := client.Entities.GetTagsForEntityWithContext(ctx, guid)
+		if err != nil {
+			return resource.NonRetryableError(fmt.Errorf("error retrieving entity tags for guid %s: %s", guid, err))
+		}
+
+		currentTags := convertTagTypes(t)
+
+		for _, k := range deleteKeys {
+			if _, ok := newTags[k]; !ok && getTag(currentTags, k) != nil {
+				return resource.RetryableError(fmt.Errorf("expected entity tag %s to have been deleted but was found", k))
+			}
+		}
+
+		for _, t := range addTags {
+			var tag *entities.TaggingTagInput
+			if tag = getTag(currentTags, t.Key); tag == nil {
+				return resource.RetryableError(fmt.Errorf("expected entity tag %s to have been updated but was not found", t.Key))
+			}
+
+			if ok := tagValuesExist(tag, t.Values); !ok {
+				return resource.RetryableError(fmt.Errorf("expected entity tag values %s to have been updated for tag %s but were not found", t.Values, t.Key))
+			}
+		}
+
+		return nil
+	})
+}
+
+func taggingMutationError(result *entities.TaggingMutationResult, err error) error {
+	if err != nil {
+		return err
+	}
+
+	if result == nil || len(result.Errors) == 0 {
+		return nil
+	}
+
+	var messages []string
+	for _, e := range result.Errors {
+		messages = append(messages, fmt.Sprintf("%s: %s", e.Type, e.Message))
+	}
+
+	return fmt.Errorf("%s", strings.Join(messages, ", "))
+}
+
+// getEntityGUID builds the GUID of an entity which is only known by its
+// domain specific ID, such as a Synthetics monitor or an APM application.
+func getEntityGUID(accountID int, domain string, entityType string, domainID string) common.EntityGUID {
+	id := fmt.Sprintf("%d|%s|%s|%s", accountID, domain, entityType, domainID)
+
+	return common.EntityGUID(base64.RawStdEncoding.EncodeToString([]byte(id)))
+}
@@ -0,0 +1,185 @@
+//go:build unit
+// +build unit
+
+package newrelic
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/newrelic/newrelic-client-go/pkg/common"
+	"github.com/newrelic/newrelic-client-go/pkg/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testDefaultTagsResource is a minimal resource for an entity with the GUID
+// "guid", which manages its default tags like the provider's resources do.
+func testDefaultTagsResource() *schema.Resource {
+	apply := func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		d.SetId("guid")
+
+		if err := updateDefaultTags(ctx, meta.(*ProviderConfig), common.EntityGUID(d.Id()), d); err != nil {
+			return diag.FromErr(err)
+		}
+
+		return diag.FromErr(readDefaultTags(ctx, meta.(*ProviderConfig), common.EntityGUID(d.Id()), d))
+	}
+
+	return &schema.Resource{
+		CreateContext: apply,
+		UpdateContext: apply,
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			return diag.FromErr(readDefaultTags(ctx, meta.(*ProviderConfig), common.EntityGUID(d.Id()), d))
+		},
+		DeleteContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			return nil
+		},
+		CustomizeDiff: customizeDiffDefaultTags,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"default_tags": defaultTagsSchema(),
+		},
+	}
+}
+
+func TestDefaultTags_Reconcile(t *testing.T) {
+	f := newFakeBackend(t)
+	meta := testFakeBackendMeta(t, f)
+	r := testDefaultTagsResource()
+	config := map[string]interface{}{"name": "test"}
+
+	f.tags["guid"] = map[string][]string{"owner": {"someone"}}
+	meta.DefaultTags = map[string]string{"team": "a", "managed-by": "terraform"}
+
+	state := testFakeBackendApply(t, meta, r, nil, config)
+	assert.Equal(t, []string{"add:managed-by", "add:team"}, f.takeOperations(true))
+	assert.Equal(t, "a", state.Attributes["default_tags.team"])
+	assert.Equal(t, "terraform", state.Attributes["default_tags.managed-by"])
+
+	// Nothing changes when the tags are in sync.
+	assert.Nil(t, testFakeBackendPlan(t, meta, r, state, config))
+
+	// Changing the default tags replaces only the provider's tags.
+	meta.DefaultTags = map[string]string{"team": "b"}
+
+	state = testFakeBackendApply(t, meta, r, state, config)
+	assert.Equal(t, []string{"add:team", "delete:managed-by", "delete:team"}, f.takeOperations(true))
+	assert.Equal(t, map[string][]string{"owner": {"someone"}, "team": {"b"}}, f.tags["guid"])
+	assert.Equal(t, "1", state.Attributes["default_tags.%"])
+
+	// Drift of a default tag is reconciled.
+	f.tags["guid"]["team"] = []string{"c"}
+
+	state, diags := r.RefreshWithoutUpgrade(context.Background(), state, meta)
+	require.False(t, diags.HasError())
+	assert.Equal(t, "c", state.Attributes["default_tags.team"])
+
+	state = testFakeBackendApply(t, meta, r, state, config)
+	assert.Equal(t, []string{"add:team", "delete:team"}, f.takeOperations(true))
+	assert.Equal(t, map[string][]string{"owner": {"someone"}, "team": {"b"}}, f.tags["guid"])
+	assert.Equal(t, "b", state.Attributes["default_tags.team"])
+}
+
+func TestDefaultTags_Workload_FakeBackend(t *testing.T) {
+	f := newFakeBackend(t)
+	resourceName := "newrelic_workload.foo"
+
+	providerBlock := func(team string) string {
+		return fmt.Sprintf(`
+provider "newrelic" {
+  account_id         = %[1]d
+  api_key            = "NRAK-FAKE"
+  region             = "US"
+  nerdgraph_api_url  = "%[2]s/graphql"
+  api_url            = "%[2]s/v2"
+  synthetics_api_url = "%[2]s/synthetics"
+
+  default_tags {
+    tags = {
+      team = "%[3]s"
+    }
+  }
+}
+`, fakeBackendAccountID, f.server.URL, team)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: f.providerFactories(),
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: providerBlock("a") + testFakeNewRelicWorkloadConfig("tf-test", "name like 'tf-test'"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "default_tags.team", "a"),
+				),
+			},
+			// Test: Update the default tags
+			{
+				Config: providerBlock("b") + testFakeNewRelicWorkloadConfig("tf-test", "name like 'tf-test'"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "default_tags.team", "b"),
+				),
+			},
+			// Test: Drift of the default tags is reconciled
+			{
+				PreConfig: func() {
+					f.Lock()
+					defer f.Unlock()
+
+					for _, tags := range f.tags {
+						tags["team"] = []string{"c"}
+					}
+				},
+				Config: providerBlock("b") + testFakeNewRelicWorkloadConfig("tf-test", "name like 'tf-test'"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "default_tags.team", "b"),
+				),
+			},
+		},
+	})
+}
+
+func TestExpandProviderDefaultTags(t *testing.T) {
+	assert.Equal(t, map[string]string{}, expandProviderDefaultTags([]interface{}{}))
+
+	tags := expandProviderDefaultTags([]interface{}{
+		map[string]interface{}{
+			"tags": map[string]interface{}{"team": "a"},
+		},
+	})
+	assert.Equal(t, map[string]string{"team": "a"}, tags)
+}
+
+func TestWithoutProviderDefaultTags(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceNewRelicEntityTags().Schema, map[string]interface{}{
+		"guid": "guid",
+		"tag": []interface{}{
+			map[string]interface{}{"key": "team", "values": []interface{}{"a"}},
+		},
+	})
+
+	providerConfig := &ProviderConfig{DefaultTags: map[string]string{"team": "a", "managed-by": "terraform"}}
+
+	tags := withoutProviderDefaultTags([]*entities.TaggingTagInput{
+		{Key: "team", Values: []string{"a"}},
+		{Key: "managed-by", Values: []string{"terraform"}},
+		{Key: "owner", Values: []string{"someone"}},
+	}, providerConfig, d)
+
+	assert.Equal(t, []*entities.TaggingTagInput{
+		{Key: "team", Values: []string{"a"}},
+		{Key: "owner", Values: []string{"someone"}},
+	}, tags)
+}
+
+func TestGetEntityGUID(t *testing.T) {
+	assert.Equal(t, common.EntityGUID("MXxTWU5USHxNT05JVE9SfGFiYw"), getEntityGUID(1, "SYNTH", "MONITOR", "abc"))
+}
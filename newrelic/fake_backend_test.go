@@ -0,0 +1,1406 @@
+//go:build unit
+// +build unit
+
+package newrelic
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeBackend is an in-memory New Relic backend serving the NerdGraph, REST
+// Synthetics and Infrastructure APIs used by the resources covered by unit
+// tests. The provider is pointed at it through the nerdgraph_api_url, api_url,
+// synthetics_api_url and infrastructure_api_url overrides, see providerBlock.
+//
+// Only the operations exercised by the tests are implemented, any other
+// request fails the test.
+type fakeBackend struct {
+	sync.Mutex
+	t      *testing.T
+	server *httptest.Server
+	nextID int
+
+	// NerdGraph
+	policies                 map[string]map[string]interface{}
+	workloads                map[string]map[string]interface{}
+	tags                     map[string]map[string][]string
+	notificationDestinations map[string]map[string]interface{}
+	notificationChannels     map[string]map[string]interface{}
+	workflows                map[string]map[string]interface{}
+	serviceLevels            map[string]map[string]interface{}
+	mutingRules              map[string]map[string]interface{}
+	dashboards               map[string]map[string]interface{}
+
+	// dashboardWidgetDefaults are added to the raw configuration of the
+	// dashboard widgets which leave them out, as the API does.
+	dashboardWidgetDefaults map[string]interface{}
+
+	// NerdGraph and REST, by condition family
+	alertConditions map[string][]map[string]interface{}
+
+	// REST
+	applications   map[int]map[string]interface{}
+	channels       map[int]map[string]interface{}
+	policyChannels map[int]map[int]bool
+
+	// Synthetics
+	monitors map[string]map[string]interface{}
+
+	// operations are the NRQL condition and tagging mutations received, for
+	// the tests checking which ones the provider sent.
+	operations []string
+}
+
+const fakeBackendAccountID = 1
+
+func newFakeBackend(t *testing.T) *fakeBackend {
+	f := &fakeBackend{
+		t:                        t,
+		nextID:                   100,
+		policies:                 map[string]map[string]interface{}{},
+		workloads:                map[string]map[string]interface{}{},
+		tags:                     map[string]map[string][]string{},
+		notificationDestinations: map[string]map[string]interface{}{},
+		notificationChannels:     map[string]map[string]interface{}{},
+		workflows:                map[string]map[string]interface{}{},
+		serviceLevels:            map[string]map[string]interface{}{},
+		mutingRules:              map[string]map[string]interface{}{},
+		dashboards:               map[string]map[string]interface{}{},
+		alertConditions:          map[string][]map[string]interface{}{},
+		applications:             map[int]map[string]interface{}{},
+		channels:                 map[int]map[string]interface{}{},
+		policyChannels:           map[int]map[int]bool{},
+		monitors:                 map[string]map[string]interface{}{},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/graphql", f.serveNerdGraph)
+	mux.HandleFunc("/v2/", f.serveApplications)
+	mux.HandleFunc("/v2/alerts_channels.json", f.serveAlertChannels)
+	mux.HandleFunc("/v2/alerts_channels/", f.serveAlertChannels)
+	mux.HandleFunc("/v2/alerts_policy_channels.json", f.servePolicyChannels)
+	mux.HandleFunc("/v2/alerts_conditions.json", f.serveAlertConditions)
+	mux.HandleFunc("/v2/alerts_synthetics_conditions.json", f.serveAlertConditions)
+	mux.HandleFunc("/v2/alerts_location_failure_conditions/policies/", f.serveAlertConditions)
+	mux.HandleFunc("/v2/alerts_plugins_conditions.json", f.serveAlertConditions)
+	mux.HandleFunc("/infrastructure/alerts/conditions", f.serveAlertConditions)
+	mux.HandleFunc("/synthetics/v4/monitors", f.serveMonitors)
+	mux.HandleFunc("/synthetics/v4/monitors/", f.serveMonitors)
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		f.unsupported(w, r.Method+" "+r.URL.Path)
+	})
+
+	f.server = httptest.NewServer(mux)
+	t.Cleanup(f.server.Close)
+
+	return f
+}
+
+// providerBlock returns the provider configuration pointing at the backend.
+func (f *fakeBackend) providerBlock() string {
+	return fmt.Sprintf(`
+provider "newrelic" {
+  account_id             = %[1]d
+  api_key                = "NRAK-FAKE"
+  region                 = "US"
+  nerdgraph_api_url      = "%[2]s/graphql"
+  api_url                = "%[2]s/v2"
+  synthetics_api_url     = "%[2]s/synthetics"
+  infrastructure_api_url = "%[2]s/infrastructure"
+}
+`, fakeBackendAccountID, f.server.URL)
+}
+
+// providerConfig returns the raw provider configuration pointing at the backend.
+func (f *fakeBackend) providerConfig() map[string]interface{} {
+	return map[string]interface{}{
+		"account_id":             fakeBackendAccountID,
+		"api_key":                "NRAK-FAKE",
+		"region":                 "US",
+		"nerdgraph_api_url":      f.server.URL + "/graphql",
+		"api_url":                f.server.URL + "/v2",
+		"synthetics_api_url":     f.server.URL + "/synthetics",
+		"infrastructure_api_url": f.server.URL + "/infrastructure",
+	}
+}
+
+// providerFactories returns a new provider per test, so that tests running in
+// parallel don't share the configured clients.
+func (f *fakeBackend) providerFactories() map[string]func() (*schema.Provider, error) {
+	return map[string]func() (*schema.Provider, error){
+		"newrelic": func() (*schema.Provider, error) {
+			return Provider(), nil
+		},
+	}
+}
+
+func (f *fakeBackend) id() int {
+	f.nextID++
+	return f.nextID
+}
+
+// uuid returns a new UUID, as identifies the notification and workflow
+// resources.
+func (f *fakeBackend) uuid() string {
+	return fmt.Sprintf("00000000-0000-4000-8000-%012d", f.id())
+}
+
+func (f *fakeBackend) unsupported(w http.ResponseWriter, operation string) {
+	f.t.Errorf("fake backend: unsupported operation %s", operation)
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+//
+// NerdGraph
+//
+
+func (f *fakeBackend) serveNerdGraph(w http.ResponseWriter, r *http.Request) {
+	f.Lock()
+	defer f.Unlock()
+
+	var req struct {
+		Query     string                 `json:"query"`
+		Variables map[string]interface{} `json:"variables"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	vars := req.Variables
+
+	var data interface{}
+	var errs []interface{}
+
+	switch {
+	// Alert policies
+	case strings.Contains(req.Query, "alertsPolicyCreate("):
+		policy := vars["policy"].(map[string]interface{})
+		policy["id"] = strconv.Itoa(f.id())
+		policy["accountId"] = vars["accountID"]
+		f.policies[policy["id"].(string)] = policy
+		data = map[string]interface{}{"alertsPolicyCreate": policy}
+	case strings.Contains(req.Query, "alertsPolicyUpdate("):
+		policy, ok := f.policies[vars["policyID"].(string)]
+		if !ok {
+			errs = fakeNerdGraphNotFound()
+			break
+		}
+		for k, v := range vars["policy"].(map[string]interface{}) {
+			policy[k] = v
+		}
+		data = map[string]interface{}{"alertsPolicyUpdate": policy}
+	case strings.Contains(req.Query, "alertsPolicyDelete("):
+		delete(f.policies, vars["policyID"].(string))
+		for _, policyIDs := range f.policyChannels {
+			id, _ := strconv.Atoi(vars["policyID"].(string))
+			delete(policyIDs, id)
+		}
+		data = map[string]interface{}{"alertsPolicyDelete": map[string]interface{}{"id": vars["policyID"]}}
+	case strings.Contains(req.Query, "policy(id:"):
+		policy, ok := f.policies[vars["policyID"].(string)]
+		if !ok {
+			errs = fakeNerdGraphNotFound()
+			break
+		}
+		data = fakeNerdGraphAccountAlerts("policy", policy)
+
+	// Alert channels
+	case strings.Contains(req.Query, "notificationChannel(id:"):
+		id, _ := strconv.Atoi(fmt.Sprint(vars["id"]))
+		if f.channels[id] == nil {
+			errs = fakeNerdGraphNotFound()
+			break
+		}
+		f.operations = append(f.operations, "read:"+strconv.Itoa(id))
+		policyIDs := []int{}
+		for policyID := range f.policyChannels[id] {
+			policyIDs = append(policyIDs, policyID)
+		}
+		sortIntegerSlice(policyIDs)
+		policies := []interface{}{}
+		for _, policyID := range policyIDs {
+			policies = append(policies, map[string]interface{}{"id": strconv.Itoa(policyID)})
+		}
+		data = fakeNerdGraphAccountAlerts("notificationChannel", map[string]interface{}{
+			"associatedPolicies": map[string]interface{}{"policies": policies},
+		})
+
+	// NRQL conditions
+	case strings.Contains(req.Query, "nrqlCondition(id:"):
+		condition := f.nrqlCondition(fmt.Sprint(vars["id"]))
+		if condition == nil {
+			errs = fakeNerdGraphNotFound()
+			break
+		}
+		data = fakeNerdGraphAccountAlerts("nrqlCondition", condition)
+	case strings.Contains(req.Query, "alertsNrqlCondition"):
+		data, errs = f.mutateNrqlCondition(req.Query, vars)
+		if data == nil && errs == nil {
+			f.unsupported(w, "NerdGraph "+req.Query)
+			return
+		}
+	case strings.Contains(req.Query, "alertsConditionDelete("):
+		id := fmt.Sprint(vars["id"])
+		conditions := []map[string]interface{}{}
+		for _, c := range f.alertConditions["nrql"] {
+			if c["id"] != id {
+				conditions = append(conditions, c)
+			}
+		}
+		f.alertConditions["nrql"] = conditions
+		f.operations = append(f.operations, "delete:"+id)
+		data = map[string]interface{}{"alertsConditionDelete": map[string]interface{}{"id": id}}
+
+	// Alert channels
+	case strings.Contains(req.Query, "alertsNotificationChannelUpdate("):
+		id, _ := strconv.Atoi(vars["id"].(string))
+		channel, ok := f.channels[id]
+		if !ok {
+			data = map[string]interface{}{"alertsNotificationChannelUpdate": map[string]interface{}{
+				"error": map[string]interface{}{"description": "Not found", "errorType": "NOT_FOUND"},
+			}}
+			break
+		}
+		f.updateChannel(channel, vars["notificationChannel"].(map[string]interface{}))
+		data = map[string]interface{}{"alertsNotificationChannelUpdate": map[string]interface{}{
+			"notificationChannel": map[string]interface{}{"id": vars["id"]},
+		}}
+
+	// Notification destinations
+	case strings.Contains(req.Query, "aiNotificationsCreateDestination("):
+		input := vars["destination"].(map[string]interface{})
+		destination := map[string]interface{}{
+			"id":     f.uuid(),
+			"type":   input["type"],
+			"active": true,
+		}
+		f.updateNotificationDestination(destination, input)
+		f.notificationDestinations[destination["id"].(string)] = destination
+		data = map[string]interface{}{"aiNotificationsCreateDestination": map[string]interface{}{"destination": destination}}
+	case strings.Contains(req.Query, "aiNotificationsUpdateDestination("):
+		destination, ok := f.notificationDestinations[vars["destinationId"].(string)]
+		if !ok {
+			data = fakeNotificationsNotFound("aiNotificationsUpdateDestination")
+			break
+		}
+		f.updateNotificationDestination(destination, vars["destination"].(map[string]interface{}))
+		data = map[string]interface{}{"aiNotificationsUpdateDestination": map[string]interface{}{"destination": destination}}
+	case strings.Contains(req.Query, "aiNotificationsDeleteDestination("):
+		delete(f.notificationDestinations, vars["destinationId"].(string))
+		data = map[string]interface{}{"aiNotificationsDeleteDestination": map[string]interface{}{"ids": []interface{}{vars["destinationId"]}}}
+	case strings.Contains(req.Query, "destinations(filters:"):
+		data = fakeNotificationsEntities("destinations", fakeFilterEntities(f.notificationDestinations, vars["filters"]), vars["cursor"])
+
+	// Notification channels
+	case strings.Contains(req.Query, "aiNotificationsCreateChannel("):
+		input := vars["channel"].(map[string]interface{})
+		if _, ok := f.notificationDestinations[input["destinationId"].(string)]; !ok {
+			data = fakeNotificationsNotFound("aiNotificationsCreateChannel")
+			break
+		}
+		channel := map[string]interface{}{
+			"id":            f.uuid(),
+			"type":          input["type"],
+			"product":       input["product"],
+			"destinationId": input["destinationId"],
+			"active":        true,
+		}
+		f.updateNotificationChannel(channel, input)
+		f.notificationChannels[channel["id"].(string)] = channel
+		data = map[string]interface{}{"aiNotificationsCreateChannel": map[string]interface{}{"channel": channel}}
+	case strings.Contains(req.Query, "aiNotificationsUpdateChannel("):
+		channel, ok := f.notificationChannels[vars["channelId"].(string)]
+		if !ok {
+			data = fakeNotificationsNotFound("aiNotificationsUpdateChannel")
+			break
+		}
+		f.updateNotificationChannel(channel, vars["channel"].(map[string]interface{}))
+		data = map[string]interface{}{"aiNotificationsUpdateChannel": map[string]interface{}{"channel": channel}}
+	case strings.Contains(req.Query, "aiNotificationsDeleteChannel("):
+		delete(f.notificationChannels, vars["channelId"].(string))
+		data = map[string]interface{}{"aiNotificationsDeleteChannel": map[string]interface{}{"ids": []interface{}{vars["channelId"]}}}
+	case strings.Contains(req.Query, "channels(filters:"):
+		data = fakeNotificationsEntities("channels", fakeFilterEntities(f.notificationChannels, vars["filters"]), vars["cursor"])
+
+	// Workflows
+	case strings.Contains(req.Query, "aiWorkflowsCreateWorkflow("):
+		w := map[string]interface{}{
+			"id":        f.uuid(),
+			"accountId": vars["accountId"],
+			"lastRun":   "",
+		}
+		f.updateWorkflow(w, vars["createWorkflowData"].(map[string]interface{}))
+		f.workflows[w["id"].(string)] = w
+		data = map[string]interface{}{"aiWorkflowsCreateWorkflow": map[string]interface{}{"workflow": w, "errors": []interface{}{}}}
+	case strings.Contains(req.Query, "aiWorkflowsUpdateWorkflow("):
+		input := vars["updateWorkflowData"].(map[string]interface{})
+		w, ok := f.workflows[input["id"].(string)]
+		if !ok {
+			data = map[string]interface{}{"aiWorkflowsUpdateWorkflow": map[string]interface{}{"errors": []interface{}{
+				map[string]interface{}{"description": "Workflow not found", "type": "INVALID_PARAMETER"},
+			}}}
+			break
+		}
+		f.updateWorkflow(w, input)
+		data = map[string]interface{}{"aiWorkflowsUpdateWorkflow": map[string]interface{}{"workflow": w, "errors": []interface{}{}}}
+	case strings.Contains(req.Query, "aiWorkflowsDeleteWorkflow("):
+		delete(f.workflows, vars["id"].(string))
+		data = map[string]interface{}{"aiWorkflowsDeleteWorkflow": map[string]interface{}{"id": vars["id"], "errors": []interface{}{}}}
+	case strings.Contains(req.Query, "workflows(filters:"):
+		data = map[string]interface{}{"actor": map[string]interface{}{"account": map[string]interface{}{
+			"aiWorkflows": map[string]interface{}{"workflows": fakeEntitiesPage(fakeFilterEntities(f.workflows, vars["filters"]), vars["cursor"])},
+		}}}
+
+	// Service levels
+	case strings.Contains(req.Query, "serviceLevelCreate("):
+		input := vars["indicator"].(map[string]interface{})
+		events := input["events"].(map[string]interface{})
+		id := strconv.Itoa(f.id())
+		indicator := map[string]interface{}{
+			"id":         id,
+			"entityGuid": vars["entityGuid"],
+			"events":     map[string]interface{}{"account": map[string]interface{}{"id": events["accountId"]}},
+			"resultQueries": map[string]interface{}{
+				"indicator":   map[string]interface{}{"nrql": fmt.Sprintf("SELECT clamp_max(sum(newrelic.sli.good) / sum(newrelic.sli.valid) * 100, 100) FROM Metric WHERE sli.id = '%s'", id)},
+				"validEvents": map[string]interface{}{"nrql": fmt.Sprintf("SELECT count(*) FROM %s", events["validEvents"].(map[string]interface{})["from"])},
+			},
+		}
+		f.updateServiceLevel(indicator, input)
+		f.serviceLevels[id] = indicator
+		data = map[string]interface{}{"serviceLevelCreate": indicator}
+	case strings.Contains(req.Query, "serviceLevelUpdate("):
+		indicator, ok := f.serviceLevels[vars["id"].(string)]
+		if !ok {
+			errs = fakeNerdGraphNotFound()
+			break
+		}
+		f.updateServiceLevel(indicator, vars["indicator"].(map[string]interface{}))
+		data = map[string]interface{}{"serviceLevelUpdate": indicator}
+	case strings.Contains(req.Query, "serviceLevelDelete("):
+		indicator, ok := f.serviceLevels[vars["id"].(string)]
+		if !ok {
+			errs = fakeNerdGraphNotFound()
+			break
+		}
+		delete(f.serviceLevels, vars["id"].(string))
+		data = map[string]interface{}{"serviceLevelDelete": indicator}
+	case strings.Contains(req.Query, "serviceLevel {"):
+		indicators := []interface{}{}
+		for _, indicator := range f.serviceLevels {
+			if indicator["entityGuid"] == vars["entityGuid"] {
+				indicators = append(indicators, indicator)
+			}
+		}
+		data = map[string]interface{}{"actor": map[string]interface{}{"entity": map[string]interface{}{
+			"serviceLevel": map[string]interface{}{"indicators": indicators},
+		}}}
+
+	// Workloads
+	case strings.Contains(req.Query, "workloadCreate("):
+		id := f.id()
+		guid := base64.RawStdEncoding.EncodeToString([]byte(fmt.Sprintf("%v|NR1|WORKLOAD|%d", vars["accountId"], id)))
+		workload := map[string]interface{}{
+			"account":   map[string]interface{}{"id": vars["accountId"], "name": "fake"},
+			"guid":      guid,
+			"id":        id,
+			"permalink": fmt.Sprintf("https://one.newrelic.com/redirect/entity/%s", guid),
+		}
+		f.updateWorkload(workload, vars["workload"].(map[string]interface{}))
+		f.workloads[guid] = workload
+		data = map[string]interface{}{"workloadCreate": workload}
+	case strings.Contains(req.Query, "workloadUpdate("):
+		workload, ok := f.workloads[vars["guid"].(string)]
+		if !ok {
+			errs = fakeNerdGraphNotFound()
+			break
+		}
+		f.updateWorkload(workload, vars["workload"].(map[string]interface{}))
+		data = map[string]interface{}{"workloadUpdate": workload}
+	case strings.Contains(req.Query, "workloadDelete("):
+		workload := f.workloads[vars["guid"].(string)]
+		delete(f.workloads, vars["guid"].(string))
+		data = map[string]interface{}{"workloadDelete": workload}
+	case strings.Contains(req.Query, "collection(guid:"):
+		data = map[string]interface{}{"actor": map[string]interface{}{"account": map[string]interface{}{
+			"workload": map[string]interface{}{"collection": f.workloads[vars["guid"].(string)]},
+		}}}
+
+	// Muting rules
+	case strings.Contains(req.Query, "alertsMutingRuleCreate("):
+		rule := vars["rule"].(map[string]interface{})
+		rule["id"] = strconv.Itoa(f.id())
+		rule["accountId"] = vars["accountID"]
+		f.mutingRules[rule["id"].(string)] = rule
+		data = map[string]interface{}{"alertsMutingRuleCreate": rule}
+	case strings.Contains(req.Query, "alertsMutingRuleUpdate("):
+		rule, ok := f.mutingRules[fmt.Sprint(vars["ruleID"])]
+		if !ok {
+			errs = fakeNerdGraphNotFound()
+			break
+		}
+		for k, v := range vars["rule"].(map[string]interface{}) {
+			rule[k] = v
+		}
+		data = map[string]interface{}{"alertsMutingRuleUpdate": rule}
+	case strings.Contains(req.Query, "alertsMutingRuleDelete("):
+		delete(f.mutingRules, fmt.Sprint(vars["ruleID"]))
+		data = map[string]interface{}{"alertsMutingRuleDelete": map[string]interface{}{"id": fmt.Sprint(vars["ruleID"])}}
+	case strings.Contains(req.Query, "mutingRule(id:"):
+		rule, ok := f.mutingRules[fmt.Sprint(vars["ruleID"])]
+		if !ok {
+			errs = fakeNerdGraphNotFound()
+			break
+		}
+		data = map[string]interface{}{"actor": map[string]interface{}{"account": map[string]interface{}{
+			"alerts": map[string]interface{}{"mutingRule": rule},
+		}}}
+
+	// Dashboards
+	case strings.Contains(req.Query, "dashboardCreate("):
+		guid := base64.RawStdEncoding.EncodeToString([]byte(fmt.Sprintf("%v|VIZ|DASHBOARD|%d", vars["accountId"], f.id())))
+		dashboard := map[string]interface{}{
+			"__typename": "DashboardEntity",
+			"accountId":  vars["accountId"],
+			"guid":       guid,
+			"permalink":  fmt.Sprintf("https://one.newrelic.com/redirect/entity/%s", guid),
+		}
+		f.updateDashboard(dashboard, vars["dashboard"].(map[string]interface{}))
+		f.dashboards[guid] = dashboard
+		data = map[string]interface{}{"dashboardCreate": map[string]interface{}{"entityResult": dashboard, "errors": []interface{}{}}}
+	case strings.Contains(req.Query, "dashboardUpdate("):
+		dashboard, ok := f.dashboards[vars["guid"].(string)]
+		if !ok {
+			errs = fakeNerdGraphNotFound()
+			break
+		}
+		f.updateDashboard(dashboard, vars["dashboard"].(map[string]interface{}))
+		data = map[string]interface{}{"dashboardUpdate": map[string]interface{}{"entityResult": dashboard, "errors": []interface{}{}}}
+	case strings.Contains(req.Query, "dashboardDelete("):
+		delete(f.dashboards, vars["guid"].(string))
+		data = map[string]interface{}{"dashboardDelete": map[string]interface{}{"status": "SUCCESS", "errors": []interface{}{}}}
+	case strings.Contains(req.Query, "... on DashboardEntity"):
+		var entity interface{}
+		if dashboard, ok := f.dashboards[vars["guid"].(string)]; ok {
+			entity = dashboard
+		}
+		data = map[string]interface{}{"actor": map[string]interface{}{"entity": entity}}
+
+	// NRQL conditions, one per page to exercise the pagination
+	case strings.Contains(req.Query, "nrqlConditionsSearch("):
+		policyID := vars["searchCriteria"].(map[string]interface{})["policyId"]
+		conditions := []interface{}{}
+		for _, c := range f.alertConditions["nrql"] {
+			if c["policyId"] == policyID {
+				conditions = append(conditions, c)
+			}
+		}
+		page, _ := strconv.Atoi(fmt.Sprint(vars["cursor"]))
+		search := map[string]interface{}{"nrqlConditions": []interface{}{}, "nextCursor": nil}
+		if page < len(conditions) {
+			search["nrqlConditions"] = conditions[page : page+1]
+		}
+		if page+1 < len(conditions) {
+			search["nextCursor"] = strconv.Itoa(page + 1)
+		}
+		data = map[string]interface{}{"actor": map[string]interface{}{"account": map[string]interface{}{
+			"alerts": map[string]interface{}{"nrqlConditionsSearch": search},
+		}}}
+
+	// Entity tags
+	case strings.Contains(req.Query, "taggingAddTagsToEntity("):
+		tags := f.entityTags(vars["guid"].(string))
+		for _, raw := range vars["tags"].([]interface{}) {
+			tag := raw.(map[string]interface{})
+			for _, v := range tag["values"].([]interface{}) {
+				tags[tag["key"].(string)] = append(tags[tag["key"].(string)], v.(string))
+			}
+			f.operations = append(f.operations, "add:"+tag["key"].(string))
+		}
+		data = map[string]interface{}{"taggingAddTagsToEntity": map[string]interface{}{"errors": []interface{}{}}}
+	case strings.Contains(req.Query, "taggingReplaceTagsOnEntity("):
+		tags := map[string][]string{}
+		for _, raw := range vars["tags"].([]interface{}) {
+			tag := raw.(map[string]interface{})
+			for _, v := range tag["values"].([]interface{}) {
+				tags[tag["key"].(string)] = append(tags[tag["key"].(string)], v.(string))
+			}
+		}
+		f.tags[vars["guid"].(string)] = tags
+		data = map[string]interface{}{"taggingReplaceTagsOnEntity": map[string]interface{}{"errors": []interface{}{}}}
+	case strings.Contains(req.Query, "taggingDeleteTagFromEntity("):
+		tags := f.entityTags(vars["guid"].(string))
+		for _, key := range vars["tagKeys"].([]interface{}) {
+			delete(tags, key.(string))
+			f.operations = append(f.operations, "delete:"+key.(string))
+		}
+		data = map[string]interface{}{"taggingDeleteTagFromEntity": map[string]interface{}{"errors": []interface{}{}}}
+	case strings.Contains(req.Query, "entity(guid:") && strings.Contains(req.Query, "tags"):
+		tags := []interface{}{}
+		for k, v := range f.entityTags(vars["guid"].(string)) {
+			tags = append(tags, map[string]interface{}{"key": k, "values": v})
+		}
+		data = map[string]interface{}{"actor": map[string]interface{}{"entity": map[string]interface{}{"tags": tags}}}
+
+	default:
+		f.unsupported(w, "NerdGraph "+req.Query)
+		return
+	}
+
+	resp := map[string]interface{}{"data": data}
+	if errs != nil {
+		resp = map[string]interface{}{"errors": errs}
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// mutateNrqlCondition creates or updates a NRQL condition of the type named
+// by the mutation. It returns no data for any other mutation.
+func (f *fakeBackend) mutateNrqlCondition(query string, vars map[string]interface{}) (interface{}, []interface{}) {
+	for _, conditionType := range []string{"Static", "Baseline", "Outlier"} {
+		for _, action := range []string{"Create", "Update"} {
+			mutation := "alertsNrqlCondition" + conditionType + action
+			if !strings.Contains(query, mutation+"(") {
+				continue
+			}
+
+			condition := vars["condition"].(map[string]interface{})
+			condition["type"] = strings.ToUpper(conditionType)
+
+			if action == "Create" {
+				condition["id"] = strconv.Itoa(f.id())
+				condition["policyId"] = fmt.Sprint(vars["policyId"])
+				f.alertConditions["nrql"] = append(f.alertConditions["nrql"], condition)
+			} else {
+				existing := f.nrqlCondition(fmt.Sprint(vars["id"]))
+				if existing == nil {
+					return nil, fakeNerdGraphNotFound()
+				}
+
+				// Like the API, a condition can't be updated with a mutation of another type.
+				if existing["type"] != condition["type"] {
+					return nil, []interface{}{map[string]interface{}{"message": "condition type mismatch"}}
+				}
+
+				for k, v := range condition {
+					existing[k] = v
+				}
+				condition = existing
+			}
+
+			f.operations = append(f.operations, strings.ToLower(action+":"+conditionType))
+
+			return map[string]interface{}{mutation: condition}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (f *fakeBackend) nrqlCondition(id string) map[string]interface{} {
+	for _, c := range f.alertConditions["nrql"] {
+		if c["id"] == id {
+			return c
+		}
+	}
+
+	return nil
+}
+
+func (f *fakeBackend) updateWorkload(workload map[string]interface{}, input map[string]interface{}) {
+	workload["name"] = input["name"]
+
+	entities := []interface{}{}
+	if guids, ok := input["entityGuids"].([]interface{}); ok {
+		for _, guid := range guids {
+			entities = append(entities, map[string]interface{}{"guid": guid})
+		}
+	}
+	workload["entities"] = entities
+
+	queries := []interface{}{}
+	if inputs, ok := input["entitySearchQueries"].([]interface{}); ok {
+		for _, q := range inputs {
+			queries = append(queries, map[string]interface{}{
+				"id":    f.id(),
+				"query": q.(map[string]interface{})["query"],
+			})
+		}
+	}
+	workload["entitySearchQueries"] = queries
+
+	accountIDs := []interface{}{workload["account"].(map[string]interface{})["id"]}
+	if scope, ok := input["scopeAccounts"].(map[string]interface{}); ok {
+		accountIDs = scope["accountIds"].([]interface{})
+	}
+	workload["scopeAccounts"] = map[string]interface{}{"accountIds": accountIDs}
+}
+
+func (f *fakeBackend) updateNotificationDestination(destination map[string]interface{}, input map[string]interface{}) {
+	for _, k := range []string{"name", "active", "properties"} {
+		if v, ok := input[k]; ok {
+			destination[k] = v
+		}
+	}
+
+	if auth, ok := input["auth"].(map[string]interface{}); ok {
+		// The credentials are not returned.
+		switch auth["type"] {
+		case "BASIC":
+			destination["auth"] = map[string]interface{}{"authType": "BASIC", "user": auth["basic"].(map[string]interface{})["user"]}
+		case "TOKEN":
+			destination["auth"] = map[string]interface{}{"authType": "TOKEN", "prefix": auth["token"].(map[string]interface{})["prefix"]}
+		}
+	}
+
+	if input["disableAuth"] == true {
+		destination["auth"] = nil
+	}
+}
+
+func (f *fakeBackend) updateNotificationChannel(channel map[string]interface{}, input map[string]interface{}) {
+	for _, k := range []string{"name", "active", "properties"} {
+		if v, ok := input[k]; ok {
+			channel[k] = v
+		}
+	}
+}
+
+func (f *fakeBackend) updateWorkflow(w map[string]interface{}, input map[string]interface{}) {
+	for _, k := range []string{"name", "workflowEnabled", "destinationsEnabled", "enrichmentsEnabled", "mutingRulesHandling"} {
+		w[k] = input[k]
+	}
+
+	destinations := []interface{}{}
+	for _, raw := range input["destinationConfigurations"].([]interface{}) {
+		id := raw.(map[string]interface{})["channelId"]
+		channel := f.notificationChannels[id.(string)]
+		destinations = append(destinations, map[string]interface{}{
+			"channelId": id,
+			"name":      channel["name"],
+			"type":      channel["type"],
+		})
+	}
+	w["destinationConfigurations"] = destinations
+
+	filter := input["issuesFilter"].(map[string]interface{})
+	filterID, ok := filter["id"]
+	if !ok {
+		filterID = f.uuid()
+	}
+	if filterInput, ok := filter["filterInput"]; ok {
+		filter = filterInput.(map[string]interface{})
+	}
+	w["issuesFilter"] = map[string]interface{}{
+		"id":         filterID,
+		"name":       filter["name"],
+		"type":       filter["type"],
+		"predicates": filter["predicates"],
+	}
+
+	enrichments := []interface{}{}
+	if input, ok := input["enrichments"].(map[string]interface{}); ok {
+		for _, raw := range input["nrql"].([]interface{}) {
+			enrichment := raw.(map[string]interface{})
+			id, ok := enrichment["id"]
+			if !ok {
+				id = f.uuid()
+			}
+			enrichments = append(enrichments, map[string]interface{}{
+				"id":             id,
+				"name":           enrichment["name"],
+				"type":           "NRQL",
+				"configurations": enrichment["configuration"],
+			})
+		}
+	}
+	w["enrichments"] = enrichments
+}
+
+func (f *fakeBackend) updateDashboard(dashboard map[string]interface{}, input map[string]interface{}) {
+	for _, k := range []string{"name", "description", "permissions", "variables"} {
+		dashboard[k] = input[k]
+	}
+
+	pages := []interface{}{}
+	if inputPages, ok := input["pages"].([]interface{}); ok {
+		for _, raw := range inputPages {
+			inputPage := raw.(map[string]interface{})
+			page := map[string]interface{}{
+				"guid":        inputPage["guid"],
+				"name":        inputPage["name"],
+				"description": inputPage["description"],
+			}
+			if page["guid"] == nil {
+				page["guid"] = base64.RawStdEncoding.EncodeToString([]byte(fmt.Sprintf("%v|VIZ|DASHBOARD|%d", dashboard["accountId"], f.id())))
+			}
+
+			widgets := []interface{}{}
+			if inputWidgets, ok := inputPage["widgets"].([]interface{}); ok {
+				for _, raw := range inputWidgets {
+					widget := map[string]interface{}{}
+					for k, v := range raw.(map[string]interface{}) {
+						widget[k] = v
+					}
+					if widget["id"] == nil {
+						widget["id"] = strconv.Itoa(f.id())
+					}
+					if cfg, ok := widget["rawConfiguration"].(map[string]interface{}); ok {
+						for k, v := range f.dashboardWidgetDefaults {
+							if _, ok := cfg[k]; !ok {
+								cfg[k] = v
+							}
+						}
+					}
+					// The visualization of a typed widget is the one of its configuration.
+					if configuration, ok := widget["configuration"].(map[string]interface{}); ok {
+						for k, v := range configuration {
+							if v != nil {
+								widget["visualization"] = map[string]interface{}{"id": "viz." + k}
+							}
+						}
+					}
+					linkedEntities := []interface{}{}
+					if guids, ok := widget["linkedEntityGuids"].([]interface{}); ok {
+						for _, guid := range guids {
+							linkedEntities = append(linkedEntities, map[string]interface{}{"__typename": "DashboardEntityOutline", "guid": guid})
+						}
+					}
+					delete(widget, "linkedEntityGuids")
+					widget["linkedEntities"] = linkedEntities
+					widgets = append(widgets, widget)
+				}
+			}
+			page["widgets"] = widgets
+
+			pages = append(pages, page)
+		}
+	}
+	dashboard["pages"] = pages
+}
+
+func (f *fakeBackend) updateServiceLevel(indicator map[string]interface{}, input map[string]interface{}) {
+	indicator["name"] = input["name"]
+	indicator["description"] = input["description"]
+
+	events := indicator["events"].(map[string]interface{})
+	for _, k := range []string{"validEvents", "goodEvents", "badEvents"} {
+		events[k] = input["events"].(map[string]interface{})[k]
+	}
+
+	objectives := []interface{}{}
+	for _, raw := range input["objectives"].([]interface{}) {
+		objective := map[string]interface{}{}
+		for k, v := range raw.(map[string]interface{}) {
+			objective[k] = v
+		}
+		objective["resultQueries"] = map[string]interface{}{"attainment": map[string]interface{}{
+			"nrql": fmt.Sprintf("SELECT sum(newrelic.sli.good) / sum(newrelic.sli.valid) * 100 > %v AS 'attainment' FROM Metric WHERE sli.id = '%s'", objective["target"], indicator["id"]),
+		}}
+		objectives = append(objectives, objective)
+	}
+	indicator["objectives"] = objectives
+}
+
+// fakeFilterEntities returns the entities matching the id or, partially, the
+// name of a notifications or workflows filter, ordered by ID.
+func fakeFilterEntities(entities map[string]map[string]interface{}, rawFilters interface{}) []interface{} {
+	filters, _ := rawFilters.(map[string]interface{})
+
+	ids := make([]string, 0, len(entities))
+	for id := range entities {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	matches := []interface{}{}
+	for _, id := range ids {
+		entity := entities[id]
+		if filterID, ok := filters["id"]; ok && filterID != id {
+			continue
+		}
+		if name, ok := filters["name"].(string); ok && !strings.Contains(entity["name"].(string), name) {
+			continue
+		}
+		matches = append(matches, entity)
+	}
+
+	return matches
+}
+
+// fakeEntitiesPage returns the page of entities at a cursor, with a single
+// entity per page so that the clients have to follow nextCursor.
+func fakeEntitiesPage(entities []interface{}, cursor interface{}) map[string]interface{} {
+	page, _ := strconv.Atoi(fmt.Sprint(cursor))
+	result := map[string]interface{}{"entities": []interface{}{}, "nextCursor": nil}
+
+	if page < len(entities) {
+		result["entities"] = entities[page : page+1]
+	}
+	if page+1 < len(entities) {
+		result["nextCursor"] = strconv.Itoa(page + 1)
+	}
+
+	return result
+}
+
+func fakeNotificationsEntities(field string, entities []interface{}, cursor interface{}) map[string]interface{} {
+	return map[string]interface{}{"actor": map[string]interface{}{"account": map[string]interface{}{
+		"aiNotifications": map[string]interface{}{field: fakeEntitiesPage(entities, cursor)},
+	}}}
+}
+
+// fakeNotificationsNotFound returns the data of a notifications mutation for
+// a missing entity.
+func fakeNotificationsNotFound(mutation string) map[string]interface{} {
+	return map[string]interface{}{mutation: map[string]interface{}{
+		"error": map[string]interface{}{"description": "Entity not found", "type": "ENTITY_NOT_FOUND"},
+	}}
+}
+
+func (f *fakeBackend) entityTags(guid string) map[string][]string {
+	if _, ok := f.tags[guid]; !ok {
+		f.tags[guid] = map[string][]string{}
+	}
+
+	return f.tags[guid]
+}
+
+func fakeNerdGraphAccountAlerts(field string, value interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"actor": map[string]interface{}{
+			"account": map[string]interface{}{
+				"alerts": map[string]interface{}{
+					field: value,
+				},
+			},
+		},
+	}
+}
+
+// takeOperations returns the operations received since the last call, sorted
+// when the order in which they were sent doesn't matter.
+func (f *fakeBackend) takeOperations(sorted bool) []string {
+	f.Lock()
+	defer f.Unlock()
+
+	operations := f.operations
+	f.operations = nil
+
+	if sorted {
+		sort.Strings(operations)
+	}
+
+	return operations
+}
+
+// fakeNerdGraphNotFound returns the errors of a NerdGraph response for a
+// missing resource.
+func fakeNerdGraphNotFound() []interface{} {
+	return []interface{}{
+		map[string]interface{}{
+			"message":    "Not Found",
+			"extensions": map[string]interface{}{"errorClass": "BAD_USER_INPUT"},
+		},
+	}
+}
+
+//
+// REST
+//
+
+// addPolicy adds an alert policy.
+func (f *fakeBackend) addPolicy(name string) int {
+	f.Lock()
+	defer f.Unlock()
+
+	id := f.id()
+	f.policies[strconv.Itoa(id)] = map[string]interface{}{
+		"id":                 strconv.Itoa(id),
+		"accountId":          fakeBackendAccountID,
+		"name":               name,
+		"incidentPreference": "PER_POLICY",
+	}
+
+	return id
+}
+
+// addApplication adds an APM application, which can't be created through the API.
+func (f *fakeBackend) addApplication(name string) int {
+	f.Lock()
+	defer f.Unlock()
+
+	id := f.id()
+	f.applications[id] = map[string]interface{}{
+		"id":       id,
+		"name":     name,
+		"language": "go",
+		"settings": map[string]interface{}{
+			"app_apdex_threshold":         0.5,
+			"end_user_apdex_threshold":    7.0,
+			"enable_real_user_monitoring": true,
+		},
+	}
+
+	return id
+}
+
+func (f *fakeBackend) serveApplications(w http.ResponseWriter, r *http.Request) {
+	f.Lock()
+	defer f.Unlock()
+
+	path := strings.TrimPrefix(r.URL.Path, "/v2/")
+
+	if path == "applications.json" && r.Method == http.MethodGet {
+		apps := []interface{}{}
+		for _, app := range f.applications {
+			if name := r.URL.Query().Get("filter[name]"); name == "" || strings.Contains(app["name"].(string), name) {
+				apps = append(apps, app)
+			}
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"applications": apps})
+		return
+	}
+
+	id, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(path, "applications/"), ".json"))
+	if err != nil || !strings.HasPrefix(path, "applications/") {
+		f.unsupported(w, r.Method+" "+r.URL.Path)
+		return
+	}
+
+	app, ok := f.applications[id]
+	if !ok {
+		writeJSON(w, http.StatusNotFound, map[string]interface{}{"error": map[string]interface{}{"title": "Application not found"}})
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		var req struct {
+			Application map[string]interface{} `json:"application"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if name, ok := req.Application["name"]; ok {
+			app["name"] = name
+		}
+		if settings, ok := req.Application["settings"].(map[string]interface{}); ok {
+			for k, v := range settings {
+				app["settings"].(map[string]interface{})[k] = v
+			}
+		}
+	default:
+		f.unsupported(w, r.Method+" "+r.URL.Path)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"application": app})
+}
+
+// addChannel adds an alert channel.
+func (f *fakeBackend) addChannel(name string) int {
+	f.Lock()
+	defer f.Unlock()
+
+	id := f.id()
+	f.channels[id] = map[string]interface{}{
+		"id":   id,
+		"name": name,
+		"type": "email",
+		"configuration": map[string]interface{}{
+			"recipients": "foo@example.com",
+		},
+	}
+	f.policyChannels[id] = map[int]bool{}
+
+	return id
+}
+
+func (f *fakeBackend) serveAlertChannels(w http.ResponseWriter, r *http.Request) {
+	f.Lock()
+	defer f.Unlock()
+
+	switch {
+	case r.URL.Path == "/v2/alerts_channels.json" && r.Method == http.MethodGet:
+		channels := []interface{}{}
+		for id, channel := range f.channels {
+			policyIDs := []int{}
+			for policyID := range f.policyChannels[id] {
+				policyIDs = append(policyIDs, policyID)
+			}
+			sortIntegerSlice(policyIDs)
+
+			// Like the API, secrets are not returned.
+			config := map[string]interface{}{}
+			for k, v := range channel["configuration"].(map[string]interface{}) {
+				switch k {
+				case "api_key", "auth_password", "headers", "key", "service_key", "url":
+				default:
+					config[k] = v
+				}
+			}
+
+			returned := map[string]interface{}{}
+			for k, v := range channel {
+				returned[k] = v
+			}
+			returned["configuration"] = config
+			returned["links"] = map[string]interface{}{"policy_ids": policyIDs}
+
+			channels = append(channels, returned)
+		}
+
+		writeJSON(w, http.StatusOK, map[string]interface{}{"channels": channels})
+	case r.URL.Path == "/v2/alerts_channels.json" && r.Method == http.MethodPost:
+		var req struct {
+			Channel map[string]interface{} `json:"channel"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		id := f.id()
+		req.Channel["id"] = id
+		f.channels[id] = req.Channel
+		f.policyChannels[id] = map[int]bool{}
+
+		writeJSON(w, http.StatusCreated, map[string]interface{}{"channels": []interface{}{req.Channel}})
+	case r.Method == http.MethodDelete:
+		id, _ := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/v2/alerts_channels/"), ".json"))
+		channel, ok := f.channels[id]
+		if !ok {
+			writeJSON(w, http.StatusNotFound, map[string]interface{}{"error": map[string]interface{}{"title": "Channel not found"}})
+			return
+		}
+
+		delete(f.channels, id)
+		delete(f.policyChannels, id)
+
+		writeJSON(w, http.StatusOK, map[string]interface{}{"channel": channel})
+	default:
+		f.unsupported(w, r.Method+" "+r.URL.Path)
+	}
+}
+
+// updateChannel applies a NerdGraph notification channel update input to a
+// channel created through the REST API.
+func (f *fakeBackend) updateChannel(channel map[string]interface{}, input map[string]interface{}) {
+	config := channel["configuration"].(map[string]interface{})
+
+	for _, fields := range input {
+		for k, v := range fields.(map[string]interface{}) {
+			switch k {
+			case "name":
+				channel["name"] = v
+			case "emails":
+				recipients := []string{}
+				for _, email := range v.([]interface{}) {
+					recipients = append(recipients, email.(string))
+				}
+				config["recipients"] = strings.Join(recipients, ",")
+			case "includeJson":
+				config["include_json_attachment"] = strconv.FormatBool(v.(bool))
+			case "apiKey":
+				config["service_key"] = v
+			case "teamChannel":
+				config["channel"] = v
+			case "url":
+				config["url"] = v
+			case "baseUrl":
+				config["base_url"] = v
+			case "customHttpHeaders":
+				headers := map[string]interface{}{}
+				for _, h := range v.([]interface{}) {
+					header := h.(map[string]interface{})
+					headers[header["name"].(string)] = header["value"]
+				}
+				config["headers"] = headers
+			default:
+				f.t.Errorf("fake backend: unsupported notification channel field %s", k)
+			}
+		}
+	}
+}
+
+func (f *fakeBackend) servePolicyChannels(w http.ResponseWriter, r *http.Request) {
+	f.Lock()
+	defer f.Unlock()
+
+	query := r.URL.Query()
+
+	policyID, err := strconv.Atoi(query.Get("policy_id"))
+	if err != nil || f.policies[query.Get("policy_id")] == nil {
+		writeJSON(w, http.StatusNotFound, map[string]interface{}{"error": map[string]interface{}{"title": "Policy not found"}})
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		channelIDs := []int{}
+		for _, s := range strings.Split(query.Get("channel_ids"), ",") {
+			id, err := strconv.Atoi(s)
+			if err != nil || f.channels[id] == nil {
+				writeJSON(w, http.StatusNotFound, map[string]interface{}{"error": map[string]interface{}{"title": "Channel not found"}})
+				return
+			}
+			f.policyChannels[id][policyID] = true
+			channelIDs = append(channelIDs, id)
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"policy": map[string]interface{}{"id": policyID, "channel_ids": channelIDs}})
+	case http.MethodDelete:
+		id, _ := strconv.Atoi(query.Get("channel_id"))
+		if !f.policyChannels[id][policyID] {
+			writeJSON(w, http.StatusNotFound, map[string]interface{}{"error": map[string]interface{}{"title": "Channel not found"}})
+			return
+		}
+		delete(f.policyChannels[id], policyID)
+		writeJSON(w, http.StatusOK, map[string]interface{}{"channel": f.channels[id]})
+	default:
+		f.unsupported(w, r.Method+" "+r.URL.Path)
+	}
+}
+
+// addAlertCondition adds a condition of a family, nrql, apm, infra,
+// synthetics, synthetics_multilocation or plugins, to a policy.
+func (f *fakeBackend) addAlertCondition(family string, policyID int, condition map[string]interface{}) {
+	f.Lock()
+	defer f.Unlock()
+
+	if family == "nrql" {
+		condition["id"] = strconv.Itoa(f.id())
+		condition["policyId"] = strconv.Itoa(policyID)
+	} else {
+		condition["id"] = f.id()
+		condition["policy_id"] = policyID
+	}
+
+	f.alertConditions[family] = append(f.alertConditions[family], condition)
+}
+
+// serveAlertConditions lists the conditions of a policy, for every family
+// listed through the REST and Infrastructure APIs.
+func (f *fakeBackend) serveAlertConditions(w http.ResponseWriter, r *http.Request) {
+	f.Lock()
+	defer f.Unlock()
+
+	if r.Method != http.MethodGet {
+		f.unsupported(w, r.Method+" "+r.URL.Path)
+		return
+	}
+
+	policyID := r.URL.Query().Get("policy_id")
+
+	var family, field string
+	switch {
+	case r.URL.Path == "/v2/alerts_conditions.json":
+		family, field = "apm", "conditions"
+	case r.URL.Path == "/v2/alerts_synthetics_conditions.json":
+		family, field = "synthetics", "synthetics_conditions"
+	case strings.HasPrefix(r.URL.Path, "/v2/alerts_location_failure_conditions/policies/"):
+		family, field = "synthetics_multilocation", "location_failure_conditions"
+		policyID = strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/v2/alerts_location_failure_conditions/policies/"), ".json")
+	case r.URL.Path == "/v2/alerts_plugins_conditions.json":
+		family, field = "plugins", "plugins_conditions"
+	default:
+		family, field = "infra", "data"
+	}
+
+	conditions := []interface{}{}
+	for _, c := range f.alertConditions[family] {
+		if fmt.Sprint(c["policy_id"]) == policyID {
+			conditions = append(conditions, c)
+		}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{field: conditions})
+}
+
+//
+// Synthetics
+//
+
+func (f *fakeBackend) serveMonitors(w http.ResponseWriter, r *http.Request) {
+	f.Lock()
+	defer f.Unlock()
+
+	id := strings.Trim(strings.TrimPrefix(r.URL.Path, "/synthetics/v4/monitors"), "/")
+
+	if id == "" {
+		if r.Method != http.MethodPost {
+			f.unsupported(w, r.Method+" "+r.URL.Path)
+			return
+		}
+
+		var monitor map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&monitor); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		id = fmt.Sprintf("00000000-0000-0000-0000-%012d", f.id())
+		monitor["id"] = id
+		f.monitors[id] = monitor
+
+		w.Header().Set("Location", f.server.URL+"/synthetics/v4/monitors/"+id)
+		w.WriteHeader(http.StatusCreated)
+		return
+	}
+
+	monitor, ok := f.monitors[id]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, monitor)
+	case http.MethodPut:
+		var update map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		update["id"] = id
+		f.monitors[id] = update
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodDelete:
+		delete(f.monitors, id)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		f.unsupported(w, r.Method+" "+r.URL.Path)
+	}
+}
+
+// testFakeBackendMeta configures a provider against the backend through the
+// API URL overrides and returns its meta.
+func testFakeBackendMeta(t *testing.T, f *fakeBackend) *ProviderConfig {
+	p := Provider()
+
+	diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(f.providerConfig()))
+	require.False(t, diags.HasError(), "%+v", diags)
+
+	return p.Meta().(*ProviderConfig)
+}
+
+// testResourceConfig returns the raw configuration of a resource, with the
+// overrides applied to the base configuration. A nil override removes the
+// attribute.
+func testResourceConfig(base map[string]interface{}, overrides map[string]interface{}) map[string]interface{} {
+	config := make(map[string]interface{}, len(base)+len(overrides))
+	for k, v := range base {
+		config[k] = v
+	}
+
+	for k, v := range overrides {
+		if v == nil {
+			delete(config, k)
+			continue
+		}
+		config[k] = v
+	}
+
+	return config
+}
+
+// testFakeBackendPlan returns the diff of a resource for a configuration.
+func testFakeBackendPlan(t *testing.T, meta *ProviderConfig, r *schema.Resource, state *terraform.InstanceState, config map[string]interface{}) *terraform.InstanceDiff {
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), meta)
+	require.NoError(t, err)
+
+	return diff
+}
+
+// testFakeBackendApply plans and applies a resource without a Terraform
+// binary, see resource.UnitTest for tests running the full workflow.
+func testFakeBackendApply(t *testing.T, meta *ProviderConfig, r *schema.Resource, state *terraform.InstanceState, config map[string]interface{}) *terraform.InstanceState {
+	diff := testFakeBackendPlan(t, meta, r, state, config)
+
+	if config == nil {
+		diff = &terraform.InstanceDiff{Destroy: true}
+	}
+
+	newState, diags := r.Apply(context.Background(), state, diff, meta)
+	require.False(t, diags.HasError(), "%+v", diags)
+
+	return newState
+}
+
+func TestFakeBackend_ProviderOverrides(t *testing.T) {
+	f := newFakeBackend(t)
+	meta := testFakeBackendMeta(t, f)
+
+	// NerdGraph
+	r := resourceNewRelicAlertPolicy()
+	state := testFakeBackendApply(t, meta, r, nil, map[string]interface{}{"name": "tf-test"})
+	assert.Equal(t, "tf-test", f.policies[state.ID]["name"])
+	assert.Equal(t, "PER_POLICY", state.Attributes["incident_preference"])
+
+	state = testFakeBackendApply(t, meta, r, state, map[string]interface{}{"name": "tf-test-updated"})
+	assert.Equal(t, "tf-test-updated", f.policies[state.ID]["name"])
+
+	testFakeBackendApply(t, meta, r, state, nil)
+	assert.Empty(t, f.policies)
+
+	r = resourceNewRelicWorkload()
+	state = testFakeBackendApply(t, meta, r, nil, map[string]interface{}{"name": "tf-test", "account_id": 1})
+	assert.Equal(t, "tf-test", state.Attributes["name"])
+	assert.NotEmpty(t, state.Attributes["guid"])
+	assert.Len(t, f.workloads, 1)
+
+	// Synthetics
+	r = resourceNewRelicSyntheticsMonitor()
+	monitorConfig := map[string]interface{}{
+		"name":      "tf-test",
+		"type":      "SIMPLE",
+		"frequency": 5,
+		"status":    "DISABLED",
+		"locations": []interface{}{"AWS_US_EAST_1"},
+		"uri":       "https://example.com",
+	}
+	state = testFakeBackendApply(t, meta, r, nil, monitorConfig)
+	assert.Equal(t, "tf-test", state.Attributes["name"])
+	assert.Equal(t, "5", state.Attributes["frequency"])
+
+	testFakeBackendApply(t, meta, r, state, nil)
+	assert.Empty(t, f.monitors)
+
+	// REST
+	id := f.addApplication("tf-test-app")
+	r = resourceNewRelicApplicationSettings()
+	state = testFakeBackendApply(t, meta, r, nil, map[string]interface{}{
+		"name":                        "tf-test-app",
+		"app_apdex_threshold":         0.8,
+		"end_user_apdex_threshold":    7,
+		"enable_real_user_monitoring": false,
+	})
+	assert.Equal(t, strconv.Itoa(id), state.ID)
+	assert.Equal(t, 0.8, f.applications[id]["settings"].(map[string]interface{})["app_apdex_threshold"])
+}
@@ -7,14 +7,13 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccNewRelicAPIAccessKey_importBasic(t *testing.T) {
-	keyName := fmt.Sprintf("tftest-keyname-%s", acctest.RandString(10))
-	keyNotes := fmt.Sprintf("tftest-keynotes-%s", acctest.RandString(10))
+	keyName := fmt.Sprintf("tftest-keyname-%s", testAccRandString(t, 10))
+	keyNotes := fmt.Sprintf("tftest-keynotes-%s", testAccRandString(t, 10))
 	_, accountID := retrieveIdsFromEnvOrSkip(t, "NEW_RELIC_TEST_ACCOUNT_ID")
 
 	resource.Test(t, resource.TestCase{
@@ -0,0 +1,491 @@
+package newrelic
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/newrelic/newrelic-client-go/newrelic"
+	"github.com/newrelic/newrelic-client-go/pkg/errors"
+)
+
+// newrelic-client-go does not model New Relic's notification destinations,
+// channels and workflows yet, so they are managed with these NerdGraph
+// documents through the client's NerdGraph API.
+
+const notificationsErrorFragment = `
+	... on AiNotificationsResponseError {
+		description
+		details
+		type
+	}
+	... on AiNotificationsDataValidationError {
+		details
+		fields {
+			field
+			message
+		}
+	}
+	... on AiNotificationsConstraintsError {
+		constraints {
+			dependencies
+			name
+		}
+	}
+	... on AiNotificationsSuggestionError {
+		description
+		details
+		type
+	}`
+
+const notificationDestinationFields = `
+	id
+	name
+	type
+	active
+	properties {
+		key
+		value
+		label
+		displayValue
+	}
+	auth {
+		... on AiNotificationsBasicAuth {
+			authType
+			user
+		}
+		... on AiNotificationsTokenAuth {
+			authType
+			prefix
+		}
+	}`
+
+const notificationChannelFields = `
+	id
+	name
+	type
+	product
+	destinationId
+	active
+	properties {
+		key
+		value
+		label
+		displayValue
+	}`
+
+const (
+	createNotificationDestinationMutation = `mutation($accountId: Int!, $destination: AiNotificationsDestinationInput!) {
+		aiNotificationsCreateDestination(accountId: $accountId, destination: $destination) {
+			destination {` + notificationDestinationFields + `
+			}
+			error {` + notificationsErrorFragment + `
+			}
+		}
+	}`
+
+	updateNotificationDestinationMutation = `mutation($accountId: Int!, $destinationId: ID!, $destination: AiNotificationsDestinationUpdate!) {
+		aiNotificationsUpdateDestination(accountId: $accountId, destinationId: $destinationId, destination: $destination) {
+			destination {` + notificationDestinationFields + `
+			}
+			error {` + notificationsErrorFragment + `
+			}
+		}
+	}`
+
+	deleteNotificationDestinationMutation = `mutation($accountId: Int!, $destinationId: ID!) {
+		aiNotificationsDeleteDestination(accountId: $accountId, destinationId: $destinationId) {
+			ids
+			error {` + notificationsErrorFragment + `
+			}
+		}
+	}`
+
+	getNotificationDestinationsQuery = `query($accountId: Int!, $filters: AiNotificationsDestinationFilter, $cursor: String) {
+		actor {
+			account(id: $accountId) {
+				aiNotifications {
+					destinations(filters: $filters, cursor: $cursor) {
+						entities {` + notificationDestinationFields + `
+						}
+						error {` + notificationsErrorFragment + `
+						}
+						nextCursor
+					}
+				}
+			}
+		}
+	}`
+
+	createNotificationChannelMutation = `mutation($accountId: Int!, $channel: AiNotificationsChannelInput!) {
+		aiNotificationsCreateChannel(accountId: $accountId, channel: $channel) {
+			channel {` + notificationChannelFields + `
+			}
+			error {` + notificationsErrorFragment + `
+			}
+		}
+	}`
+
+	updateNotificationChannelMutation = `mutation($accountId: Int!, $channelId: ID!, $channel: AiNotificationsChannelUpdate!) {
+		aiNotificationsUpdateChannel(accountId: $accountId, channelId: $channelId, channel: $channel) {
+			channel {` + notificationChannelFields + `
+			}
+			error {` + notificationsErrorFragment + `
+			}
+		}
+	}`
+
+	deleteNotificationChannelMutation = `mutation($accountId: Int!, $channelId: ID!) {
+		aiNotificationsDeleteChannel(accountId: $accountId, channelId: $channelId) {
+			ids
+			error {` + notificationsErrorFragment + `
+			}
+		}
+	}`
+
+	getNotificationChannelsQuery = `query($accountId: Int!, $filters: AiNotificationsChannelFilter, $cursor: String) {
+		actor {
+			account(id: $accountId) {
+				aiNotifications {
+					channels(filters: $filters, cursor: $cursor) {
+						entities {` + notificationChannelFields + `
+						}
+						error {` + notificationsErrorFragment + `
+						}
+						nextCursor
+					}
+				}
+			}
+		}
+	}`
+)
+
+type notificationProperty struct {
+	Key          string `json:"key"`
+	Value        string `json:"value"`
+	Label        string `json:"label,omitempty"`
+	DisplayValue string `json:"displayValue,omitempty"`
+}
+
+type notificationDestinationAuth struct {
+	AuthType string `json:"authType"`
+	User     string `json:"user,omitempty"`
+	Prefix   string `json:"prefix,omitempty"`
+}
+
+type notificationDestination struct {
+	ID         string                       `json:"id"`
+	Name       string                       `json:"name"`
+	Type       string                       `json:"type"`
+	Active     bool                         `json:"active"`
+	Properties []notificationProperty       `json:"properties"`
+	Auth       *notificationDestinationAuth `json:"auth"`
+}
+
+type notificationChannel struct {
+	ID            string                 `json:"id"`
+	Name          string                 `json:"name"`
+	Type          string                 `json:"type"`
+	Product       string                 `json:"product"`
+	DestinationID string                 `json:"destinationId"`
+	Active        bool                   `json:"active"`
+	Properties    []notificationProperty `json:"properties"`
+}
+
+// notificationsError is the error of a notifications query or mutation, one
+// of the members of NerdGraph's AiNotificationsError union.
+type notificationsError struct {
+	Description string `json:"description"`
+	Details     string `json:"details"`
+	Type        string `json:"type"`
+	Fields      []struct {
+		Field   string `json:"field"`
+		Message string `json:"message"`
+	} `json:"fields"`
+	Constraints []struct {
+		Dependencies []string `json:"dependencies"`
+		Name         string   `json:"name"`
+	} `json:"constraints"`
+}
+
+func (e *notificationsError) Error() string {
+	messages := []string{}
+
+	for _, s := range []string{e.Type, e.Description, e.Details} {
+		if s != "" {
+			messages = append(messages, s)
+		}
+	}
+
+	for _, f := range e.Fields {
+		messages = append(messages, fmt.Sprintf("%s: %s", f.Field, f.Message))
+	}
+
+	for _, c := range e.Constraints {
+		messages = append(messages, fmt.Sprintf("%s depends on %s", c.Name, strings.Join(c.Dependencies, ", ")))
+	}
+
+	return strings.Join(messages, ": ")
+}
+
+// err returns the error as an error, or nil when e is nil. NerdGraph returns
+// an empty error object in some successful responses.
+func (e *notificationsError) err() error {
+	if e == nil || e.Error() == "" {
+		return nil
+	}
+
+	return e
+}
+
+func createNotificationDestination(ctx context.Context, client *newrelic.NewRelic, accountID int, destination map[string]interface{}) (*notificationDestination, error) {
+	var resp struct {
+		AiNotificationsCreateDestination struct {
+			Destination *notificationDestination `json:"destination"`
+			Error       *notificationsError      `json:"error"`
+		} `json:"aiNotificationsCreateDestination"`
+	}
+
+	vars := map[string]interface{}{
+		"accountId":   accountID,
+		"destination": destination,
+	}
+
+	if err := client.NerdGraph.QueryWithResponseAndContext(ctx, createNotificationDestinationMutation, vars, &resp); err != nil {
+		return nil, err
+	}
+
+	if err := resp.AiNotificationsCreateDestination.Error.err(); err != nil {
+		return nil, err
+	}
+
+	return resp.AiNotificationsCreateDestination.Destination, nil
+}
+
+func updateNotificationDestination(ctx context.Context, client *newrelic.NewRelic, accountID int, id string, destination map[string]interface{}) (*notificationDestination, error) {
+	var resp struct {
+		AiNotificationsUpdateDestination struct {
+			Destination *notificationDestination `json:"destination"`
+			Error       *notificationsError      `json:"error"`
+		} `json:"aiNotificationsUpdateDestination"`
+	}
+
+	vars := map[string]interface{}{
+		"accountId":     accountID,
+		"destinationId": id,
+		"destination":   destination,
+	}
+
+	if err := client.NerdGraph.QueryWithResponseAndContext(ctx, updateNotificationDestinationMutation, vars, &resp); err != nil {
+		return nil, err
+	}
+
+	if err := resp.AiNotificationsUpdateDestination.Error.err(); err != nil {
+		return nil, err
+	}
+
+	return resp.AiNotificationsUpdateDestination.Destination, nil
+}
+
+func deleteNotificationDestination(ctx context.Context, client *newrelic.NewRelic, accountID int, id string) error {
+	var resp struct {
+		AiNotificationsDeleteDestination struct {
+			Error *notificationsError `json:"error"`
+		} `json:"aiNotificationsDeleteDestination"`
+	}
+
+	vars := map[string]interface{}{
+		"accountId":     accountID,
+		"destinationId": id,
+	}
+
+	if err := client.NerdGraph.QueryWithResponseAndContext(ctx, deleteNotificationDestinationMutation, vars, &resp); err != nil {
+		return err
+	}
+
+	return resp.AiNotificationsDeleteDestination.Error.err()
+}
+
+// getNotificationDestinations returns the destinations matching filters, an
+// AiNotificationsDestinationFilter, from all of the pages of results.
+func getNotificationDestinations(ctx context.Context, client *newrelic.NewRelic, accountID int, filters map[string]interface{}) ([]notificationDestination, error) {
+	destinations := []notificationDestination{}
+	var nextCursor *string
+
+	for ok := true; ok; ok = nextCursor != nil {
+		var resp struct {
+			Actor struct {
+				Account struct {
+					AiNotifications struct {
+						Destinations struct {
+							Entities   []notificationDestination `json:"entities"`
+							Error      *notificationsError       `json:"error"`
+							NextCursor *string                   `json:"nextCursor"`
+						} `json:"destinations"`
+					} `json:"aiNotifications"`
+				} `json:"account"`
+			} `json:"actor"`
+		}
+
+		vars := map[string]interface{}{
+			"accountId": accountID,
+			"filters":   filters,
+			"cursor":    nextCursor,
+		}
+
+		if err := client.NerdGraph.QueryWithResponseAndContext(ctx, getNotificationDestinationsQuery, vars, &resp); err != nil {
+			return nil, err
+		}
+
+		page := resp.Actor.Account.AiNotifications.Destinations
+		if err := page.Error.err(); err != nil {
+			return nil, err
+		}
+
+		destinations = append(destinations, page.Entities...)
+		nextCursor = page.NextCursor
+	}
+
+	return destinations, nil
+}
+
+func getNotificationDestination(ctx context.Context, client *newrelic.NewRelic, accountID int, id string) (*notificationDestination, error) {
+	destinations, err := getNotificationDestinations(ctx, client, accountID, map[string]interface{}{"id": id})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range destinations {
+		if destinations[i].ID == id {
+			return &destinations[i], nil
+		}
+	}
+
+	return nil, errors.NewNotFoundf("no notification destination found for id %s", id)
+}
+
+func createNotificationChannel(ctx context.Context, client *newrelic.NewRelic, accountID int, channel map[string]interface{}) (*notificationChannel, error) {
+	var resp struct {
+		AiNotificationsCreateChannel struct {
+			Channel *notificationChannel `json:"channel"`
+			Error   *notificationsError  `json:"error"`
+		} `json:"aiNotificationsCreateChannel"`
+	}
+
+	vars := map[string]interface{}{
+		"accountId": accountID,
+		"channel":   channel,
+	}
+
+	if err := client.NerdGraph.QueryWithResponseAndContext(ctx, createNotificationChannelMutation, vars, &resp); err != nil {
+		return nil, err
+	}
+
+	if err := resp.AiNotificationsCreateChannel.Error.err(); err != nil {
+		return nil, err
+	}
+
+	return resp.AiNotificationsCreateChannel.Channel, nil
+}
+
+func updateNotificationChannel(ctx context.Context, client *newrelic.NewRelic, accountID int, id string, channel map[string]interface{}) (*notificationChannel, error) {
+	var resp struct {
+		AiNotificationsUpdateChannel struct {
+			Channel *notificationChannel `json:"channel"`
+			Error   *notificationsError  `json:"error"`
+		} `json:"aiNotificationsUpdateChannel"`
+	}
+
+	vars := map[string]interface{}{
+		"accountId": accountID,
+		"channelId": id,
+		"channel":   channel,
+	}
+
+	if err := client.NerdGraph.QueryWithResponseAndContext(ctx, updateNotificationChannelMutation, vars, &resp); err != nil {
+		return nil, err
+	}
+
+	if err := resp.AiNotificationsUpdateChannel.Error.err(); err != nil {
+		return nil, err
+	}
+
+	return resp.AiNotificationsUpdateChannel.Channel, nil
+}
+
+func deleteNotificationChannel(ctx context.Context, client *newrelic.NewRelic, accountID int, id string) error {
+	var resp struct {
+		AiNotificationsDeleteChannel struct {
+			Error *notificationsError `json:"error"`
+		} `json:"aiNotificationsDeleteChannel"`
+	}
+
+	vars := map[string]interface{}{
+		"accountId": accountID,
+		"channelId": id,
+	}
+
+	if err := client.NerdGraph.QueryWithResponseAndContext(ctx, deleteNotificationChannelMutation, vars, &resp); err != nil {
+		return err
+	}
+
+	return resp.AiNotificationsDeleteChannel.Error.err()
+}
+
+// getNotificationChannels returns the channels matching filters, an
+// AiNotificationsChannelFilter, from all of the pages of results.
+func getNotificationChannels(ctx context.Context, client *newrelic.NewRelic, accountID int, filters map[string]interface{}) ([]notificationChannel, error) {
+	channels := []notificationChannel{}
+	var nextCursor *string
+
+	for ok := true; ok; ok = nextCursor != nil {
+		var resp struct {
+			Actor struct {
+				Account struct {
+					AiNotifications struct {
+						Channels struct {
+							Entities   []notificationChannel `json:"entities"`
+							Error      *notificationsError   `json:"error"`
+							NextCursor *string               `json:"nextCursor"`
+						} `json:"channels"`
+					} `json:"aiNotifications"`
+				} `json:"account"`
+			} `json:"actor"`
+		}
+
+		vars := map[string]interface{}{
+			"accountId": accountID,
+			"filters":   filters,
+			"cursor":    nextCursor,
+		}
+
+		if err := client.NerdGraph.QueryWithResponseAndContext(ctx, getNotificationChannelsQuery, vars, &resp); err != nil {
+			return nil, err
+		}
+
+		page := resp.Actor.Account.AiNotifications.Channels
+		if err := page.Error.err(); err != nil {
+			return nil, err
+		}
+
+		channels = append(channels, page.Entities...)
+		nextCursor = page.NextCursor
+	}
+
+	return channels, nil
+}
+
+func getNotificationChannel(ctx context.Context, client *newrelic.NewRelic, accountID int, id string) (*notificationChannel, error) {
+	channels, err := getNotificationChannels(ctx, client, accountID, map[string]interface{}{"id": id})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range channels {
+		if channels[i].ID == id {
+			return &channels[i], nil
+		}
+	}
+
+	return nil, errors.NewNotFoundf("no notification channel found for id %s", id)
+}
@@ -1,13 +1,19 @@
 package newrelic
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/meta"
+	"github.com/newrelic/newrelic-client-go/pkg/region"
 )
 
 var (
@@ -23,13 +29,37 @@ var (
 // TerraformProviderProductUserAgent string used to identify this provider in User Agent requests
 const TerraformProviderProductUserAgent = "terraform-provider-newrelic"
 
-const (
-	insightsInsertURL = "https://insights-collector.newrelic.com/v1/accounts"
-	insightsQueryURL  = "https://insights-api.newrelic.com/v1/accounts"
-)
+type insightsURLs struct {
+	insert string
+	query  string
+}
+
+// insightsRegionURLs holds the Event API endpoints of each region supported by
+// newrelic-client-go, which only exposes the insert endpoint itself.
+var insightsRegionURLs = map[region.Name]insightsURLs{
+	region.US: {
+		insert: "https://insights-collector.newrelic.com/v1/accounts",
+		query:  "https://insights-api.newrelic.com/v1/accounts",
+	},
+	region.EU: {
+		insert: "https://insights-collector.eu01.nr-data.net/v1/accounts",
+		query:  "https://insights-api.eu.newrelic.com/v1/accounts",
+	},
+	region.Staging: {
+		insert: "https://staging-insights-collector.newrelic.com/v1/accounts",
+		query:  "https://staging-insights-api.newrelic.com/v1/accounts",
+	},
+}
 
 // Provider represents a resource provider in Terraform
 func Provider() *schema.Provider {
+	return newProvider(nil)
+}
+
+// newProvider returns the provider, wrapping the transport of its API clients
+// with wrapTransport when set. The acceptance tests use it to record and
+// replay their requests.
+func newProvider(wrapTransport func(http.RoundTripper) http.RoundTripper) *schema.Provider {
 	deprecationMsgBaseURLs := "New Relic internal use only. API URLs are now configured based on the configured region."
 
 	provider := &schema.Provider{
@@ -96,12 +126,30 @@ func Provider() *schema.Provider {
 			"insights_insert_url": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				DefaultFunc: schema.EnvDefaultFunc("NEW_RELIC_INSIGHTS_INSERT_URL", insightsInsertURL),
+				DefaultFunc: schema.EnvDefaultFunc("NEW_RELIC_INSIGHTS_INSERT_URL", ""),
+				Description: "The Insights insert API URL. Defaults to the endpoint of the configured region.",
 			},
 			"insights_query_url": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				DefaultFunc: schema.EnvDefaultFunc("NEW_RELIC_INSIGHTS_QUERY_URL", insightsQueryURL),
+				DefaultFunc: schema.EnvDefaultFunc("NEW_RELIC_INSIGHTS_QUERY_URL", ""),
+				Description: "The Insights query API URL. Defaults to the endpoint of the configured region.",
+			},
+			"default_tags": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Tags applied to every entity created by the resources of this provider that support entity tags.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"tags": {
+							Type:        schema.TypeMap,
+							Required:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "A map of tag keys to tag values.",
+						},
+					},
+				},
 			},
 			"insecure_skip_verify": {
 				Type:        schema.TypeBool,
@@ -113,20 +161,67 @@ func Provider() *schema.Provider {
 				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("NEW_RELIC_API_CACERT", ""),
 			},
+			"client_cert_file": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("NEW_RELIC_API_CLIENT_CERT", ""),
+				Description:  "A path to a PEM-encoded client certificate used for mutual TLS.",
+				RequiredWith: []string{"client_key_file"},
+			},
+			"client_key_file": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("NEW_RELIC_API_CLIENT_KEY", ""),
+				Description:  "A path to the PEM-encoded private key of the client certificate used for mutual TLS.",
+				RequiredWith: []string{"client_cert_file"},
+			},
+			"proxy_url": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("NEW_RELIC_PROXY_URL", ""),
+				Description:  "The URL of an HTTP proxy used for all API requests, overriding the HTTP_PROXY and HTTPS_PROXY environment variables. Proxy credentials can be included in the URL.",
+				ValidateFunc: validation.IsURLWithScheme([]string{"http", "https", "socks5"}),
+			},
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("NEW_RELIC_MAX_RETRIES", defaultMaxRetries),
+				Description:  "The maximum number of times a request is retried after being rate limited, failing with a transient server error, or failing to connect. Set to 0 to disable retries.",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"max_concurrent_requests": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("NEW_RELIC_MAX_CONCURRENT_REQUESTS", 0),
+				Description:  "The maximum number of concurrent requests made to the New Relic APIs by all resources and data sources. Defaults to 0, which means unlimited.",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"max_retry_backoff": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("NEW_RELIC_MAX_RETRY_BACKOFF", defaultMaxRetryBackoff),
+				Description:  "The maximum time, in seconds, to wait between retries, including waits requested by a Retry-After header.",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
-			"newrelic_account":                      dataSourceNewRelicAccount(),
-			"newrelic_alert_channel":                dataSourceNewRelicAlertChannel(),
-			"newrelic_alert_policy":                 dataSourceNewRelicAlertPolicy(),
-			"newrelic_application":                  dataSourceNewRelicApplication(),
-			"newrelic_entity":                       dataSourceNewRelicEntity(),
-			"newrelic_key_transaction":              dataSourceNewRelicKeyTransaction(),
-			"newrelic_plugin":                       dataSourceNewRelicPlugin(),
-			"newrelic_plugin_component":             dataSourceNewRelicPluginComponent(),
-			"newrelic_synthetics_monitor":           dataSourceNewRelicSyntheticsMonitor(),
-			"newrelic_synthetics_monitor_location":  dataSourceNewRelicSyntheticsMonitorLocation(),
-			"newrelic_synthetics_secure_credential": dataSourceNewRelicSyntheticsSecureCredential(),
+			"newrelic_account":                         dataSourceNewRelicAccount(),
+			"newrelic_alert_channel":                   dataSourceNewRelicAlertChannel(),
+			"newrelic_alert_condition_nrql_conversion": dataSourceNewRelicAlertConditionNrqlConversion(),
+			"newrelic_alert_conditions":                dataSourceNewRelicAlertConditions(),
+			"newrelic_alert_policy":                    dataSourceNewRelicAlertPolicy(),
+			"newrelic_application":                     dataSourceNewRelicApplication(),
+			"newrelic_entity":                          dataSourceNewRelicEntity(),
+			"newrelic_key_transaction":                 dataSourceNewRelicKeyTransaction(),
+			"newrelic_notification_channel":            dataSourceNewRelicNotificationChannel(),
+			"newrelic_notification_destination":        dataSourceNewRelicNotificationDestination(),
+			"newrelic_plugin":                          dataSourceNewRelicPlugin(),
+			"newrelic_plugin_component":                dataSourceNewRelicPluginComponent(),
+			"newrelic_synthetics_monitor":              dataSourceNewRelicSyntheticsMonitor(),
+			"newrelic_synthetics_monitor_location":     dataSourceNewRelicSyntheticsMonitorLocation(),
+			"newrelic_synthetics_secure_credential":    dataSourceNewRelicSyntheticsSecureCredential(),
+			"newrelic_workflow":                        dataSourceNewRelicWorkflow(),
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
@@ -142,33 +237,38 @@ func Provider() *schema.Provider {
 			"newrelic_events_to_metrics_rule":                   resourceNewRelicEventsToMetricsRule(),
 			"newrelic_infra_alert_condition":                    resourceNewRelicInfraAlertCondition(),
 			"newrelic_insights_event":                           resourceNewRelicInsightsEvent(),
+			"newrelic_notification_channel":                     resourceNewRelicNotificationChannel(),
+			"newrelic_notification_destination":                 resourceNewRelicNotificationDestination(),
 			"newrelic_nrql_alert_condition":                     resourceNewRelicNrqlAlertCondition(),
 			"newrelic_nrql_drop_rule":                           resourceNewRelicNRQLDropRule(),
 			"newrelic_one_dashboard":                            resourceNewRelicOneDashboard(),
 			"newrelic_one_dashboard_raw":                        resourceNewRelicOneDashboardRaw(),
+			"newrelic_one_dashboard_json":                       resourceNewRelicOneDashboardJSON(),
 			"newrelic_plugins_alert_condition":                  resourceNewRelicPluginsAlertCondition(),
+			"newrelic_service_level":                            resourceNewRelicServiceLevel(),
 			"newrelic_synthetics_alert_condition":               resourceNewRelicSyntheticsAlertCondition(),
 			"newrelic_synthetics_monitor":                       resourceNewRelicSyntheticsMonitor(),
 			"newrelic_synthetics_monitor_script":                resourceNewRelicSyntheticsMonitorScript(),
 			"newrelic_synthetics_multilocation_alert_condition": resourceNewRelicSyntheticsMultiLocationAlertCondition(),
 			"newrelic_synthetics_secure_credential":             resourceNewRelicSyntheticsSecureCredential(),
+			"newrelic_workflow":                                 resourceNewRelicWorkflow(),
 			"newrelic_workload":                                 resourceNewRelicWorkload(),
 		},
 	}
 
-	provider.ConfigureFunc = func(d *schema.ResourceData) (interface{}, error) {
+	provider.ConfigureContextFunc = func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 		terraformVersion := provider.TerraformVersion
 		if terraformVersion == "" {
 			// Catch for versions < 0.12
 			terraformVersion = "0.11+compatible"
 		}
-		return providerConfigure(d, terraformVersion)
+		return providerConfigure(d, terraformVersion, wrapTransport)
 	}
 
 	return provider
 }
 
-func providerConfigure(data *schema.ResourceData, terraformVersion string) (interface{}, error) {
+func providerConfigure(data *schema.ResourceData, terraformVersion string, wrapTransport func(http.RoundTripper) http.RoundTripper) (interface{}, diag.Diagnostics) {
 	adminAPIKey := data.Get("admin_api_key").(string)
 	personalAPIKey := data.Get("api_key").(string)
 	terraformUA := fmt.Sprintf("HashiCorp Terraform/%s (+https://www.terraform.io) Terraform Plugin SDK/%s", terraformVersion, meta.SDKVersionString())
@@ -188,22 +288,32 @@ func providerConfigure(data *schema.ResourceData, terraformVersion string) (inte
 		userAgent:            userAgent,
 		InsecureSkipVerify:   data.Get("insecure_skip_verify").(bool),
 		CACertFile:           data.Get("cacert_file").(string),
+		ClientCertFile:       data.Get("client_cert_file").(string),
+		ClientKeyFile:        data.Get("client_key_file").(string),
+		ProxyURL:             data.Get("proxy_url").(string),
+		MaxRetries:           data.Get("max_retries").(int),
+		MaxRetryBackoff:      time.Duration(data.Get("max_retry_backoff").(int)) * time.Second,
+		requestLimiter:       newRequestLimiter(data.Get("max_concurrent_requests").(int)),
+		wrapTransport:        wrapTransport,
 	}
 	log.Println("[INFO] Initializing newrelic-client-go")
 
 	client, err := cfg.Client()
 	if err != nil {
-		return nil, fmt.Errorf("error initializing newrelic-client-go: %w", err)
+		return nil, diag.Errorf("error initializing newrelic-client-go: %s", err)
 	}
 
-	insightsInsertConfig := Config{
-		InsightsAccountID: strconv.Itoa(accountID),
-		InsightsInsertKey: data.Get("insights_insert_key").(string),
-		InsightsInsertURL: data.Get("insights_insert_url").(string),
-	}
-	clientInsightsInsert, err := insightsInsertConfig.ClientInsightsInsert()
+	insightsURLs, diags := getInsightsURLs(data)
+
+	// The Insights insert client shares the transport settings of newrelic-client-go.
+	cfg.InsightsAccountID = strconv.Itoa(accountID)
+	cfg.InsightsInsertKey = data.Get("insights_insert_key").(string)
+	cfg.InsightsInsertURL = insightsURLs.insert
+	cfg.InsightsQueryURL = insightsURLs.query
+
+	clientInsightsInsert, err := cfg.ClientInsightsInsert()
 	if err != nil {
-		return nil, fmt.Errorf("error initializing New Relic Insights insert client: %w", err)
+		return nil, append(diags, diag.Errorf("error initializing New Relic Insights insert client: %s", err)...)
 	}
 
 	providerConfig := ProviderConfig{
@@ -211,9 +321,10 @@ func providerConfigure(data *schema.ResourceData, terraformVersion string) (inte
 		InsightsInsertClient: clientInsightsInsert,
 		PersonalAPIKey:       personalAPIKey,
 		AccountID:            accountID,
+		DefaultTags:          expandProviderDefaultTags(data.Get("default_tags").([]interface{})),
 	}
 
-	return &providerConfig, nil
+	return &providerConfig, diags
 }
 
 func getInfraAPIURL(data *schema.ResourceData) string {
@@ -225,3 +336,57 @@ func getInfraAPIURL(data *schema.ResourceData) string {
 
 	return ""
 }
+
+// getInsightsURLs returns the Insights insert and query URLs for the configured
+// region. Explicitly configured URLs take precedence, but a warning is returned
+// when one of them belongs to a different region.
+func getInsightsURLs(data *schema.ResourceData) (insightsURLs, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	regionName, err := region.Parse(data.Get("region").(string))
+	if err != nil {
+		regionName = region.Default
+	}
+
+	urls, ok := insightsRegionURLs[regionName]
+	if !ok {
+		urls = insightsRegionURLs[region.Default]
+	}
+
+	for _, u := range []struct {
+		attr string
+		url  *string
+	}{
+		{"insights_insert_url", &urls.insert},
+		{"insights_query_url", &urls.query},
+	} {
+		attr := u.attr
+		configuredURL := data.Get(attr).(string)
+		if configuredURL == "" {
+			continue
+		}
+
+		if name, ok := getInsightsURLRegion(configuredURL); ok && name != regionName {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("%s does not match the configured region", attr),
+				Detail:   fmt.Sprintf("%s %q is an endpoint of the %s region, but the provider is configured for the %s region. The configured URL will be used.", attr, configuredURL, name, regionName),
+			})
+		}
+
+		*u.url = configuredURL
+	}
+
+	return urls, diags
+}
+
+// getInsightsURLRegion returns the region a known Insights URL belongs to.
+func getInsightsURLRegion(insightsURL string) (region.Name, bool) {
+	for name, urls := range insightsRegionURLs {
+		if strings.HasPrefix(insightsURL, urls.insert) || strings.HasPrefix(insightsURL, urls.query) {
+			return name, true
+		}
+	}
+
+	return "", false
+}
@@ -7,7 +7,6 @@ import (
 	"regexp"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -27,7 +26,7 @@ func TestAccNewRelicProvider_Region(t *testing.T) {
 	// This error message will occur when configuring
 	// US region with EU API URLs when using the TF test account.
 	expectedErrorMsg := "403 response returned"
-	rName := acctest.RandString(5)
+	rName := testAccRandString(t, 5)
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -9,8 +9,14 @@ package newrelic
 
 import (
 	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"net/http"
 	"os"
+	"path/filepath"
 	"strconv"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -32,11 +38,26 @@ var (
 	//testAccCleanupComplete          = false
 )
 
+const (
+	// testAccCassetteDir holds the cassettes of the acceptance tests, see testAccUseCassette.
+	testAccCassetteDir = "testdata/cassettes"
+
+	// testAccReplayAccountID is the account ID used when replaying a cassette
+	// without NEW_RELIC_ACCOUNT_ID being set.
+	testAccReplayAccountID = 1000000
+)
+
+var (
+	testAccRecording     = os.Getenv("NEW_RELIC_VCR_RECORD") != ""
+	testAccRandSourcesMu sync.Mutex
+	testAccRandSources   = map[string]*rand.Rand{}
+)
+
 func init() {
-	testAccExpectedAlertChannelName = fmt.Sprintf("%s tf-test@example.com", acctest.RandString(5))
-	testAccExpectedApplicationName = fmt.Sprintf("tf_test_%s", acctest.RandString(10))
-	testAccExpectedAlertPolicyName = fmt.Sprintf("tf_test_%s", acctest.RandString(10))
-	testAccProvider = Provider()
+	testAccExpectedAlertChannelName = fmt.Sprintf("%s tf-test@example.com", testAccRandStringFor("testAccExpectedAlertChannelName", 5))
+	testAccExpectedApplicationName = fmt.Sprintf("tf_test_%s", testAccRandStringFor("testAccExpectedApplicationName", 10))
+	testAccExpectedAlertPolicyName = fmt.Sprintf("tf_test_%s", testAccRandStringFor("testAccExpectedAlertPolicyName", 10))
+	testAccProvider = newProvider(testAccCassetteTransport)
 	testAccProviders = map[string]*schema.Provider{
 		"newrelic": testAccProvider,
 	}
@@ -47,6 +68,8 @@ func init() {
 
 	if v, _ := strconv.Atoi(os.Getenv("NEW_RELIC_ACCOUNT_ID")); v != 0 {
 		testAccountID = v
+	} else {
+		testAccountID = testAccReplayAccountID
 	}
 
 	// Used for cross-account scenarios if needed, such as dashboard widgets.
@@ -76,6 +99,11 @@ resource "newrelic_alert_policy" "foo" {
 }
 
 func testAccPreCheck(t *testing.T) {
+	if testAccReplaying(t) {
+		testAccUseCassette(t, cassetteReplay)
+		return
+	}
+
 	if v := os.Getenv("NEW_RELIC_API_KEY"); v == "" {
 		t.Skipf("[WARN] NEW_RELIC_API_KEY has not been set for acceptance tests")
 	}
@@ -88,6 +116,14 @@ func testAccPreCheck(t *testing.T) {
 		t.Skipf("NEW_RELIC_ACCOUNT_ID must be set for acceptance tests")
 	}
 
+	if testAccRecording {
+		testAccUseCassette(t, cassetteRecord)
+	} else if testAccHasCassettes() {
+		// The requests of the test must not go to the cassette of a test
+		// replayed at the same time.
+		testAccUseNoCassette(t)
+	}
+
 	//testAccApplicationsCleanup(t)
 	testAccCreateApplication(t)
 
@@ -95,6 +131,176 @@ func testAccPreCheck(t *testing.T) {
 	time.Sleep(5 * time.Second)
 }
 
+// testAccCassettes holds the cassette of the test being recorded or
+// replayed, see testAccCassetteTransport. running is held by the test for as
+// long as it runs.
+var testAccCassettes struct {
+	running sync.Mutex
+	mu      sync.Mutex
+	current *cassette
+}
+
+// testAccCassetteTransport records the requests of the acceptance tests to
+// the cassette of the test being recorded, or replays them from the cassette
+// of the test being replayed.
+func testAccCassetteTransport(t http.RoundTripper) http.RoundTripper {
+	return newCassetteTransport(t, testAccCurrentCassette,
+		os.Getenv("NEW_RELIC_API_KEY"),
+		os.Getenv("NEW_RELIC_ADMIN_API_KEY"),
+		os.Getenv("NEW_RELIC_INSIGHTS_INSERT_KEY"),
+		os.Getenv("NEW_RELIC_INSIGHTS_QUERY_KEY"),
+		os.Getenv("NEW_RELIC_LICENSE_KEY"),
+	)
+}
+
+func testAccCurrentCassette() *cassette {
+	testAccCassettes.mu.Lock()
+	defer testAccCassettes.mu.Unlock()
+
+	return testAccCassettes.current
+}
+
+// testAccUseCassette records the requests made by the test to its cassette,
+// or replays them from its cassette. The cassette of a test is named after
+// the test.
+//
+// The tests share a provider, so their requests can't be told apart. Tests
+// using a cassette run one at a time, which keeps the requests of a test in
+// its own cassette, and answers them only from its own cassette.
+//
+// Cassettes are recorded by running the acceptance tests with
+// NEW_RELIC_VCR_RECORD set. A test with a cassette is replayed otherwise,
+// and doesn't need any credentials.
+func testAccUseCassette(t *testing.T, mode cassetteMode) {
+	c, err := newCassette(testAccCassettePath(t), mode, map[string]int{
+		"{{ACCOUNT_ID}}":    testAccountID,
+		"{{SUBACCOUNT_ID}}": testSubaccountID,
+	})
+	if err != nil {
+		t.Fatalf("error loading cassette: %s", err)
+	}
+
+	if mode == cassetteReplay {
+		for k, v := range map[string]string{
+			"NEW_RELIC_API_KEY":    testAccAPIKey,
+			"NEW_RELIC_ACCOUNT_ID": strconv.Itoa(testAccountID),
+		} {
+			if os.Getenv(k) == "" {
+				os.Setenv(k, v)
+			}
+		}
+	}
+
+	testAccSetCassette(t, c)
+
+	if mode == cassetteRecord {
+		t.Cleanup(func() {
+			if err := c.save(); err != nil {
+				t.Errorf("error saving cassette: %s", err)
+			}
+		})
+	}
+}
+
+// testAccUseNoCassette sends the requests of a test without a cassette to New
+// Relic, while no other test is using a cassette.
+func testAccUseNoCassette(t *testing.T) {
+	testAccSetCassette(t, nil)
+}
+
+func testAccSetCassette(t *testing.T, c *cassette) {
+	testAccCassettes.running.Lock()
+
+	testAccCassettes.mu.Lock()
+	testAccCassettes.current = c
+	testAccCassettes.mu.Unlock()
+
+	t.Cleanup(func() {
+		testAccCassettes.mu.Lock()
+		testAccCassettes.current = nil
+		testAccCassettes.mu.Unlock()
+
+		testAccCassettes.running.Unlock()
+	})
+}
+
+// testAccHasCassettes returns whether any test has a cassette.
+func testAccHasCassettes() bool {
+	cassettes, _ := filepath.Glob(filepath.Join(testAccCassetteDir, "*.json"))
+
+	return len(cassettes) > 0
+}
+
+func testAccCassettePath(t *testing.T) string {
+	return filepath.Join(testAccCassetteDir, strings.ReplaceAll(t.Name(), "/", "_")+".json")
+}
+
+// testAccReplaying returns whether the test replays its cassette.
+func testAccReplaying(t *testing.T) bool {
+	if testAccRecording {
+		return false
+	}
+
+	_, err := os.Stat(testAccCassettePath(t))
+
+	return err == nil
+}
+
+// testAccRandString returns a random string for the names of the resources
+// created by the test. A test using a cassette gets the same strings on
+// every run, so its requests match the ones recorded.
+func testAccRandString(t *testing.T, n int) string {
+	if !testAccRecording && !testAccReplaying(t) {
+		return acctest.RandString(n)
+	}
+
+	testAccRandSourcesMu.Lock()
+	_, ok := testAccRandSources[t.Name()]
+	testAccRandSourcesMu.Unlock()
+
+	// Every run of the test starts over with the same strings.
+	if !ok {
+		t.Cleanup(func() {
+			testAccRandSourcesMu.Lock()
+			defer testAccRandSourcesMu.Unlock()
+
+			delete(testAccRandSources, t.Name())
+		})
+	}
+
+	return testAccCassetteRandString(t.Name(), n)
+}
+
+// testAccRandStringFor is testAccRandString for names shared by several
+// tests, which are identified by label instead.
+func testAccRandStringFor(label string, n int) string {
+	if _, err := os.Stat(testAccCassetteDir); !testAccRecording && err != nil {
+		return acctest.RandString(n)
+	}
+
+	return testAccCassetteRandString(label, n)
+}
+
+func testAccCassetteRandString(label string, n int) string {
+	testAccRandSourcesMu.Lock()
+	defer testAccRandSourcesMu.Unlock()
+
+	r, ok := testAccRandSources[label]
+	if !ok {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(label))
+		r = rand.New(rand.NewSource(int64(h.Sum64())))
+		testAccRandSources[label] = r
+	}
+
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = acctest.CharSetAlpha[r.Intn(len(acctest.CharSetAlpha))]
+	}
+
+	return string(b)
+}
+
 func testAccCreateApplication(t *testing.T) {
 	app, err := newrelic.NewApplication(
 		newrelic.ConfigFromEnvironment(),
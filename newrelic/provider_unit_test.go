@@ -5,6 +5,9 @@ package newrelic
 
 import (
 	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestProvider(t *testing.T) {
@@ -25,3 +28,53 @@ func TestProviderConfig(t *testing.T) {
 		t.Error("hasNerdGraphCreds should be true")
 	}
 }
+
+func TestGetInsightsURLs(t *testing.T) {
+	cases := map[string]struct {
+		raw             map[string]interface{}
+		expectedInsert  string
+		expectedQuery   string
+		expectedWarning bool
+	}{
+		"US region": {
+			raw:            map[string]interface{}{"region": "US"},
+			expectedInsert: "https://insights-collector.newrelic.com/v1/accounts",
+			expectedQuery:  "https://insights-api.newrelic.com/v1/accounts",
+		},
+		"EU region": {
+			raw:            map[string]interface{}{"region": "EU"},
+			expectedInsert: "https://insights-collector.eu01.nr-data.net/v1/accounts",
+			expectedQuery:  "https://insights-api.eu.newrelic.com/v1/accounts",
+		},
+		"explicit URLs win": {
+			raw: map[string]interface{}{
+				"region":              "EU",
+				"insights_insert_url": "https://insights.example.com/v1/accounts",
+			},
+			expectedInsert: "https://insights.example.com/v1/accounts",
+			expectedQuery:  "https://insights-api.eu.newrelic.com/v1/accounts",
+		},
+		"explicit URL of another region": {
+			raw: map[string]interface{}{
+				"region":              "EU",
+				"insights_insert_url": "https://insights-collector.newrelic.com/v1/accounts",
+			},
+			expectedInsert:  "https://insights-collector.newrelic.com/v1/accounts",
+			expectedQuery:   "https://insights-api.eu.newrelic.com/v1/accounts",
+			expectedWarning: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, Provider().Schema, tc.raw)
+
+			urls, diags := getInsightsURLs(d)
+
+			assert.Equal(t, tc.expectedInsert, urls.insert)
+			assert.Equal(t, tc.expectedQuery, urls.query)
+			assert.False(t, diags.HasError())
+			assert.Equal(t, tc.expectedWarning, len(diags) > 0)
+		})
+	}
+}
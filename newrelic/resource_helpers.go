@@ -105,6 +105,33 @@ func resourceImportStateWithMetadata(defaultIDCount int, attribute string) schem
 	}
 }
 
+// Handles importing of resources identified by a non-numeric ID, such as a
+// UUID, which might be followed by the account ID of the resource.
+//
+// e.g. "e3ac7d5a-4f58-4a1b-8cb5-6c5a5ad1d5f0:2384930" (<ID>:<accountID>)
+func resourceImportStateWithAccountID(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	idItems := strings.Split(d.Id(), ":")
+
+	if len(idItems) > 2 {
+		return []*schema.ResourceData{}, fmt.Errorf("unhandled id format %s, expected <id> or <id>:<account_id>", d.Id())
+	}
+
+	if len(idItems) == 2 {
+		accountID, err := strconv.Atoi(idItems[1])
+		if err != nil {
+			return []*schema.ResourceData{}, fmt.Errorf("invalid account ID %s: %s", idItems[1], err)
+		}
+
+		if err := d.Set("account_id", accountID); err != nil {
+			return []*schema.ResourceData{}, err
+		}
+
+		d.SetId(idItems[0])
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
 // Selects the proper accountID for usage within a resource. An account ID provided
 // within a `resource` block will override a `provider` block account ID. This ensures
 // resources can be scoped to specific accounts. Bear in mind those accounts must be
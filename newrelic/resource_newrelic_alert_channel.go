@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/newrelic/newrelic-client-go/pkg/errors"
@@ -57,8 +58,12 @@ func resourceNewRelicAlertChannel() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceNewRelicAlertChannelCreate,
 		ReadContext:   resourceNewRelicAlertChannelRead,
-		// Update: Not currently supported in API
+		UpdateContext: resourceNewRelicAlertChannelUpdate,
 		DeleteContext: resourceNewRelicAlertChannelDelete,
+		CustomizeDiff: customdiff.All(
+			forceNewAlertChannelIfNotUpdatable,
+			customizeAlertChannelSecretHash,
+		),
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
@@ -66,7 +71,6 @@ func resourceNewRelicAlertChannel() *schema.Resource {
 			"name": {
 				Type:        schema.TypeString,
 				Required:    true,
-				ForceNew:    true,
 				Description: "(Required) The name of the channel.",
 			},
 			"type": {
@@ -76,87 +80,90 @@ func resourceNewRelicAlertChannel() *schema.Resource {
 				ValidateFunc: validation.StringInSlice(validAlertChannelTypes, false),
 				Description:  fmt.Sprintf("(Required) The type of channel. One of: (%s).", strings.Join(validAlertChannelTypes, ", ")),
 			},
+			"rotate_secrets": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "An arbitrary value which, when changed, sends the configured secrets to New Relic again, such as after they were changed outside of Terraform.",
+			},
+			"secret_hash": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "A salted hash of the secrets last sent to New Relic. The secrets themselves are not stored in state.",
+			},
 			"config": {
 				Type:        schema.TypeList,
 				Optional:    true,
-				ForceNew:    true,
 				MaxItems:    1,
 				Description: "The configuration block for the alert channel.",
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"api_key": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Sensitive:   true,
-							ForceNew:    true,
-							Description: "The API key for integrating with OpsGenie.",
+							Type:             schema.TypeString,
+							Optional:         true,
+							Sensitive:        true,
+							Description:      "The API key for integrating with OpsGenie.",
+							DiffSuppressFunc: suppressUnchangedAlertChannelSecret,
 						},
 						"auth_password": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Sensitive:   true,
-							ForceNew:    true,
-							Description: "Specifies an authentication password for use with a channel. Supported by the webhook channel type.",
+							Type:             schema.TypeString,
+							Optional:         true,
+							Sensitive:        true,
+							Description:      "Specifies an authentication password for use with a channel. Supported by the webhook channel type.",
+							DiffSuppressFunc: suppressUnchangedAlertChannelSecret,
 						},
 						"auth_type": {
 							Type:         schema.TypeString,
 							Optional:     true,
 							Sensitive:    true,
 							ValidateFunc: validation.StringInSlice([]string{"BASIC"}, false),
-							ForceNew:     true,
 							Description:  "Specifies an authentication method for use with a channel. Supported by the webhook channel type. Only HTTP basic authentication is currently supported via the value BASIC.",
 						},
 						"auth_username": {
 							Type:        schema.TypeString,
 							Optional:    true,
-							ForceNew:    true,
 							Description: "Specifies an authentication username for use with a channel. Supported by the webhook channel type.",
 						},
 						"base_url": {
 							Type:        schema.TypeString,
 							Optional:    true,
 							Sensitive:   true,
-							ForceNew:    true,
 							Description: "The base URL of the webhook destination.",
 						},
 						"channel": {
 							Type:        schema.TypeString,
 							Optional:    true,
-							ForceNew:    true,
 							Description: "The Slack channel to send notifications to.",
 						},
 						"headers": {
-							Type:          schema.TypeMap,
-							Elem:          &schema.Schema{Type: schema.TypeString},
-							Optional:      true,
-							Sensitive:     true,
-							ForceNew:      true,
-							ConflictsWith: []string{"config.0.headers_string"},
-							Description:   "A map of key/value pairs that represents extra HTTP headers to be sent along with the webhook payload.",
+							Type:             schema.TypeMap,
+							Elem:             &schema.Schema{Type: schema.TypeString},
+							Optional:         true,
+							Sensitive:        true,
+							ConflictsWith:    []string{"config.0.headers_string"},
+							Description:      "A map of key/value pairs that represents extra HTTP headers to be sent along with the webhook payload.",
+							DiffSuppressFunc: suppressUnchangedAlertChannelSecret,
 						},
 						"headers_string": {
 							Type:          schema.TypeString,
 							Optional:      true,
 							Sensitive:     true,
-							ForceNew:      true,
 							ConflictsWith: []string{"config.0.headers"},
 							Description:   "Use instead of headers if the desired payload is more complex than a list of key/value pairs (e.g. a set of headers that makes use of nested objects). The value provided should be a valid JSON string with escaped double quotes. Conflicts with headers.",
 							// Suppress the diff shown if the differences are solely due to whitespace
 							DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
-								return stripWhitespace(old) == stripWhitespace(new)
+								return stripWhitespace(old) == stripWhitespace(new) || suppressUnchangedAlertChannelSecret(k, old, new, d)
 							},
 						},
 						"key": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Sensitive:   true,
-							ForceNew:    true,
-							Description: "The key for integrating with VictorOps.",
+							Type:             schema.TypeString,
+							Optional:         true,
+							Sensitive:        true,
+							Description:      "The key for integrating with VictorOps.",
+							DiffSuppressFunc: suppressUnchangedAlertChannelSecret,
 						},
 						"include_json_attachment": {
 							Type:         schema.TypeString,
 							Optional:     true,
-							ForceNew:     true,
 							Description:  "true or false. Flag for whether or not to attach a JSON document containing information about the associated alert to the email that is sent to recipients.",
 							ValidateFunc: validation.StringInSlice([]string{"true", "false", "1", "0"}, true),
 							DiffSuppressFunc: func(k, old, current string, d *schema.ResourceData) bool {
@@ -180,7 +187,6 @@ func resourceNewRelicAlertChannel() *schema.Resource {
 							Elem:          &schema.Schema{Type: schema.TypeString},
 							Sensitive:     true,
 							Optional:      true,
-							ForceNew:      true,
 							ConflictsWith: []string{"config.0.payload_string"},
 							Description:   "A map of key/value pairs that represents the webhook payload. Must provide payload_type if setting this argument.",
 						},
@@ -188,7 +194,6 @@ func resourceNewRelicAlertChannel() *schema.Resource {
 							Type:          schema.TypeString,
 							Optional:      true,
 							Sensitive:     true,
-							ForceNew:      true,
 							ConflictsWith: []string{"config.0.payload"},
 							Description:   "Use instead of payload if the desired payload is more complex than a list of key/value pairs (e.g. a payload that makes use of nested objects). The value provided should be a valid JSON string with escaped double quotes. Conflicts with payload.",
 							// Suppress the diff shown if the differences are solely due to whitespace
@@ -199,60 +204,53 @@ func resourceNewRelicAlertChannel() *schema.Resource {
 						"payload_type": {
 							Type:         schema.TypeString,
 							Optional:     true,
-							ForceNew:     true,
 							ValidateFunc: validation.StringInSlice([]string{"application/json", "application/x-www-form-urlencoded"}, false),
 							Description:  "Can either be application/json or application/x-www-form-urlencoded. The payload_type argument is required if payload is set.",
 						},
 						"recipients": {
 							Type:        schema.TypeString,
 							Optional:    true,
-							ForceNew:    true,
 							Description: "A set of recipients for targeting notifications. Multiple values are comma separated.",
 						},
 						"region": {
 							Type:         schema.TypeString,
 							Optional:     true,
 							ValidateFunc: validation.StringInSlice([]string{"US", "EU"}, false),
-							ForceNew:     true,
 							Description:  "The data center region to store your data. Valid values are US and EU. Default is US.",
 						},
 						"route_key": {
 							Type:        schema.TypeString,
 							Optional:    true,
 							Sensitive:   true,
-							ForceNew:    true,
 							Description: "The route key for integrating with VictorOps.",
 						},
 						"service_key": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Sensitive:   true,
-							ForceNew:    true,
-							Description: "Specifies the service key for integrating with Pagerduty.",
+							Type:             schema.TypeString,
+							Optional:         true,
+							Sensitive:        true,
+							Description:      "Specifies the service key for integrating with Pagerduty.",
+							DiffSuppressFunc: suppressUnchangedAlertChannelSecret,
 						},
 						"tags": {
 							Type:        schema.TypeString,
 							Optional:    true,
-							ForceNew:    true,
 							Description: "A set of tags for targeting notifications. Multiple values are comma separated.",
 						},
 						"teams": {
 							Type:        schema.TypeString,
 							Optional:    true,
-							ForceNew:    true,
 							Description: "A set of teams for targeting notifications. Multiple values are comma separated.",
 						},
 						"url": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Sensitive:   true,
-							ForceNew:    true,
-							Description: "Your organization's Slack URL.",
+							Type:             schema.TypeString,
+							Optional:         true,
+							Sensitive:        true,
+							Description:      "Your organization's Slack URL.",
+							DiffSuppressFunc: suppressUnchangedAlertChannelSecret,
 						},
 						"user_id": {
 							Type:        schema.TypeString,
 							Optional:    true,
-							ForceNew:    true,
 							Description: "The user ID for use with the user channel type.",
 						},
 					},
@@ -278,6 +276,13 @@ func resourceNewRelicAlertChannelCreate(ctx context.Context, d *schema.ResourceD
 
 	d.SetId(strconv.Itoa(channel.ID))
 
+	secretHash, err := newAlertChannelSecretHash(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_ = d.Set("secret_hash", secretHash)
+
 	return resourceNewRelicAlertChannelRead(ctx, d, meta)
 }
 
@@ -301,9 +306,69 @@ func resourceNewRelicAlertChannelRead(ctx context.Context, d *schema.ResourceDat
 		return diag.FromErr(err)
 	}
 
+	// Channels created before secret_hash or imported have no secret hash yet,
+	// it's seeded with the secrets in state, if any, which are then dropped.
+	if alertChannelSecretSalt(d.Get("secret_hash").(string)) == "" {
+		salt, err := newAlertChannelSecretSalt()
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		_ = d.Set("secret_hash", alertChannelSecretHash(salt, alertChannelConfig(d.Get("config"))))
+	}
+
 	return diag.FromErr(flattenAlertChannel(channel, d))
 }
 
+func resourceNewRelicAlertChannelUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+
+	if !providerConfig.hasNerdGraphCredentials() {
+		return diag.Errorf("err: NerdGraph support not present, but required for Update")
+	}
+
+	channel, err := expandAlertChannel(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The secrets in state are blank, so they're only sent when they changed.
+	secretsChanged := alertChannelSecretsChanged(d)
+
+	input, err := expandAlertChannelUpdateInput(channel, secretsChanged)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Updating New Relic alert channel %s", d.Id())
+
+	vars := map[string]interface{}{
+		"accountId":           providerConfig.AccountID,
+		"id":                  d.Id(),
+		"notificationChannel": input,
+	}
+
+	resp := alertChannelUpdateResponse{}
+	if err := providerConfig.NewClient.NerdGraph.QueryWithResponseAndContext(ctx, updateAlertChannelMutation, vars, &resp); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if e := resp.AlertsNotificationChannelUpdate.Error; e != nil {
+		return diag.Errorf("error updating alert channel %s: %s: %s", d.Id(), e.ErrorType, e.Description)
+	}
+
+	if secretsChanged {
+		secretHash, err := newAlertChannelSecretHash(d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		_ = d.Set("secret_hash", secretHash)
+	}
+
+	return resourceNewRelicAlertChannelRead(ctx, d, meta)
+}
+
 func resourceNewRelicAlertChannelDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*ProviderConfig).NewClient
 
@@ -320,3 +385,155 @@ func resourceNewRelicAlertChannelDelete(ctx context.Context, d *schema.ResourceD
 
 	return nil
 }
+
+// The REST API can't update alert channels and newrelic-client-go does not
+// model NerdGraph's notification channel mutations yet, so channels are
+// updated with this NerdGraph document.
+const updateAlertChannelMutation = `mutation($accountId: Int!, $id: ID!, $notificationChannel: AlertsNotificationChannelUpdateConfiguration!) {
+	alertsNotificationChannelUpdate(accountId: $accountId, id: $id, notificationChannel: $notificationChannel) {
+		notificationChannel {
+			id
+		}
+		error {
+			description
+			errorType
+		}
+	}
+}`
+
+type alertChannelUpdateResponse struct {
+	AlertsNotificationChannelUpdate struct {
+		Error *struct {
+			Description string `json:"description"`
+			ErrorType   string `json:"errorType"`
+		} `json:"error"`
+	} `json:"alertsNotificationChannelUpdate"`
+}
+
+// forceNewAlertChannelIfNotUpdatable replaces the channels which can't be
+// updated in place, such as user channels or any channel when NerdGraph
+// credentials are missing, when their name or config changes.
+func forceNewAlertChannelIfNotUpdatable(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	if alertChannelUpdatable(d, meta) {
+		return nil
+	}
+
+	keys := d.GetChangedKeysPrefix("config")
+	for _, key := range []string{"name", "rotate_secrets"} {
+		if d.HasChange(key) {
+			keys = append(keys, key)
+		}
+	}
+
+	for _, key := range keys {
+		if err := d.ForceNew(key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// alertChannelUpdatable reports whether a channel can be updated in place.
+func alertChannelUpdatable(d *schema.ResourceDiff, meta interface{}) bool {
+	if providerConfig, ok := meta.(*ProviderConfig); !ok || !providerConfig.hasNerdGraphCredentials() {
+		return false
+	}
+
+	_, ok := alertChannelUpdateInputNames[d.Get("type").(string)]
+
+	return ok
+}
+
+// customizeAlertChannelSecretHash plans a new secret_hash when the configured
+// secrets no longer match the hash of the secrets last sent to New Relic, or
+// when they have to be sent again. The channels which can't be updated are
+// replaced instead, see forceNewAlertChannelIfNotUpdatable.
+func customizeAlertChannelSecretHash(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || !alertChannelUpdatable(d, meta) {
+		return nil
+	}
+
+	secretHash := d.Get("secret_hash").(string)
+	salt := alertChannelSecretSalt(secretHash)
+
+	if salt == "" || d.HasChange("rotate_secrets") || !alertChannelSecretsKnown(d) {
+		return d.SetNewComputed("secret_hash")
+	}
+
+	if h := alertChannelSecretHash(salt, alertChannelConfig(d.Get("config"))); h != secretHash {
+		return d.SetNew("secret_hash", h)
+	}
+
+	return nil
+}
+
+// suppressUnchangedAlertChannelSecret hides the diff between the blank secret
+// in state and the configured secret while the configured secrets match the
+// secret hash.
+func suppressUnchangedAlertChannelSecret(k, old, new string, d *schema.ResourceData) bool {
+	if old != "" && !(strings.HasSuffix(k, ".%") && old == "0") {
+		return false
+	}
+
+	if d.Id() == "" || d.HasChange("rotate_secrets") {
+		return false
+	}
+
+	o, _ := d.GetChange("secret_hash")
+	secretHash := o.(string)
+	salt := alertChannelSecretSalt(secretHash)
+
+	return salt != "" && alertChannelSecretHash(salt, alertChannelConfig(d.Get("config"))) == secretHash
+}
+
+// alertChannelSecretsChanged reports whether the secrets of a channel have to
+// be sent to New Relic. The diff of secrets matching the secret hash is
+// suppressed, so any diff of a secret is a change.
+func alertChannelSecretsChanged(d *schema.ResourceData) bool {
+	if d.HasChanges("secret_hash", "rotate_secrets") {
+		return true
+	}
+
+	for _, k := range alertChannelSecretAttributes {
+		if d.HasChange("config.0." + k) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func alertChannelSecretsKnown(d *schema.ResourceDiff) bool {
+	if !d.NewValueKnown("config") {
+		return false
+	}
+
+	for _, k := range alertChannelSecretAttributes {
+		if !d.NewValueKnown("config.0." + k) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// newAlertChannelSecretHash returns the hash of the configured secrets, with
+// a new salt when the secrets are rotated or haven't been hashed yet.
+func newAlertChannelSecretHash(d *schema.ResourceData) (string, error) {
+	o, _ := d.GetChange("secret_hash")
+	salt := alertChannelSecretSalt(o.(string))
+
+	if salt == "" || d.HasChange("rotate_secrets") {
+		var err error
+		if salt, err = newAlertChannelSecretSalt(); err != nil {
+			return "", err
+		}
+	}
+
+	return alertChannelSecretHash(salt, alertChannelConfig(d.Get("config"))), nil
+}
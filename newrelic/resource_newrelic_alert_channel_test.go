@@ -9,14 +9,13 @@ import (
 	"strconv"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccNewRelicAlertChannel_Basic(t *testing.T) {
 	resourceName := "newrelic_alert_channel.foo"
-	rand := acctest.RandString(5)
+	rand := testAccRandString(t, 5)
 	rName := fmt.Sprintf("tf-test-%s", rand)
 	rNameUpdated := fmt.Sprintf("tf-test-updated-%s", rand)
 	rNameDeprecatedUpdated := fmt.Sprintf("tf-test-deprecated-updated-%s", rand)
@@ -61,9 +60,10 @@ func TestAccNewRelicAlertChannel_Basic(t *testing.T) {
 			},
 			// Test: Import
 			{
-				ResourceName:      resourceName,
-				ImportState:       true,
-				ImportStateVerify: true,
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"secret_hash"},
 			},
 		},
 	})
@@ -71,7 +71,7 @@ func TestAccNewRelicAlertChannel_Basic(t *testing.T) {
 
 func TestAccNewRelicAlertChannel_Webhook(t *testing.T) {
 	resourceName := "newrelic_alert_channel.foo"
-	rand := acctest.RandString(5)
+	rand := testAccRandString(t, 5)
 	rName := fmt.Sprintf("tf-test-%s", rand)
 
 	resource.ParallelTest(t, resource.TestCase{
@@ -102,6 +102,7 @@ func TestAccNewRelicAlertChannel_Webhook(t *testing.T) {
 				ImportStateVerify: true,
 				// ignore sensitive data that's not returned from the API
 				ImportStateVerifyIgnore: []string{
+					"secret_hash", // the secrets of an imported channel are unknown
 					"config.0.base_url",
 					"config.0.auth_password",
 					"config.0.payload",
@@ -112,7 +113,7 @@ func TestAccNewRelicAlertChannel_Webhook(t *testing.T) {
 }
 
 func TestAccNewRelicAlertChannel_WebhookPayloadHeaderStringConflicts(t *testing.T) {
-	rand := acctest.RandString(5)
+	rand := testAccRandString(t, 5)
 	rName := fmt.Sprintf("tf-test-%s", rand)
 
 	resource.ParallelTest(t, resource.TestCase{
@@ -153,7 +154,7 @@ func TestAccNewRelicAlertChannel_WebhookPayloadHeaderStringConflicts(t *testing.
 
 func TestAccNewRelicAlertChannel_WebhookPayloadHeaderString(t *testing.T) {
 	resourceName := "newrelic_alert_channel.foo"
-	rand := acctest.RandString(5)
+	rand := testAccRandString(t, 5)
 	rName := fmt.Sprintf("tf-test-%s", rand)
 
 	resource.ParallelTest(t, resource.TestCase{
@@ -178,7 +179,7 @@ func TestAccNewRelicAlertChannel_WebhookPayloadHeaderString(t *testing.T) {
 
 func TestAccNewRelicAlertChannel_Slack(t *testing.T) {
 	resourceName := "newrelic_alert_channel.foo"
-	rand := acctest.RandString(5)
+	rand := testAccRandString(t, 5)
 	rName := fmt.Sprintf("tf-test-%s", rand)
 
 	resource.ParallelTest(t, resource.TestCase{
@@ -196,7 +197,7 @@ func TestAccNewRelicAlertChannel_Slack(t *testing.T) {
 					testAccCheckNewRelicAlertChannelExists(resourceName),
 					resource.TestCheckResourceAttr(resourceName, "name", rName),
 					resource.TestCheckResourceAttr(resourceName, "type", "slack"),
-					resource.TestCheckResourceAttr(resourceName, "config.0.url", "https://example.slack.com"),
+					resource.TestCheckResourceAttrSet(resourceName, "secret_hash"),
 					resource.TestCheckResourceAttr(resourceName, "config.0.channel", "example-channel"),
 				),
 			},
@@ -206,6 +207,7 @@ func TestAccNewRelicAlertChannel_Slack(t *testing.T) {
 				ImportState:       true,
 				ImportStateVerify: true,
 				ImportStateVerifyIgnore: []string{
+					"secret_hash",  // the secrets of an imported channel are unknown
 					"config.0.url", // ignore sensitive data that's not returned from the API
 				},
 			},
@@ -215,8 +217,9 @@ func TestAccNewRelicAlertChannel_Slack(t *testing.T) {
 
 func TestAccNewRelicAlertChannel_PagerDuty(t *testing.T) {
 	resourceName := "newrelic_alert_channel.foo"
-	rand := acctest.RandString(5)
+	rand := testAccRandString(t, 5)
 	rName := fmt.Sprintf("tf-test-%s", rand)
+	var channelID string
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -232,7 +235,11 @@ func TestAccNewRelicAlertChannel_PagerDuty(t *testing.T) {
 					testAccCheckNewRelicAlertChannelExists(resourceName),
 					resource.TestCheckResourceAttr(resourceName, "name", rName),
 					resource.TestCheckResourceAttr(resourceName, "type", "pagerduty"),
-					resource.TestCheckResourceAttr(resourceName, "config.0.service_key", "abc123"),
+					resource.TestCheckResourceAttrSet(resourceName, "secret_hash"),
+					func(s *terraform.State) error {
+						channelID = s.RootModule().Resources[resourceName].Primary.ID
+						return nil
+					},
 				),
 			},
 			// Test: Update
@@ -244,7 +251,8 @@ func TestAccNewRelicAlertChannel_PagerDuty(t *testing.T) {
 					testAccCheckNewRelicAlertChannelExists(resourceName),
 					resource.TestCheckResourceAttr(resourceName, "name", rName),
 					resource.TestCheckResourceAttr(resourceName, "type", "pagerduty"),
-					resource.TestCheckResourceAttr(resourceName, "config.0.service_key", "abc321"),
+					resource.TestCheckResourceAttrSet(resourceName, "secret_hash"),
+					resource.TestCheckResourceAttrPtr(resourceName, "id", &channelID),
 				),
 			},
 			// Test: Import
@@ -253,6 +261,7 @@ func TestAccNewRelicAlertChannel_PagerDuty(t *testing.T) {
 				ImportState:       true,
 				ImportStateVerify: true,
 				ImportStateVerifyIgnore: []string{
+					"secret_hash",          // the secrets of an imported channel are unknown
 					"config.0.service_key", // ignore sensitive data that's not returned from the API
 				},
 			},
@@ -262,7 +271,7 @@ func TestAccNewRelicAlertChannel_PagerDuty(t *testing.T) {
 
 func TestAccNewRelicAlertChannel_OpsGenie(t *testing.T) {
 	resourceName := "newrelic_alert_channel.foo"
-	rand := acctest.RandString(5)
+	rand := testAccRandString(t, 5)
 	rName := fmt.Sprintf("tf-test-%s", rand)
 
 	resource.ParallelTest(t, resource.TestCase{
@@ -293,6 +302,7 @@ func TestAccNewRelicAlertChannel_OpsGenie(t *testing.T) {
 				ImportState:       true,
 				ImportStateVerify: true,
 				ImportStateVerifyIgnore: []string{
+					"secret_hash",      // the secrets of an imported channel are unknown
 					"config.0.api_key", // ignore sensitive data that's not returned from the API
 				},
 			},
@@ -302,7 +312,7 @@ func TestAccNewRelicAlertChannel_OpsGenie(t *testing.T) {
 
 func TestAccNewRelicAlertChannel_VictorOps(t *testing.T) {
 	resourceName := "newrelic_alert_channel.foo"
-	rand := acctest.RandString(5)
+	rand := testAccRandString(t, 5)
 	rName := fmt.Sprintf("tf-test-%s", rand)
 
 	resource.ParallelTest(t, resource.TestCase{
@@ -329,6 +339,7 @@ func TestAccNewRelicAlertChannel_VictorOps(t *testing.T) {
 				ImportState:       true,
 				ImportStateVerify: true,
 				ImportStateVerifyIgnore: []string{
+					"secret_hash",  // the secrets of an imported channel are unknown
 					"config.0.key", // ignore sensitive data that's not returned from the API
 				},
 			},
@@ -337,7 +348,7 @@ func TestAccNewRelicAlertChannel_VictorOps(t *testing.T) {
 }
 
 func TestAccNewRelicAlertChannel_WebhookPayloadValidation(t *testing.T) {
-	rand := acctest.RandString(5)
+	rand := testAccRandString(t, 5)
 	rName := fmt.Sprintf("tf-test-%s", rand)
 	expectedErrorMsg, _ := regexp.Compile(`payload_type is required when using payload`)
 
@@ -361,7 +372,7 @@ func TestAccNewRelicAlertChannel_WebhookPayloadValidation(t *testing.T) {
 }
 
 func TestAccNewRelicAlertChannel_ResourceNotFound(t *testing.T) {
-	rand := acctest.RandString(5)
+	rand := testAccRandString(t, 5)
 	rName := fmt.Sprintf("tf-test-%s", rand)
 
 	resource.ParallelTest(t, resource.TestCase{
@@ -0,0 +1,246 @@
+//go:build unit
+// +build unit
+
+package newrelic
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRelicAlertChannel_UpdateInPlace(t *testing.T) {
+	f := newFakeBackend(t)
+	meta := testFakeBackendMeta(t, f)
+	r := resourceNewRelicAlertChannel()
+
+	config := func(name string, serviceKey string) map[string]interface{} {
+		return map[string]interface{}{
+			"name": name,
+			"type": "pagerduty",
+			"config": []interface{}{
+				map[string]interface{}{"service_key": serviceKey},
+			},
+		}
+	}
+
+	state := testFakeBackendApply(t, meta, r, nil, config("tf-test", "key-1"))
+	channelID := state.ID
+
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config("tf-test-updated", "key-2")), meta)
+	require.NoError(t, err)
+	assert.False(t, diff.RequiresNew(), "changing the config must not replace the channel")
+
+	state = testFakeBackendApply(t, meta, r, state, config("tf-test-updated", "key-2"))
+	assert.Equal(t, channelID, state.ID)
+	assert.Equal(t, "tf-test-updated", state.Attributes["name"])
+	assert.Len(t, f.channels, 1)
+
+	id, err := strconv.Atoi(channelID)
+	require.NoError(t, err)
+	assert.Equal(t, "key-2", f.channels[id]["configuration"].(map[string]interface{})["service_key"])
+
+	// Renaming the channel leaves its secrets alone.
+	state = testFakeBackendApply(t, meta, r, state, config("tf-test-renamed", "key-2"))
+	assert.Equal(t, "tf-test-renamed", f.channels[id]["name"])
+	assert.Equal(t, "key-2", f.channels[id]["configuration"].(map[string]interface{})["service_key"])
+
+	testFakeBackendApply(t, meta, r, state, nil)
+	assert.Empty(t, f.channels)
+}
+
+func TestNewRelicAlertChannel_SecretHash(t *testing.T) {
+	f := newFakeBackend(t)
+	meta := testFakeBackendMeta(t, f)
+	r := resourceNewRelicAlertChannel()
+
+	config := func(serviceKey string, rotate string) map[string]interface{} {
+		c := map[string]interface{}{
+			"name": "tf-test",
+			"type": "pagerduty",
+			"config": []interface{}{
+				map[string]interface{}{"service_key": serviceKey},
+			},
+		}
+		if rotate != "" {
+			c["rotate_secrets"] = rotate
+		}
+		return c
+	}
+
+	plan := func(state *terraform.InstanceState, config map[string]interface{}) *terraform.InstanceDiff {
+		diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), meta)
+		require.NoError(t, err)
+		return diff
+	}
+
+	state := testFakeBackendApply(t, meta, r, nil, config("key-1", ""))
+	secretHash := state.Attributes["secret_hash"]
+
+	// The secret is not stored in state, only its hash.
+	assert.Empty(t, state.Attributes["config.0.service_key"])
+	assert.NotEmpty(t, secretHash)
+	assert.NotContains(t, secretHash, "key-1")
+
+	state, diags := r.RefreshWithoutUpgrade(context.Background(), state, meta)
+	require.False(t, diags.HasError(), "%+v", diags)
+	assert.Nil(t, plan(state, config("key-1", "")), "unchanged secrets must not show a diff")
+
+	// A changed secret is detected through its hash.
+	diff := plan(state, config("key-2", ""))
+	require.NotNil(t, diff)
+	assert.False(t, diff.RequiresNew())
+	assert.NotNil(t, diff.Attributes["secret_hash"])
+
+	state = testFakeBackendApply(t, meta, r, state, config("key-2", ""))
+	assert.NotEqual(t, secretHash, state.Attributes["secret_hash"])
+	assert.Equal(t, alertChannelSecretSalt(secretHash), alertChannelSecretSalt(state.Attributes["secret_hash"]))
+	assert.Nil(t, plan(state, config("key-2", "")))
+
+	// Rotating the secrets sends them again with a new salt.
+	require.NotNil(t, plan(state, config("key-2", "1")))
+	rotated := testFakeBackendApply(t, meta, r, state, config("key-2", "1"))
+	assert.NotEqual(t, alertChannelSecretSalt(state.Attributes["secret_hash"]), alertChannelSecretSalt(rotated.Attributes["secret_hash"]))
+	assert.Nil(t, plan(rotated, config("key-2", "1")))
+
+	// An imported channel's secrets are sent once.
+	imported, diags := r.RefreshWithoutUpgrade(context.Background(), &terraform.InstanceState{ID: state.ID}, meta)
+	require.False(t, diags.HasError(), "%+v", diags)
+	assert.Equal(t, alertChannelSecretHash(alertChannelSecretSalt(imported.Attributes["secret_hash"]), nil), imported.Attributes["secret_hash"])
+
+	diff = plan(imported, config("key-2", ""))
+	require.NotNil(t, diff)
+	assert.False(t, diff.RequiresNew())
+	assert.NotNil(t, diff.Attributes["secret_hash"])
+
+	imported = testFakeBackendApply(t, meta, r, imported, config("key-2", ""))
+	assert.NotEmpty(t, imported.Attributes["secret_hash"])
+	assert.Nil(t, plan(imported, config("key-2", "")))
+}
+
+func TestNewRelicAlertChannel_UserChannelForcesReplacement(t *testing.T) {
+	r := resourceNewRelicAlertChannel()
+
+	state := &terraform.InstanceState{
+		ID: "123",
+		Attributes: map[string]string{
+			"id":               "123",
+			"name":             "tf-test",
+			"type":             "user",
+			"config.#":         "1",
+			"config.0.user_id": "1",
+		},
+	}
+
+	cases := map[string]map[string]interface{}{
+		"name": {
+			"name":   "tf-test-updated",
+			"type":   "user",
+			"config": []interface{}{map[string]interface{}{"user_id": "1"}},
+		},
+		"config": {
+			"name":   "tf-test",
+			"type":   "user",
+			"config": []interface{}{map[string]interface{}{"user_id": "2"}},
+		},
+	}
+
+	for name, config := range cases {
+		t.Run(name, func(t *testing.T) {
+			diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), nil)
+			require.NoError(t, err)
+			assert.True(t, diff.RequiresNew())
+		})
+	}
+}
+
+func TestNewRelicAlertChannel_NoNerdGraphCredentialsForcesReplacement(t *testing.T) {
+	r := resourceNewRelicAlertChannel()
+
+	state := &terraform.InstanceState{
+		ID: "123",
+		Attributes: map[string]string{
+			"id":                  "123",
+			"name":                "tf-test",
+			"type":                "email",
+			"config.#":            "1",
+			"config.0.recipients": "foo@example.com",
+		},
+	}
+
+	config := map[string]interface{}{
+		"name":   "tf-test-updated",
+		"type":   "email",
+		"config": []interface{}{map[string]interface{}{"recipients": "foo@example.com"}},
+	}
+
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), &ProviderConfig{AccountID: 1, PersonalAPIKey: "foo"})
+	require.NoError(t, err)
+	assert.False(t, diff.RequiresNew())
+
+	// Without a personal API key, the channel can only be replaced.
+	diff, err = r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), &ProviderConfig{AccountID: 1})
+	require.NoError(t, err)
+	assert.True(t, diff.RequiresNew())
+}
+
+func TestNewRelicAlertChannel_SeedSecretHash(t *testing.T) {
+	f := newFakeBackend(t)
+	meta := testFakeBackendMeta(t, f)
+	r := resourceNewRelicAlertChannel()
+
+	// A channel created before secret_hash kept its secrets in state.
+	pagerduty := f.addChannel("tf-test")
+	f.channels[pagerduty]["type"] = "pagerduty"
+	f.channels[pagerduty]["configuration"] = map[string]interface{}{}
+
+	state := &terraform.InstanceState{
+		ID: strconv.Itoa(pagerduty),
+		Attributes: map[string]string{
+			"id":                   strconv.Itoa(pagerduty),
+			"name":                 "tf-test",
+			"type":                 "pagerduty",
+			"config.#":             "1",
+			"config.0.service_key": "key-1",
+		},
+	}
+
+	state, diags := r.RefreshWithoutUpgrade(context.Background(), state, meta)
+	require.False(t, diags.HasError(), "%+v", diags)
+	assert.NotEmpty(t, state.Attributes["secret_hash"])
+	assert.Empty(t, state.Attributes["config.0.service_key"])
+
+	assert.Nil(t, testFakeBackendPlan(t, meta, r, state, map[string]interface{}{
+		"name":   "tf-test",
+		"type":   "pagerduty",
+		"config": []interface{}{map[string]interface{}{"service_key": "key-1"}},
+	}))
+
+	// Imported channels without secrets, like user channels, have no diff.
+	user := f.addChannel("tf-test-user")
+	f.channels[user]["type"] = "user"
+	f.channels[user]["configuration"] = map[string]interface{}{"user_id": "1"}
+
+	imported, diags := r.RefreshWithoutUpgrade(context.Background(), &terraform.InstanceState{ID: strconv.Itoa(user)}, meta)
+	require.False(t, diags.HasError(), "%+v", diags)
+
+	assert.Nil(t, testFakeBackendPlan(t, meta, r, imported, map[string]interface{}{
+		"name":   "tf-test-user",
+		"type":   "user",
+		"config": []interface{}{map[string]interface{}{"user_id": "1"}},
+	}))
+
+	// Rotating the secrets of a channel which can't be updated replaces it.
+	diff := testFakeBackendPlan(t, meta, r, imported, map[string]interface{}{
+		"name":           "tf-test-user",
+		"type":           "user",
+		"rotate_secrets": "1",
+		"config":         []interface{}{map[string]interface{}{"user_id": "1"}},
+	})
+	require.NotNil(t, diff)
+	assert.True(t, diff.RequiresNew())
+}
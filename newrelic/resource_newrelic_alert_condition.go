@@ -82,6 +82,7 @@ func resourceNewRelicAlertCondition() *schema.Resource {
 		ReadContext:   resourceNewRelicAlertConditionRead,
 		UpdateContext: resourceNewRelicAlertConditionUpdate,
 		DeleteContext: resourceNewRelicAlertConditionDelete,
+		CustomizeDiff: validateAlertConditionDiff,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -121,7 +122,6 @@ func resourceNewRelicAlertCondition() *schema.Resource {
 				Type:        schema.TypeString,
 				Required:    true,
 				Description: "The metric field accepts parameters based on the type set.",
-				//TODO: ValidateFunc from map
 			},
 			"runbook_url": {
 				Type:        schema.TypeString,
@@ -201,6 +201,76 @@ func resourceNewRelicAlertCondition() *schema.Resource {
 	}
 }
 
+// validateAlertConditionDiff checks the attributes which depend on the type
+// and metric of the condition, which the API would otherwise only reject at
+// apply time. Values which are not yet known during plan are skipped.
+func validateAlertConditionDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("type") || !d.NewValueKnown("metric") {
+		return nil
+	}
+
+	var errs []string
+	conditionType := d.Get("type").(string)
+	metric := d.Get("metric").(string)
+
+	if metrics, ok := alertConditionTypes[conditionType]; ok && !stringInSlice(metrics, metric) {
+		errs = append(errs, fmt.Sprintf("attribute `metric` must be one of (%s) for alert conditions of type `%s`, got: %s", strings.Join(metrics, ", "), conditionType, metric))
+	}
+
+	for _, attr := range []string{"user_defined_metric", "user_defined_value_function"} {
+		if !d.NewValueKnown(attr) {
+			continue
+		}
+
+		_, ok := d.GetOk(attr)
+
+		switch {
+		case metric == "user_defined" && !ok:
+			errs = append(errs, fmt.Sprintf("attribute `%s` is required when `metric` is `user_defined`", attr))
+		case metric != "user_defined" && ok:
+			errs = append(errs, fmt.Sprintf("attribute `%s` can only be used when `metric` is `user_defined`, got `metric` %q", attr, metric))
+		}
+	}
+
+	if d.NewValueKnown("gc_metric") {
+		_, ok := d.GetOk("gc_metric")
+
+		switch {
+		case conditionType == "apm_jvm_metric" && metric == "gc_cpu_time" && !ok:
+			errs = append(errs, "attribute `gc_metric` is required when `metric` is `gc_cpu_time`")
+		case metric != "gc_cpu_time" && ok:
+			errs = append(errs, fmt.Sprintf("attribute `gc_metric` can only be used when `metric` is `gc_cpu_time`, got `metric` %q", metric))
+		}
+	}
+
+	if d.NewValueKnown("condition_scope") {
+		scope := d.Get("condition_scope").(string)
+
+		if scope != "" && conditionType != "apm_app_metric" && conditionType != "apm_jvm_metric" {
+			errs = append(errs, fmt.Sprintf("attribute `condition_scope` is only supported for alert conditions of type `apm_app_metric` or `apm_jvm_metric`, got type `%s`", conditionType))
+		}
+
+		// The API scopes conditions to the application when no scope is configured.
+		if _, ok := d.GetOk("violation_close_timer"); ok {
+			switch conditionType {
+			case "apm_app_metric":
+				if scope != "instance" {
+					errs = append(errs, "violation_close_timer only supported for apm_app_metric when condition_scope = 'instance'")
+				}
+			case "apm_jvm_metric":
+			default:
+				errs = append(errs, fmt.Sprintf("attribute `violation_close_timer` is only supported for alert conditions of type `apm_app_metric` or `apm_jvm_metric`, got type `%s`", conditionType))
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf(strings.Join(errs, ", "))
+	}
+
+	return nil
+}
+
 func resourceNewRelicAlertConditionCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*ProviderConfig).NewClient
 	condition, err := expandAlertCondition(d)
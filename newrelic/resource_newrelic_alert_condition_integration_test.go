@@ -8,13 +8,12 @@ import (
 	"regexp"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
 func TestAccNewRelicAlertCondition_Basic(t *testing.T) {
 	resourceName := "newrelic_alert_condition.foo"
-	rand := acctest.RandString(5)
+	rand := testAccRandString(t, 5)
 	rName := fmt.Sprintf("tf-test-%s", rand)
 	rNameUpdated := fmt.Sprintf("tf-test-updated-%s", rand)
 	resource.ParallelTest(t, resource.TestCase{
@@ -52,7 +51,7 @@ func TestAccNewRelicAlertCondition_Basic(t *testing.T) {
 }
 
 func TestAccNewRelicAlertCondition_ZeroThreshold(t *testing.T) {
-	rName := fmt.Sprintf("tf-test-%s", acctest.RandString(5))
+	rName := fmt.Sprintf("tf-test-%s", testAccRandString(t, 5))
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
@@ -69,7 +68,7 @@ func TestAccNewRelicAlertCondition_ZeroThreshold(t *testing.T) {
 }
 
 func TestAccNewRelicAlertCondition_FloatThreshold(t *testing.T) {
-	rName := fmt.Sprintf("tf-test-%s", acctest.RandString(5))
+	rName := fmt.Sprintf("tf-test-%s", testAccRandString(t, 5))
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
@@ -86,7 +85,7 @@ func TestAccNewRelicAlertCondition_FloatThreshold(t *testing.T) {
 }
 
 func TestAccNewRelicAlertCondition_AlertPolicyNotFound(t *testing.T) {
-	rName := fmt.Sprintf("tf-test-%s", acctest.RandString(5))
+	rName := fmt.Sprintf("tf-test-%s", testAccRandString(t, 5))
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -106,7 +105,7 @@ func TestAccNewRelicAlertCondition_AlertPolicyNotFound(t *testing.T) {
 }
 
 func TestAccNewRelicAlertCondition_ApplicationScopeWithCloseTimer(t *testing.T) {
-	rName := fmt.Sprintf("tf-test-%s", acctest.RandString(5))
+	rName := fmt.Sprintf("tf-test-%s", testAccRandString(t, 5))
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -126,7 +125,7 @@ func TestAccNewRelicAlertCondition_InstanceScopeWithCloseTimer(t *testing.T) {
 		t.Skipf("New Relic internal testing account required")
 	}
 
-	rName := fmt.Sprintf("tf-test-%s", acctest.RandString(5))
+	rName := fmt.Sprintf("tf-test-%s", testAccRandString(t, 5))
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -145,7 +144,7 @@ func TestAccNewRelicAlertCondition_APMJVMMetricApplicationScope(t *testing.T) {
 		t.Skipf("New Relic internal testing account required")
 	}
 
-	rName := fmt.Sprintf("tf-test-%s", acctest.RandString(5))
+	rName := fmt.Sprintf("tf-test-%s", testAccRandString(t, 5))
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -163,7 +162,7 @@ func TestAccNewRelicAlertCondition_APMJVMMetricInstanceScope(t *testing.T) {
 		t.Skipf("New Relic internal testing account required")
 	}
 
-	rName := fmt.Sprintf("tf-test-%s", acctest.RandString(5))
+	rName := fmt.Sprintf("tf-test-%s", testAccRandString(t, 5))
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
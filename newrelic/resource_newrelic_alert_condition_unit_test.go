@@ -4,17 +4,20 @@
 package newrelic
 
 import (
+	"context"
 	"fmt"
 	"regexp"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAccNewRelicAlertCondition_ShortTermDuration(t *testing.T) {
 	avoidEmptyAccountID()
-	rName := fmt.Sprintf("tf-test-%s", acctest.RandString(5))
+	rName := fmt.Sprintf("tf-test-%s", testAccRandString(t, 5))
 	expectedErrorMsg, _ := regexp.Compile(`expected term.0.duration to be in the range \(5 - 120\)`)
 	resource.ParallelTest(t, resource.TestCase{
 		IsUnitTest:   true,
@@ -31,7 +34,7 @@ func TestAccNewRelicAlertCondition_ShortTermDuration(t *testing.T) {
 
 func TestAccNewRelicAlertCondition_LongTermDuration(t *testing.T) {
 	avoidEmptyAccountID()
-	rName := fmt.Sprintf("tf-test-%s", acctest.RandString(5))
+	rName := fmt.Sprintf("tf-test-%s", testAccRandString(t, 5))
 	expectedErrorMsg, _ := regexp.Compile(`expected term.0.duration to be in the range \(5 - 120\)`)
 	resource.ParallelTest(t, resource.TestCase{
 		IsUnitTest:   true,
@@ -77,3 +80,135 @@ func TestAccNewRelicAlertCondition_EmptyName(t *testing.T) {
 		},
 	})
 }
+
+func testAlertConditionDiffConfig(overrides map[string]interface{}) map[string]interface{} {
+	return testResourceConfig(map[string]interface{}{
+		"policy_id":       1,
+		"name":            "tf-test",
+		"type":            "apm_app_metric",
+		"entities":        []interface{}{123},
+		"metric":          "apdex",
+		"condition_scope": "application",
+		"term": []interface{}{
+			map[string]interface{}{
+				"duration":      5,
+				"threshold":     0.75,
+				"time_function": "all",
+			},
+		},
+	}, overrides)
+}
+
+func TestValidateAlertConditionDiff(t *testing.T) {
+	cases := map[string]struct {
+		Overrides map[string]interface{}
+		ErrorMsg  string
+	}{
+		"valid apm_app_metric": {},
+		"valid without condition_scope": {
+			Overrides: map[string]interface{}{"condition_scope": nil},
+		},
+		"valid user_defined": {
+			Overrides: map[string]interface{}{
+				"metric":                      "user_defined",
+				"user_defined_metric":         "Custom/foo",
+				"user_defined_value_function": "average",
+			},
+		},
+		"metric of another type": {
+			Overrides: map[string]interface{}{"metric": "response_time"},
+			ErrorMsg:  "attribute `metric` must be one of (apdex, error_percentage, response_time_background, response_time_web, throughput_background, throughput_web, user_defined) for alert conditions of type `apm_app_metric`, got: response_time",
+		},
+		"valid apm_kt_metric": {
+			Overrides: map[string]interface{}{
+				"type":            "apm_kt_metric",
+				"metric":          "response_time",
+				"condition_scope": nil,
+			},
+		},
+		"user_defined without user_defined_metric": {
+			Overrides: map[string]interface{}{
+				"metric":                      "user_defined",
+				"user_defined_value_function": "average",
+			},
+			ErrorMsg: "attribute `user_defined_metric` is required when `metric` is `user_defined`",
+		},
+		"user_defined without user_defined_value_function": {
+			Overrides: map[string]interface{}{
+				"metric":              "user_defined",
+				"user_defined_metric": "Custom/foo",
+			},
+			ErrorMsg: "attribute `user_defined_value_function` is required when `metric` is `user_defined`",
+		},
+		"user_defined_metric without user_defined": {
+			Overrides: map[string]interface{}{"user_defined_metric": "Custom/foo"},
+			ErrorMsg:  "attribute `user_defined_metric` can only be used when `metric` is `user_defined`, got `metric` \"apdex\"",
+		},
+		"gc_cpu_time without gc_metric": {
+			Overrides: map[string]interface{}{
+				"type":   "apm_jvm_metric",
+				"metric": "gc_cpu_time",
+			},
+			ErrorMsg: "attribute `gc_metric` is required when `metric` is `gc_cpu_time`",
+		},
+		"valid gc_cpu_time": {
+			Overrides: map[string]interface{}{
+				"type":      "apm_jvm_metric",
+				"metric":    "gc_cpu_time",
+				"gc_metric": "GC/G1 Young Generation",
+			},
+		},
+		"gc_metric without gc_cpu_time": {
+			Overrides: map[string]interface{}{"gc_metric": "GC/G1 Young Generation"},
+			ErrorMsg:  "attribute `gc_metric` can only be used when `metric` is `gc_cpu_time`",
+		},
+		"condition_scope on browser_metric": {
+			Overrides: map[string]interface{}{
+				"type":   "browser_metric",
+				"metric": "end_user_apdex",
+			},
+			ErrorMsg: "attribute `condition_scope` is only supported for alert conditions of type `apm_app_metric` or `apm_jvm_metric`, got type `browser_metric`",
+		},
+		"violation_close_timer with application scope": {
+			Overrides: map[string]interface{}{"violation_close_timer": 24},
+			ErrorMsg:  "violation_close_timer only supported for apm_app_metric when condition_scope = 'instance'",
+		},
+		"violation_close_timer with instance scope": {
+			Overrides: map[string]interface{}{
+				"condition_scope":       "instance",
+				"violation_close_timer": 24,
+			},
+		},
+		"violation_close_timer on apm_jvm_metric": {
+			Overrides: map[string]interface{}{
+				"type":                  "apm_jvm_metric",
+				"metric":                "heap_memory_usage",
+				"violation_close_timer": 24,
+			},
+		},
+		"violation_close_timer on servers_metric": {
+			Overrides: map[string]interface{}{
+				"type":                  "servers_metric",
+				"metric":                "cpu_percentage",
+				"condition_scope":       nil,
+				"violation_close_timer": 24,
+			},
+			ErrorMsg: "attribute `violation_close_timer` is only supported for alert conditions of type `apm_app_metric` or `apm_jvm_metric`, got type `servers_metric`",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := resourceNewRelicAlertCondition()
+			_, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(testAlertConditionDiffConfig(tc.Overrides)), nil)
+
+			if tc.ErrorMsg == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.ErrorMsg)
+		})
+	}
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 	"regexp"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	"log"
@@ -28,20 +30,124 @@ func validateMutingRuleConditionAttribute(val interface{}, key string) (warns []
 		}
 		return
 	}
-	v := validation.StringInSlice([]string{"accountId", "conditionId", "policyId", "policyName", "conditionName", "conditionType", "conditionRunbookUrl", "product", "targetId", "targetName", "nrqlEventType", "tag", "nrqlQuery"}, false)
+	v := validation.StringInSlice([]string{"accountId", "conditionId", "entity.guid", "policyId", "policyName", "conditionName", "conditionType", "conditionRunbookUrl", "product", "targetId", "targetName", "nrqlEventType", "tag", "nrqlQuery"}, false)
 	return v(valueString, key)
 }
 func validateNaiveDateTime(val interface{}, key string) (warns []string, errs []error) {
 	valueString := val.(string)
 
 	// test conversion to desired format:
-	_, err := time.Parse("2006-01-02T15:04:05", valueString)
+	_, err := time.Parse(mutingRuleDateTimeFormat, valueString)
 	if err != nil {
 		errs = append(errs, fmt.Errorf("%#v of %#v must be in the format 2006-01-02T15:04:05", key, valueString))
 	}
 	return
 }
 
+// mutingRuleDateTimeFormat is the format of the naive datetimes of a
+// MutingRule schedule, interpreted in the schedule's time zone.
+const mutingRuleDateTimeFormat = "2006-01-02T15:04:05"
+
+// validateMutingRuleScheduleDiff validates the fields of a MutingRule
+// schedule against each other, the schema only validates them one by one.
+func validateMutingRuleScheduleDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if len(d.Get("schedule").([]interface{})) == 0 {
+		return nil
+	}
+
+	var errs []string
+
+	var location *time.Location
+	timeZoneKnown := d.NewValueKnown("schedule.0.time_zone")
+	if timeZoneKnown {
+		timeZone := d.Get("schedule.0.time_zone").(string)
+
+		loc, err := time.LoadLocation(timeZone)
+		if err != nil || timeZone == "" || timeZone == "Local" {
+			errs = append(errs, fmt.Sprintf("attribute `schedule.0.time_zone` must be a valid IANA time zone such as America/Los_Angeles, got: %q", timeZone))
+		} else {
+			location = loc
+		}
+	}
+
+	if location == nil {
+		location = time.UTC
+	}
+
+	startTime, hasStartTime := mutingRuleScheduleTime(d, "schedule.0.start_time", location)
+	endTime, hasEndTime := mutingRuleScheduleTime(d, "schedule.0.end_time", location)
+
+	if hasStartTime && hasEndTime && !endTime.After(startTime) {
+		errs = append(errs, "attribute `schedule.0.end_time` must be after `schedule.0.start_time`")
+	}
+
+	// An end time which passed since it was set doesn't prevent planning.
+	if hasEndTime && timeZoneKnown && d.HasChange("schedule.0.end_time") && endTime.Before(time.Now().In(location)) {
+		errs = append(errs, fmt.Sprintf("attribute `schedule.0.end_time` must be in the future in time zone %s, got: %s", location, d.Get("schedule.0.end_time").(string)))
+	}
+
+	if d.NewValueKnown("schedule.0.repeat") {
+		repeat := d.Get("schedule.0.repeat").(string)
+
+		if d.NewValueKnown("schedule.0.weekly_repeat_days") && repeat != "WEEKLY" && d.Get("schedule.0.weekly_repeat_days").(*schema.Set).Len() > 0 {
+			errs = append(errs, fmt.Sprintf("attribute `schedule.0.weekly_repeat_days` can only be used when `schedule.0.repeat` is `WEEKLY`, got `repeat` %q", repeat))
+		}
+
+		for _, attr := range []string{"end_repeat", "repeat_count"} {
+			key := "schedule.0." + attr
+			if _, ok := d.GetOk(key); ok && d.NewValueKnown(key) && repeat == "" {
+				errs = append(errs, fmt.Sprintf("attribute `%s` can only be used when `schedule.0.repeat` is set", key))
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf(strings.Join(errs, ", "))
+	}
+
+	return nil
+}
+
+// validateMutingRuleConditionDiff checks that the condition block combines
+// its conditions with AND when entity_guids or tags are set, since their
+// conditions are added to the same condition group.
+func validateMutingRuleConditionDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("condition.0.operator") {
+		return nil
+	}
+
+	operator := d.Get("condition.0.operator").(string)
+	if operator == "" || operator == "AND" {
+		return nil
+	}
+
+	for _, attr := range []string{"entity_guids", "tags"} {
+		if _, ok := d.GetOk(attr); ok {
+			return fmt.Errorf("attribute `condition.0.operator` must be `AND` when `%s` is set, got: %q", attr, operator)
+		}
+	}
+
+	return nil
+}
+
+// mutingRuleScheduleTime returns a datetime of a MutingRule schedule in the
+// schedule's time zone, if it is known and set. Invalid values are reported
+// by validateNaiveDateTime.
+func mutingRuleScheduleTime(d *schema.ResourceDiff, key string, location *time.Location) (time.Time, bool) {
+	if !d.NewValueKnown(key) {
+		return time.Time{}, false
+	}
+
+	v := d.Get(key).(string)
+	if v == "" {
+		return time.Time{}, false
+	}
+
+	t, err := time.ParseInLocation(mutingRuleDateTimeFormat, v, location)
+
+	return t, err == nil
+}
+
 func scheduleSchema() *schema.Resource {
 	return &schema.Resource{
 		Schema: map[string]*schema.Schema{
@@ -100,6 +206,10 @@ func resourceNewRelicAlertMutingRule() *schema.Resource {
 		ReadContext:   resourceNewRelicAlertMutingRuleRead,
 		UpdateContext: resourceNewRelicAlertMutingRuleUpdate,
 		DeleteContext: resourceNewRelicAlertMutingRuleDelete,
+		CustomizeDiff: customdiff.All(
+			validateMutingRuleScheduleDiff,
+			validateMutingRuleConditionDiff,
+		),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -111,11 +221,12 @@ func resourceNewRelicAlertMutingRule() *schema.Resource {
 				Description: "The account id of the MutingRule..",
 			},
 			"condition": {
-				Type:        schema.TypeList,
-				Required:    true,
-				Description: "The condition that defines which violations to target.",
-				MaxItems:    1,
-				MinItems:    1,
+				Type:         schema.TypeList,
+				Optional:     true,
+				AtLeastOneOf: []string{"condition", "entity_guids", "tags"},
+				Description:  "The condition that defines which violations to target.",
+				MaxItems:     1,
+				MinItems:     1,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"conditions": {
@@ -153,6 +264,21 @@ func resourceNewRelicAlertMutingRule() *schema.Resource {
 					},
 				},
 			},
+			"entity_guids": {
+				Type:         schema.TypeSet,
+				Optional:     true,
+				MinItems:     1,
+				AtLeastOneOf: []string{"condition", "entity_guids", "tags"},
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				Description:  "The GUIDs of the entities whose violations are muted.",
+			},
+			"tags": {
+				Type:         schema.TypeMap,
+				Optional:     true,
+				AtLeastOneOf: []string{"condition", "entity_guids", "tags"},
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				Description:  "The tags of the entities whose violations are muted, a map of tag keys to tag values.",
+			},
 			"enabled": {
 				Type:        schema.TypeBool,
 				Required:    true,
@@ -11,7 +11,6 @@ import (
 
 	"github.com/stretchr/testify/require"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
@@ -68,7 +67,7 @@ func TestValidateNaiveDateTime_RejectsUnixDateTime(t *testing.T) {
 
 func TestAccNewRelicAlertMutingRule_Basic(t *testing.T) {
 	resourceName := "newrelic_alert_muting_rule.foo"
-	rName := acctest.RandString(5)
+	rName := testAccRandString(t, 5)
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -100,7 +99,7 @@ func TestAccNewRelicAlertMutingRule_Basic(t *testing.T) {
 
 func TestAccNewRelicAlertMutingRule_WithSchedule(t *testing.T) {
 	resourceName := "newrelic_alert_muting_rule.foo"
-	rName := acctest.RandString(5)
+	rName := testAccRandString(t, 5)
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -116,8 +115,8 @@ func TestAccNewRelicAlertMutingRule_WithSchedule(t *testing.T) {
 					"EQUALS",
 					"APM",
 					`
-						start_time         = "2021-01-21T15:30:00"
-						end_time           = "2021-01-21T16:30:00"
+						start_time         = "2050-01-21T15:30:00"
+						end_time           = "2050-01-21T16:30:00"
 						time_zone          = "America/Los_Angeles"
 						repeat             = "WEEKLY"
 						end_repeat         = "2022-06-11T12:00:00"
@@ -149,8 +148,8 @@ func TestAccNewRelicAlertMutingRule_WithSchedule(t *testing.T) {
 					"NOT_EQUALS",
 					"baseline",
 					`
-						start_time         = "2021-02-21T15:30:00"
-						end_time           = "2021-02-21T16:30:00"
+						start_time         = "2050-02-21T15:30:00"
+						end_time           = "2050-02-21T16:30:00"
 						end_repeat         = "2022-06-11T12:00:00"
 						repeat             = "WEEKLY"
 						time_zone          = "America/Los_Angeles"
@@ -169,8 +168,8 @@ func TestAccNewRelicAlertMutingRule_WithSchedule(t *testing.T) {
 					"NOT_EQUALS",
 					"baseline",
 					`
-						start_time         = "2021-02-21T15:30:00"
-						end_time           = "2021-02-21T16:30:00"
+						start_time         = "2050-02-21T15:30:00"
+						end_time           = "2050-02-21T16:30:00"
 						repeat_count       = 42
 						repeat             = "DAILY"
 						time_zone          = "Asia/Bangkok"
@@ -191,6 +190,72 @@ func TestAccNewRelicAlertMutingRule_WithSchedule(t *testing.T) {
 	})
 }
 
+func TestAccNewRelicAlertMutingRule_EntityGUIDsAndTags(t *testing.T) {
+	resourceName := "newrelic_alert_muting_rule.foo"
+	rName := testAccRandString(t, 5)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNewRelicAlertMutingRuleDestroy,
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: testAccNewRelicAlertMutingRuleEntityGUIDsAndTags(rName, ""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicAlertMutingRuleExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "entity_guids.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "tags.env", "staging"),
+					resource.TestCheckResourceAttr(resourceName, "condition.#", "0"),
+				),
+			},
+			// Test: Update with a condition block
+			{
+				Config: testAccNewRelicAlertMutingRuleEntityGUIDsAndTags(rName, `
+	condition {
+		conditions {
+			attribute 	= "product"
+			operator 	= "EQUALS"
+			values 		= ["APM"]
+		}
+		operator = "AND"
+	}`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicAlertMutingRuleExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "entity_guids.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "condition.0.conditions.#", "1"),
+				),
+			},
+			// Test: Import
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccNewRelicAlertMutingRuleEntityGUIDsAndTags(name string, condition string) string {
+	return fmt.Sprintf(`
+resource "newrelic_workload" "foo" {
+	name       = "tf-test-%[1]s"
+	account_id = %[2]d
+}
+
+resource "newrelic_alert_muting_rule" "foo" {
+	name         = "tf-test-%[1]s"
+	enabled      = true
+	entity_guids = [newrelic_workload.foo.guid]
+
+	tags = {
+		env = "staging"
+	}
+%[3]s
+}
+`, name, testAccountID, condition)
+}
+
 func testAccNewRelicAlertMutingRuleBasic(
 	name string,
 	description string,
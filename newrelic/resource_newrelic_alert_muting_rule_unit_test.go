@@ -0,0 +1,288 @@
+//go:build unit
+// +build unit
+
+package newrelic
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testMutingRuleConfig(overrides map[string]interface{}) map[string]interface{} {
+	return testResourceConfig(map[string]interface{}{
+		"name":    "tf-test",
+		"enabled": true,
+		"condition": []interface{}{
+			map[string]interface{}{
+				"operator": "AND",
+				"conditions": []interface{}{
+					map[string]interface{}{
+						"attribute": "product",
+						"operator":  "EQUALS",
+						"values":    []interface{}{"APM"},
+					},
+				},
+			},
+		},
+	}, overrides)
+}
+
+// testMutingRuleSchedulePlan validates and diffs a muting rule with a
+// schedule, as planning it does, and returns its warnings and errors.
+func testMutingRuleSchedulePlan(schedule map[string]interface{}) ([]string, []string) {
+	config := terraform.NewResourceConfigRaw(testMutingRuleConfig(map[string]interface{}{
+		"schedule": []interface{}{schedule},
+	}))
+
+	r := resourceNewRelicAlertMutingRule()
+
+	var warns, errs []string
+	for _, d := range r.Validate(config) {
+		if d.Severity == diag.Error {
+			errs = append(errs, d.Summary)
+		} else {
+			warns = append(warns, d.Summary)
+		}
+	}
+
+	if len(errs) == 0 {
+		if _, err := r.Diff(context.Background(), nil, config, nil); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+
+	return warns, errs
+}
+
+func TestValidateMutingRuleScheduleDiff(t *testing.T) {
+	cases := map[string]struct {
+		Schedule map[string]interface{}
+		ErrorMsg string
+	}{
+		"valid": {
+			Schedule: map[string]interface{}{
+				"start_time":         "2050-01-01T09:00:00",
+				"end_time":           "2050-01-01T17:00:00",
+				"time_zone":          "America/Los_Angeles",
+				"repeat":             "WEEKLY",
+				"weekly_repeat_days": []interface{}{"MONDAY"},
+				"repeat_count":       4,
+			},
+		},
+		"valid without times": {
+			Schedule: map[string]interface{}{
+				"time_zone": "UTC",
+			},
+		},
+		"end_time before start_time": {
+			Schedule: map[string]interface{}{
+				"start_time": "2050-01-01T17:00:00",
+				"end_time":   "2050-01-01T09:00:00",
+				"time_zone":  "Europe/Paris",
+			},
+			ErrorMsg: "attribute `schedule.0.end_time` must be after `schedule.0.start_time`",
+		},
+		"end_time equal to start_time": {
+			Schedule: map[string]interface{}{
+				"start_time": "2050-01-01T09:00:00",
+				"end_time":   "2050-01-01T09:00:00",
+				"time_zone":  "Europe/Paris",
+			},
+			ErrorMsg: "attribute `schedule.0.end_time` must be after `schedule.0.start_time`",
+		},
+		"weekly_repeat_days without weekly repeat": {
+			Schedule: map[string]interface{}{
+				"time_zone":          "UTC",
+				"repeat":             "DAILY",
+				"weekly_repeat_days": []interface{}{"MONDAY"},
+			},
+			ErrorMsg: "attribute `schedule.0.weekly_repeat_days` can only be used when `schedule.0.repeat` is `WEEKLY`, got `repeat` \"DAILY\"",
+		},
+		"weekly_repeat_days without repeat": {
+			Schedule: map[string]interface{}{
+				"time_zone":          "UTC",
+				"weekly_repeat_days": []interface{}{"MONDAY"},
+			},
+			ErrorMsg: "attribute `schedule.0.weekly_repeat_days` can only be used when `schedule.0.repeat` is `WEEKLY`, got `repeat` \"\"",
+		},
+		"repeat_count without repeat": {
+			Schedule: map[string]interface{}{
+				"time_zone":    "UTC",
+				"repeat_count": 2,
+			},
+			ErrorMsg: "attribute `schedule.0.repeat_count` can only be used when `schedule.0.repeat` is set",
+		},
+		"end_repeat without repeat": {
+			Schedule: map[string]interface{}{
+				"time_zone":  "UTC",
+				"end_repeat": "2050-06-01T00:00:00",
+			},
+			ErrorMsg: "attribute `schedule.0.end_repeat` can only be used when `schedule.0.repeat` is set",
+		},
+		"end_repeat and repeat_count": {
+			Schedule: map[string]interface{}{
+				"time_zone":    "UTC",
+				"repeat":       "DAILY",
+				"end_repeat":   "2050-06-01T00:00:00",
+				"repeat_count": 2,
+			},
+			ErrorMsg: "Conflicting configuration arguments",
+		},
+		"invalid time_zone": {
+			Schedule: map[string]interface{}{
+				"time_zone": "America/Nowhere",
+			},
+			ErrorMsg: "attribute `schedule.0.time_zone` must be a valid IANA time zone such as America/Los_Angeles, got: \"America/Nowhere\"",
+		},
+		"local time_zone": {
+			Schedule: map[string]interface{}{
+				"time_zone": "Local",
+			},
+			ErrorMsg: "attribute `schedule.0.time_zone` must be a valid IANA time zone such as America/Los_Angeles, got: \"Local\"",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, errs := testMutingRuleSchedulePlan(tc.Schedule)
+
+			if tc.ErrorMsg == "" {
+				assert.Empty(t, errs)
+				return
+			}
+
+			require.NotEmpty(t, errs)
+			assert.Contains(t, errs[0], tc.ErrorMsg)
+		})
+	}
+}
+
+func TestValidateMutingRuleScheduleDiff_EndTimeInThePast(t *testing.T) {
+	_, errs := testMutingRuleSchedulePlan(map[string]interface{}{
+		"start_time": "2020-01-01T09:00:00",
+		"end_time":   "2020-01-01T17:00:00",
+		"time_zone":  "UTC",
+	})
+	require.Len(t, errs, 1)
+	assert.Contains(t, errs[0], "attribute `schedule.0.end_time` must be in the future in time zone UTC, got: 2020-01-01T17:00:00")
+
+	// The end time is compared with the current time in the schedule's time
+	// zone. An hour ago in UTC+14 is still ahead in UTC.
+	kiritimati, err := time.LoadLocation("Pacific/Kiritimati")
+	require.NoError(t, err)
+	endTime := time.Now().In(kiritimati).Add(-time.Hour).Format(mutingRuleDateTimeFormat)
+
+	_, errs = testMutingRuleSchedulePlan(map[string]interface{}{
+		"end_time":  endTime,
+		"time_zone": "Pacific/Kiritimati",
+	})
+	require.Len(t, errs, 1)
+	assert.Contains(t, errs[0], "must be in the future in time zone Pacific/Kiritimati")
+
+	_, errs = testMutingRuleSchedulePlan(map[string]interface{}{
+		"end_time":  endTime,
+		"time_zone": "UTC",
+	})
+	assert.Empty(t, errs)
+}
+
+func TestValidateMutingRuleScheduleDiff_EndTimePassedSinceApply(t *testing.T) {
+	r := resourceNewRelicAlertMutingRule()
+	schedule := map[string]interface{}{
+		"end_time":  "2020-01-01T17:00:00",
+		"time_zone": "UTC",
+	}
+
+	state := &terraform.InstanceState{
+		ID: "1:1",
+		Attributes: map[string]string{
+			"schedule.#":           "1",
+			"schedule.0.end_time":  "2020-01-01T17:00:00",
+			"schedule.0.time_zone": "UTC",
+		},
+	}
+
+	_, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(testMutingRuleConfig(map[string]interface{}{
+		"schedule": []interface{}{schedule},
+	})), nil)
+	assert.NoError(t, err)
+}
+
+func TestResourceNewRelicAlertMutingRule_EntityGUIDsAndTags_FakeBackend(t *testing.T) {
+	f := newFakeBackend(t)
+	meta := testFakeBackendMeta(t, f)
+	r := resourceNewRelicAlertMutingRule()
+
+	config := testMutingRuleConfig(map[string]interface{}{
+		"condition":    nil,
+		"entity_guids": []interface{}{"guid-2", "guid-1"},
+		"tags":         map[string]interface{}{"team": "core", "env": "staging"},
+	})
+
+	state := testFakeBackendApply(t, meta, r, nil, config)
+	require.Len(t, f.mutingRules, 1)
+
+	for _, rule := range f.mutingRules {
+		condition := rule["condition"].(map[string]interface{})
+		assert.Equal(t, "AND", condition["operator"])
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"attribute": "entity.guid", "operator": "IN", "values": []interface{}{"guid-1", "guid-2"}},
+			map[string]interface{}{"attribute": "tag.env", "operator": "EQUALS", "values": []interface{}{"staging"}},
+			map[string]interface{}{"attribute": "tag.team", "operator": "EQUALS", "values": []interface{}{"core"}},
+		}, condition["conditions"])
+	}
+
+	assert.Equal(t, "2", state.Attributes["entity_guids.#"])
+	assert.Equal(t, "staging", state.Attributes["tags.env"])
+	assert.Equal(t, "core", state.Attributes["tags.team"])
+	assert.Equal(t, "0", state.Attributes["condition.#"])
+
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), meta)
+	require.NoError(t, err)
+	assert.Nil(t, diff)
+
+	// The conditions of an imported muting rule are collapsed as well.
+	imported, diags := r.RefreshWithoutUpgrade(context.Background(), &terraform.InstanceState{ID: state.ID}, meta)
+	require.False(t, diags.HasError(), "%+v", diags)
+	assert.Equal(t, state.Attributes, imported.Attributes)
+
+	// The configured conditions stay in the condition block.
+	config = testMutingRuleConfig(map[string]interface{}{
+		"tags": map[string]interface{}{"env": "staging"},
+	})
+
+	state = testFakeBackendApply(t, meta, r, state, config)
+	assert.Equal(t, "0", state.Attributes["entity_guids.#"])
+	assert.Equal(t, "1", state.Attributes["tags.%"])
+	assert.Equal(t, "1", state.Attributes["condition.0.conditions.#"])
+	assert.Equal(t, "product", state.Attributes["condition.0.conditions.0.attribute"])
+
+	diff, err = r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), meta)
+	require.NoError(t, err)
+	assert.Nil(t, diff)
+
+	testFakeBackendApply(t, meta, r, state, nil)
+	assert.Empty(t, f.mutingRules)
+}
+
+func TestValidateMutingRuleConditionDiff(t *testing.T) {
+	r := resourceNewRelicAlertMutingRule()
+
+	config := testMutingRuleConfig(map[string]interface{}{
+		"tags": map[string]interface{}{"env": "staging"},
+	})
+	config["condition"].([]interface{})[0].(map[string]interface{})["operator"] = "OR"
+
+	_, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "attribute `condition.0.operator` must be `AND` when `tags` is set, got: \"OR\"")
+
+	diags := r.Validate(terraform.NewResourceConfigRaw(testMutingRuleConfig(map[string]interface{}{"condition": nil})))
+	assert.True(t, diags.HasError())
+}
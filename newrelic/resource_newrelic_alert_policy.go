@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log"
 	"strconv"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -50,8 +51,7 @@ func resourceNewRelicAlertPolicy() *schema.Resource {
 					Type: schema.TypeInt,
 				},
 				Optional:    true,
-				ForceNew:    true,
-				Description: "An array of channel IDs (integers) to assign to the policy. Adding or removing channel IDs from this array will result in a new alert policy resource being created and the old one being destroyed. Also note that channel IDs cannot be imported via terraform import.",
+				Description: "An array of channel IDs (integers) to assign to the policy. Channels can be added to and removed from the policy without recreating it. Channels added to the policy by other means are ignored.",
 			},
 		},
 	}
@@ -157,7 +157,23 @@ func resourceNewRelicAlertPolicyRead(ctx context.Context, d *schema.ResourceData
 		return diag.FromErr(queryErr)
 	}
 
-	return diag.FromErr(flattenAlertPolicy(queryPolicy, d, accountID))
+	if err := flattenAlertPolicy(queryPolicy, d, accountID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Only the channels managed by channel_ids are read, the other ones may be
+	// added by newrelic_alert_policy_channel resources
+	managed := d.Get("channel_ids").([]interface{})
+	if len(managed) == 0 {
+		return nil
+	}
+
+	channelIDs, err := findPolicyChannelIDs(ctx, client, accountID, policyID, expandAlertChannelIDs(managed))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.FromErr(d.Set("channel_ids", flattenAlertPolicyChannelIDs(managed, channelIDs)))
 }
 
 func resourceNewRelicAlertPolicyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -190,6 +206,34 @@ func resourceNewRelicAlertPolicyUpdate(ctx context.Context, d *schema.ResourceDa
 		return diag.FromErr(updateErr)
 	}
 
+	if d.HasChange("channel_ids") {
+		policyID, err := strconv.Atoi(d.Id())
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		o, n := d.GetChange("channel_ids")
+		added, removed := diffAlertPolicyChannelIDs(expandAlertChannelIDs(o.([]interface{})), expandAlertChannelIDs(n.([]interface{})))
+
+		if len(added) > 0 {
+			log.Printf("[INFO] Adding channels %+v to policy %d", added, policyID)
+
+			if _, err := client.Alerts.UpdatePolicyChannelsWithContext(ctx, policyID, added); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+
+		for _, channelID := range removed {
+			log.Printf("[INFO] Removing channel %d from policy %d", channelID, policyID)
+
+			if _, err := client.Alerts.DeletePolicyChannelWithContext(ctx, policyID, channelID); err != nil {
+				if _, ok := err.(*nrErrors.NotFound); !ok {
+					return diag.FromErr(err)
+				}
+			}
+		}
+	}
+
 	return diag.FromErr(flattenAlertPolicy(updateResult, d, accountID))
 }
 
@@ -234,3 +278,75 @@ func findExistingChannelIDs(ctx context.Context, client *newrelic.NewRelic, chan
 
 	return matched, nil
 }
+
+// findPolicyChannelIDs returns the IDs of the channels among channelIDs which
+// are added to a policy. Only the given channels are looked up, channels
+// which no longer exist are not added to the policy.
+func findPolicyChannelIDs(ctx context.Context, client *newrelic.NewRelic, accountID int, policyID int, channelIDs []int) ([]int, error) {
+	added := []int{}
+
+	for _, channelID := range channelIDs {
+		vars := map[string]interface{}{
+			"accountId": accountID,
+			"id":        channelID,
+		}
+
+		var resp policyChannelQueryResponse
+		if err := client.NerdGraph.QueryWithResponseAndContext(ctx, policyChannelQuery, vars, &resp); err != nil {
+			if strings.Contains(err.Error(), "Not Found") {
+				continue
+			}
+
+			return nil, err
+		}
+
+		channel := resp.Actor.Account.Alerts.NotificationChannel
+		if channel == nil {
+			continue
+		}
+
+		for _, policy := range channel.AssociatedPolicies.Policies {
+			if policy.ID == strconv.Itoa(policyID) {
+				added = append(added, channelID)
+				break
+			}
+		}
+	}
+
+	return added, nil
+}
+
+// The REST API only lists all of the channels of an account with the policies
+// they are added to, so the policies of a single channel are read with this
+// NerdGraph document.
+const policyChannelQuery = `query($accountId: Int!, $id: ID!) {
+	actor {
+		account(id: $accountId) {
+			alerts {
+				notificationChannel(id: $id) {
+					associatedPolicies {
+						policies {
+							id
+						}
+					}
+				}
+			}
+		}
+	}
+}`
+
+type policyChannelQueryResponse struct {
+	Actor struct {
+		Account struct {
+			Alerts struct {
+				NotificationChannel *struct {
+					AssociatedPolicies struct {
+						Policies []struct {
+							ID string `json:"id"`
+						} `json:"policies"`
+					} `json:"associatedPolicies"`
+				} `json:"notificationChannel"`
+			} `json:"alerts"`
+		} `json:"account"`
+	} `json:"actor"`
+}
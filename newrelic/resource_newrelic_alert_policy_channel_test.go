@@ -8,14 +8,13 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccNewRelicAlertPolicyChannel_Basic(t *testing.T) {
 	resourceName := "newrelic_alert_policy_channel.foo"
-	rName := acctest.RandString(5)
+	rName := testAccRandString(t, 5)
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -48,7 +47,7 @@ func TestAccNewRelicAlertPolicyChannel_Basic(t *testing.T) {
 
 func TestAccNewRelicAlertPolicyChannel_ChannelOrder(t *testing.T) {
 	resourceName := "newrelic_alert_policy_channel.foo"
-	rName := acctest.RandString(5)
+	rName := testAccRandString(t, 5)
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -68,7 +67,7 @@ func TestAccNewRelicAlertPolicyChannel_ChannelOrder(t *testing.T) {
 
 func TestAccNewRelicAlertPolicyChannel_MutipleChannels(t *testing.T) {
 	resourceName := "newrelic_alert_policy_channel.foo"
-	rName := acctest.RandString(5)
+	rName := testAccRandString(t, 5)
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -100,7 +99,7 @@ func TestAccNewRelicAlertPolicyChannel_MutipleChannels(t *testing.T) {
 }
 
 func TestAccNewRelicAlertPolicyChannel_AlertPolicyNotFound(t *testing.T) {
-	rName := fmt.Sprintf("tf-test-%s", acctest.RandString(5))
+	rName := fmt.Sprintf("tf-test-%s", testAccRandString(t, 5))
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -122,7 +121,7 @@ func TestAccNewRelicAlertPolicyChannel_AlertPolicyNotFound(t *testing.T) {
 }
 
 func TestAccNewRelicAlertPolicyChannel_AlertChannelNotFound(t *testing.T) {
-	rName := fmt.Sprintf("tf-test-%s", acctest.RandString(5))
+	rName := fmt.Sprintf("tf-test-%s", testAccRandString(t, 5))
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
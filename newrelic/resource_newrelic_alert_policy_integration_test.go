@@ -8,13 +8,13 @@ import (
 	"strconv"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccNewRelicAlertPolicy_Basic(t *testing.T) {
 	resourceName := "newrelic_alert_policy.foo"
-	rName := acctest.RandString(5)
+	rName := testAccRandString(t, 5)
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -47,7 +47,7 @@ func TestAccNewRelicAlertPolicy_Basic(t *testing.T) {
 }
 
 func TestAccNewRelicAlertPolicy_NoDiffOnReapply(t *testing.T) {
-	rName := fmt.Sprintf("tf-test-%s", acctest.RandString(5))
+	rName := fmt.Sprintf("tf-test-%s", testAccRandString(t, 5))
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -66,7 +66,7 @@ func TestAccNewRelicAlertPolicy_NoDiffOnReapply(t *testing.T) {
 }
 
 func TestAccNewRelicAlertPolicy_ResourceNotFound(t *testing.T) {
-	rName := fmt.Sprintf("tf-test-%s", acctest.RandString(5))
+	rName := fmt.Sprintf("tf-test-%s", testAccRandString(t, 5))
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -86,7 +86,8 @@ func TestAccNewRelicAlertPolicy_ResourceNotFound(t *testing.T) {
 
 func TestAccNewRelicAlertPolicy_WithChannels(t *testing.T) {
 	resourceName := "newrelic_alert_policy.foo"
-	rName := acctest.RandString(5)
+	rName := testAccRandString(t, 5)
+	var policyID string
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -97,8 +98,36 @@ func TestAccNewRelicAlertPolicy_WithChannels(t *testing.T) {
 				Config: testAccNewRelicAccAlertPolicyConfigWithChannels(rName),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckNewRelicAlertPolicyExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "channel_ids.#", "2"),
+					func(s *terraform.State) error {
+						policyID = s.RootModule().Resources[resourceName].Primary.ID
+						return nil
+					},
 				),
 			},
+			// Test: Update channels in place
+			{
+				Config: testAccNewRelicAccAlertPolicyConfigWithChannelsUpdated(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicAlertPolicyExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "channel_ids.#", "1"),
+					resource.TestCheckResourceAttrPair(resourceName, "channel_ids.0", "newrelic_alert_channel.channel_b", "id"),
+					func(s *terraform.State) error {
+						if id := s.RootModule().Resources[resourceName].Primary.ID; id != policyID {
+							return fmt.Errorf("policy was recreated, ID changed from %s to %s", policyID, id)
+						}
+						return nil
+					},
+				),
+			},
+			// Test: Import
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				// The channels of an imported policy are not read.
+				ImportStateVerifyIgnore: []string{"channel_ids"},
+			},
 		},
 	})
 }
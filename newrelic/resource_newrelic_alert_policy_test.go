@@ -132,3 +132,34 @@ resource "newrelic_alert_policy" "foo" {
 }
 `, name)
 }
+
+func testAccNewRelicAccAlertPolicyConfigWithChannelsUpdated(name string) string {
+	return fmt.Sprintf(`
+resource "newrelic_alert_channel" "channel_a" {
+	name = "tf-test-%[1]s-channel-a"
+	type = "email"
+
+	config {
+		recipients = "no-reply+a@newrelic.com"
+		include_json_attachment = "true"
+	}
+}
+
+resource "newrelic_alert_channel" "channel_b" {
+	name = "tf-test-%[1]s-channel-b"
+	type = "email"
+
+	config {
+		recipients = "no-reply+b@newrelic.com"
+		include_json_attachment = "1"
+	}
+}
+
+resource "newrelic_alert_policy" "foo" {
+	name = "tf-test-%[1]s"
+	channel_ids =  [
+		newrelic_alert_channel.channel_b.id
+	]
+}
+`, name)
+}
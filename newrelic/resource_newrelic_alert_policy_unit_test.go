@@ -4,10 +4,16 @@
 package newrelic
 
 import (
+	"context"
+	"fmt"
 	"regexp"
+	"strconv"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAccNewRelicAlertPolicy_ErrorThrownWhenNameEmpty(t *testing.T) {
@@ -26,3 +32,140 @@ func TestAccNewRelicAlertPolicy_ErrorThrownWhenNameEmpty(t *testing.T) {
 		},
 	})
 }
+
+func TestNewRelicAlertPolicy_FakeBackend(t *testing.T) {
+	f := newFakeBackend(t)
+	resourceName := "newrelic_alert_policy.foo"
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: f.providerFactories(),
+		CheckDestroy: func(s *terraform.State) error {
+			if len(f.policies) > 0 {
+				return fmt.Errorf("alert policies still exist: %v", f.policies)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: f.providerBlock() + testFakeNewRelicAlertPolicyConfig("tf-test", "PER_POLICY"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "tf-test"),
+					resource.TestCheckResourceAttr(resourceName, "incident_preference", "PER_POLICY"),
+					resource.TestCheckResourceAttr(resourceName, "account_id", "1"),
+				),
+			},
+			// Test: Update
+			{
+				Config: f.providerBlock() + testFakeNewRelicAlertPolicyConfig("tf-test-updated", "PER_CONDITION"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "tf-test-updated"),
+					resource.TestCheckResourceAttr(resourceName, "incident_preference", "PER_CONDITION"),
+				),
+			},
+			// Test: Import
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestNewRelicAlertPolicy_UpdateChannelIDs(t *testing.T) {
+	f := newFakeBackend(t)
+	meta := testFakeBackendMeta(t, f)
+	r := resourceNewRelicAlertPolicy()
+
+	slack := f.addChannel("slack")
+	email := f.addChannel("email")
+	webhook := f.addChannel("webhook")
+
+	state := testFakeBackendApply(t, meta, r, nil, map[string]interface{}{
+		"name":        "tf-test",
+		"channel_ids": []interface{}{slack},
+	})
+	policyID := state.ID
+
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":        "tf-test",
+		"channel_ids": []interface{}{email, webhook},
+	}), meta)
+	require.NoError(t, err)
+	assert.False(t, diff.RequiresNew(), "changing channel_ids must not replace the policy")
+
+	state = testFakeBackendApply(t, meta, r, state, map[string]interface{}{
+		"name":        "tf-test",
+		"channel_ids": []interface{}{email, webhook},
+	})
+	assert.Equal(t, policyID, state.ID)
+	assert.Empty(t, f.policyChannels[slack])
+	assert.Len(t, f.policyChannels[email], 1)
+	assert.Len(t, f.policyChannels[webhook], 1)
+
+	// Channels added outside of channel_ids, like by a
+	// newrelic_alert_policy_channel, are ignored.
+	id, err := strconv.Atoi(policyID)
+	require.NoError(t, err)
+
+	f.Lock()
+	f.policyChannels[slack][id] = true
+	f.Unlock()
+
+	state, diags := r.RefreshWithoutUpgrade(context.Background(), state, meta)
+	require.False(t, diags.HasError(), "%+v", diags)
+	assert.Equal(t, "2", state.Attributes["channel_ids.#"])
+	assert.Equal(t, strconv.Itoa(email), state.Attributes["channel_ids.0"])
+	assert.Equal(t, strconv.Itoa(webhook), state.Attributes["channel_ids.1"])
+
+	// A managed channel removed outside of Terraform is detected as drift.
+	f.Lock()
+	delete(f.policyChannels[webhook], id)
+	f.Unlock()
+
+	f.takeOperations(false)
+
+	state, diags = r.RefreshWithoutUpgrade(context.Background(), state, meta)
+	require.False(t, diags.HasError(), "%+v", diags)
+	assert.Equal(t, "1", state.Attributes["channel_ids.#"])
+	assert.Equal(t, strconv.Itoa(email), state.Attributes["channel_ids.0"])
+
+	// Only the managed channels are looked up.
+	assert.Equal(t, []string{"read:" + strconv.Itoa(email), "read:" + strconv.Itoa(webhook)}, f.takeOperations(false))
+
+	// The channels of an imported policy are not read, like any channel
+	// added to the policy by other means.
+	data, err := r.Importer.StateContext(context.Background(), r.Data(&terraform.InstanceState{ID: policyID}), meta)
+	require.NoError(t, err)
+	require.Len(t, data, 1)
+
+	imported, diags := r.RefreshWithoutUpgrade(context.Background(), data[0].State(), meta)
+	require.False(t, diags.HasError(), "%+v", diags)
+	assert.Empty(t, imported.Attributes["channel_ids.#"])
+	assert.Empty(t, f.takeOperations(false))
+
+	// Removing channel_ids removes the channels it manages only.
+	state = testFakeBackendApply(t, meta, r, state, map[string]interface{}{
+		"name": "tf-test",
+	})
+	assert.Equal(t, policyID, state.ID)
+	assert.Equal(t, "0", state.Attributes["channel_ids.#"])
+	assert.Empty(t, f.policyChannels[email])
+	assert.Len(t, f.policyChannels[slack], 1)
+
+	diff, err = r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name": "tf-test",
+	}), meta)
+	require.NoError(t, err)
+	assert.Nil(t, diff)
+}
+
+func testFakeNewRelicAlertPolicyConfig(name string, incidentPreference string) string {
+	return fmt.Sprintf(`
+resource "newrelic_alert_policy" "foo" {
+  name                = "%s"
+  incident_preference = "%s"
+}
+`, name, incidentPreference)
+}
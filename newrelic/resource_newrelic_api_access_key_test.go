@@ -7,13 +7,12 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
 func TestAccNewRelicAPIAccessKey_BasicIngestBrowser(t *testing.T) {
-	keyName := fmt.Sprintf("tftest-keyname-%s", acctest.RandString(10))
-	keyNotes := fmt.Sprintf("tftest-keynotes-%s", acctest.RandString(10))
+	keyName := fmt.Sprintf("tftest-keyname-%s", testAccRandString(t, 10))
+	keyNotes := fmt.Sprintf("tftest-keynotes-%s", testAccRandString(t, 10))
 	accountIDRaw, accountID := retrieveIdsFromEnvOrSkip(t, "NEW_RELIC_TEST_ACCOUNT_ID")
 
 	resource.Test(t, resource.TestCase{
@@ -38,8 +37,8 @@ func TestAccNewRelicAPIAccessKey_BasicIngestBrowser(t *testing.T) {
 }
 
 func TestAccNewRelicAPIAccessKey_BasicIngestLicense(t *testing.T) {
-	keyName := fmt.Sprintf("tftest-keyname-%s", acctest.RandString(10))
-	keyNotes := fmt.Sprintf("tftest-keynotes-%s", acctest.RandString(10))
+	keyName := fmt.Sprintf("tftest-keyname-%s", testAccRandString(t, 10))
+	keyNotes := fmt.Sprintf("tftest-keynotes-%s", testAccRandString(t, 10))
 	accountIDRaw, accountID := retrieveIdsFromEnvOrSkip(t, "NEW_RELIC_TEST_ACCOUNT_ID")
 
 	resource.Test(t, resource.TestCase{
@@ -64,8 +63,8 @@ func TestAccNewRelicAPIAccessKey_BasicIngestLicense(t *testing.T) {
 }
 
 func TestAccNewRelicAPIAccessKey_BasicUser(t *testing.T) {
-	keyName := fmt.Sprintf("tftest-keyname-%s", acctest.RandString(10))
-	keyNotes := fmt.Sprintf("tftest-keynotes-%s", acctest.RandString(10))
+	keyName := fmt.Sprintf("tftest-keyname-%s", testAccRandString(t, 10))
+	keyNotes := fmt.Sprintf("tftest-keynotes-%s", testAccRandString(t, 10))
 	accountIDRaw, accountID := retrieveIdsFromEnvOrSkip(t, "NEW_RELIC_TEST_ACCOUNT_ID")
 	userIDRaw, userID := retrieveIdsFromEnvOrSkip(t, "NEW_RELIC_TEST_USER_ID")
 
@@ -9,6 +9,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/newrelic/newrelic-client-go/pkg/apm"
+	"github.com/newrelic/newrelic-client-go/pkg/common"
 )
 
 func resourceNewRelicApplicationSettings() *schema.Resource {
@@ -17,6 +18,7 @@ func resourceNewRelicApplicationSettings() *schema.Resource {
 		ReadContext:   resourceNewRelicApplicationSettingsRead,
 		UpdateContext: resourceNewRelicApplicationSettingsUpdate,
 		DeleteContext: resourceNewRelicApplicationSettingsDelete,
+		CustomizeDiff: customizeDiffDefaultTags,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -37,6 +39,7 @@ func resourceNewRelicApplicationSettings() *schema.Resource {
 				Type:     schema.TypeBool,
 				Required: true,
 			},
+			"default_tags": defaultTagsSchema(),
 		},
 	}
 }
@@ -72,7 +75,8 @@ func resourceNewRelicApplicationSettingsCreate(ctx context.Context, d *schema.Re
 }
 
 func resourceNewRelicApplicationSettingsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*ProviderConfig).NewClient
+	providerConfig := meta.(*ProviderConfig)
+	client := providerConfig.NewClient
 
 	userApp := expandApplication(d)
 	log.Printf("[INFO] Reading New Relic application %+v", userApp)
@@ -84,11 +88,16 @@ func resourceNewRelicApplicationSettingsRead(ctx context.Context, d *schema.Reso
 
 	log.Printf("[INFO] Read found New Relic application %+v\n\n\n", app)
 
-	return diag.FromErr(flattenApplication(app, d))
+	if err := flattenApplication(app, d); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.FromErr(readDefaultTags(ctx, providerConfig, getApplicationGUID(providerConfig, d.Id()), d))
 }
 
 func resourceNewRelicApplicationSettingsUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*ProviderConfig).NewClient
+	providerConfig := meta.(*ProviderConfig)
+	client := providerConfig.NewClient
 
 	userApp := expandApplication(d)
 
@@ -104,12 +113,33 @@ func resourceNewRelicApplicationSettingsUpdate(ctx context.Context, d *schema.Re
 		return diag.FromErr(err)
 	}
 
+	if err := updateDefaultTags(ctx, providerConfig, getApplicationGUID(providerConfig, d.Id()), d); err != nil {
+		return diag.FromErr(err)
+	}
+
 	time.Sleep(2 * time.Second)
 
 	return diag.FromErr(flattenApplication(app, d))
 }
 
 func resourceNewRelicApplicationSettingsDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	// You can not delete application settings
-	return nil
+	// You can not delete application settings, but the default tags are removed from the application
+	providerConfig := meta.(*ProviderConfig)
+
+	tagKeys := []string{}
+	for k := range d.Get("default_tags").(map[string]interface{}) {
+		tagKeys = append(tagKeys, k)
+	}
+
+	if len(tagKeys) == 0 {
+		return nil
+	}
+
+	result, err := providerConfig.NewClient.Entities.TaggingDeleteTagFromEntityWithContext(ctx, getApplicationGUID(providerConfig, d.Id()), tagKeys)
+
+	return diag.FromErr(taggingMutationError(result, err))
+}
+
+func getApplicationGUID(providerConfig *ProviderConfig, applicationID string) common.EntityGUID {
+	return getEntityGUID(providerConfig.AccountID, "APM", "APPLICATION", applicationID)
 }
@@ -10,7 +10,6 @@ import (
 	"testing"
 	"time"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/newrelic/go-agent/v3/newrelic"
@@ -22,7 +21,7 @@ var (
 
 func TestAccNewRelicApplicationSettings_Basic(t *testing.T) {
 	resourceName := "newrelic_application_settings.app"
-	testExpectedApplicationName = fmt.Sprintf("tf_test_%s", acctest.RandString(10))
+	testExpectedApplicationName = fmt.Sprintf("tf_test_%s", testAccRandString(t, 10))
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:     func() { testPreCheck(t) },
@@ -108,6 +107,11 @@ func testAccCheckNewRelicApplicationExists(n string) resource.TestCheckFunc {
 }
 
 func testPreCheck(t *testing.T) {
+	if testAccReplaying(t) {
+		testAccUseCassette(t, cassetteReplay)
+		return
+	}
+
 	if v := os.Getenv("NEW_RELIC_API_KEY"); v == "" {
 		t.Skipf("NEW_RELIC_API_KEY must be set for acceptance tests")
 	}
@@ -116,6 +120,10 @@ func testPreCheck(t *testing.T) {
 		t.Skipf("NEW_RELIC_LICENSE_KEY must be set for acceptance tests")
 	}
 
+	if testAccRecording {
+		testAccUseCassette(t, cassetteRecord)
+	}
+
 	testCreateApplication(t)
 
 	time.Sleep(5 * time.Second)
@@ -0,0 +1,50 @@
+//go:build unit
+// +build unit
+
+package newrelic
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestNewRelicApplicationSettings_FakeBackend(t *testing.T) {
+	f := newFakeBackend(t)
+	f.addApplication("tf-test-app")
+	resourceName := "newrelic_application_settings.app"
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: f.providerFactories(),
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: f.providerBlock() + testFakeNewRelicApplicationSettingsConfig(0.8, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "app_apdex_threshold", "0.8"),
+					resource.TestCheckResourceAttr(resourceName, "enable_real_user_monitoring", "false"),
+				),
+			},
+			// Test: Update
+			{
+				Config: f.providerBlock() + testFakeNewRelicApplicationSettingsConfig(0.9, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "app_apdex_threshold", "0.9"),
+					resource.TestCheckResourceAttr(resourceName, "enable_real_user_monitoring", "true"),
+				),
+			},
+		},
+	})
+}
+
+func testFakeNewRelicApplicationSettingsConfig(apdexThreshold float64, realUserMonitoring bool) string {
+	return fmt.Sprintf(`
+resource "newrelic_application_settings" "app" {
+  name                        = "tf-test-app"
+  app_apdex_threshold         = %v
+  end_user_apdex_threshold    = 7
+  enable_real_user_monitoring = %t
+}
+`, apdexThreshold, realUserMonitoring)
+}
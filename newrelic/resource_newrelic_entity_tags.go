@@ -144,7 +144,7 @@ func resourceNewRelicEntityTagsRead(ctx context.Context, d *schema.ResourceData,
 		return diag.FromErr(err)
 	}
 
-	tags := convertTagTypes(t)
+	tags := withoutProviderDefaultTags(convertTagTypes(t), providerConfig, d)
 
 	return diag.FromErr(flattenEntityTags(d, tags))
 }
@@ -162,7 +162,22 @@ func resourceNewRelicEntityTagsUpdate(ctx context.Context, d *schema.ResourceDat
 
 	tags := expandEntityTags(d.Get("tag").(*schema.Set).List())
 
-	_, err := client.Entities.TaggingReplaceTagsOnEntityWithContext(ctx, common.EntityGUID(d.Id()), tags)
+	// Replacing the tags would otherwise remove the default tags applied by other resources.
+	replaceTags := tags
+	if len(providerConfig.DefaultTags) > 0 {
+		t, err := client.Entities.GetTagsForEntityWithContext(ctx, common.EntityGUID(d.Id()))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		for _, tag := range convertTagTypes(t) {
+			if _, ok := providerConfig.DefaultTags[tag.Key]; ok && getTag(convertTagInputs(tags), tag.Key) == nil {
+				replaceTags = append(replaceTags, *tag)
+			}
+		}
+	}
+
+	_, err := client.Entities.TaggingReplaceTagsOnEntityWithContext(ctx, common.EntityGUID(d.Id()), replaceTags)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -219,6 +234,36 @@ func convertTagTypes(tags []*entities.EntityTag) []*entities.TaggingTagInput {
 	return t
 }
 
+func convertTagInputs(tags []entities.TaggingTagInput) []*entities.TaggingTagInput {
+	t := make([]*entities.TaggingTagInput, len(tags))
+	for i := range tags {
+		t[i] = &tags[i]
+	}
+
+	return t
+}
+
+// withoutProviderDefaultTags removes the tags owned by the provider's
+// default_tags, unless they are also configured on the resource.
+func withoutProviderDefaultTags(tags []*entities.TaggingTagInput, providerConfig *ProviderConfig, d *schema.ResourceData) []*entities.TaggingTagInput {
+	if len(providerConfig.DefaultTags) == 0 {
+		return tags
+	}
+
+	configured := convertTagInputs(expandEntityTags(d.Get("tag").(*schema.Set).List()))
+
+	var t []*entities.TaggingTagInput
+	for _, tag := range tags {
+		if _, ok := providerConfig.DefaultTags[tag.Key]; ok && getTag(configured, tag.Key) == nil {
+			continue
+		}
+
+		t = append(t, tag)
+	}
+
+	return t
+}
+
 func resourceNewRelicEntityTagsDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	providerConfig := meta.(*ProviderConfig)
 
@@ -7,13 +7,12 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccNewRelicEventsToMetricsRule_Basic(t *testing.T) {
-	rand := acctest.RandString(5)
+	rand := testAccRandString(t, 5)
 	name := fmt.Sprintf("events_to_metrics_rule_%s", rand)
 	resourceName := "newrelic_events_to_metrics_rule.foo"
 
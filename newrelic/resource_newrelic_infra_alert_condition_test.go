@@ -8,14 +8,13 @@ import (
 	"regexp"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccNewRelicInfraAlertCondition_Basic(t *testing.T) {
 	resourceName := "newrelic_infra_alert_condition.foo"
-	rand := acctest.RandString(5)
+	rand := testAccRandString(t, 5)
 	rName := fmt.Sprintf("tf-test-%s", rand)
 	rNameUpdated := fmt.Sprintf("tf-test-updated-%s", rand)
 	resource.ParallelTest(t, resource.TestCase{
@@ -50,7 +49,7 @@ func TestAccNewRelicInfraAlertCondition_Basic(t *testing.T) {
 
 func TestAccNewRelicInfraAlertCondition_Where(t *testing.T) {
 	resourceName := "newrelic_infra_alert_condition.foo"
-	rand := acctest.RandString(5)
+	rand := testAccRandString(t, 5)
 	rName := fmt.Sprintf("tf-test-%s", rand)
 	whereClause := "(`hostname` LIKE '%cassandra%')"
 	resource.ParallelTest(t, resource.TestCase{
@@ -80,7 +79,7 @@ func TestAccNewRelicInfraAlertCondition_IntegrationProvider(t *testing.T) {
 		t.Skipf("New Relic internal testing account required")
 	}
 
-	rand := acctest.RandString(5)
+	rand := testAccRandString(t, 5)
 	rName := fmt.Sprintf("tf-test-%s", rand)
 	resourceName := "newrelic_infra_alert_condition.foo"
 	integrationProvider := "Elb"
@@ -108,7 +107,7 @@ func TestAccNewRelicInfraAlertCondition_IntegrationProvider(t *testing.T) {
 
 func TestAccNewRelicInfraAlertCondition_Thresholds(t *testing.T) {
 	resourceName := "newrelic_infra_alert_condition.foo"
-	rand := acctest.RandString(5)
+	rand := testAccRandString(t, 5)
 	rName := fmt.Sprintf("tf-test-%s", rand)
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -147,7 +146,7 @@ func TestAccNewRelicInfraAlertCondition_ThresholdFloatValue(t *testing.T) {
 	}
 
 	resourceName := "newrelic_infra_alert_condition.foo"
-	rand := acctest.RandString(5)
+	rand := testAccRandString(t, 5)
 	rName := fmt.Sprintf("tf-test-%s", rand)
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -177,7 +176,7 @@ func TestAccNewRelicInfraAlertCondition_ViolationCloseTimer(t *testing.T) {
 	}
 
 	resourceName := "newrelic_infra_alert_condition.foo"
-	rand := acctest.RandString(5)
+	rand := testAccRandString(t, 5)
 	rName := fmt.Sprintf("tf-test-%s", rand)
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -207,7 +206,7 @@ func TestAccNewRelicInfraAlertCondition_ViolationCloseTimerZeroValue(t *testing.
 	}
 
 	resourceName := "newrelic_infra_alert_condition.foo"
-	rand := acctest.RandString(5)
+	rand := testAccRandString(t, 5)
 	rName := fmt.Sprintf("tf-test-%s", rand)
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -233,7 +232,7 @@ func TestAccNewRelicInfraAlertCondition_ViolationCloseTimerZeroValue(t *testing.
 }
 
 func TestAccNewRelicInfraAlertCondition_MissingPolicy(t *testing.T) {
-	rand := acctest.RandString(5)
+	rand := testAccRandString(t, 5)
 	rName := fmt.Sprintf("tf-test-%s", rand)
 
 	resource.ParallelTest(t, resource.TestCase{
@@ -254,7 +253,7 @@ func TestAccNewRelicInfraAlertCondition_MissingPolicy(t *testing.T) {
 }
 
 func TestAccNewRelicInfraAlertCondition_InvalidAttrsForType(t *testing.T) {
-	rand := acctest.RandString(5)
+	rand := testAccRandString(t, 5)
 	rName := fmt.Sprintf("tf-test-%s", rand)
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -275,7 +274,7 @@ func TestAccNewRelicInfraAlertCondition_ComputedEvent(t *testing.T) {
 	}
 
 	resourceName := "newrelic_infra_alert_condition.foo"
-	rand := acctest.RandString(5)
+	rand := testAccRandString(t, 5)
 	rName := fmt.Sprintf("tf-test-%s", rand)
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
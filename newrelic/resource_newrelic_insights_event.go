@@ -164,7 +164,7 @@ func resourceNewRelicInsightsEventCreate(ctx context.Context, d *schema.Resource
 		}
 	}
 
-	if err := client.PostEvent(eventsPayload); err != nil {
+	if err := client.PostEventWithContext(ctx, eventsPayload); err != nil {
 		return diag.Errorf("error occurreed while posting events to Insights: %q", err)
 	}
 
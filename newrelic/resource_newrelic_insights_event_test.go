@@ -8,7 +8,6 @@ import (
 	"testing"
 	"time"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
@@ -19,7 +18,7 @@ func TestAccNewRelicInsightsEvent_Basic(t *testing.T) {
 	}
 
 	tNow := time.Now().Unix() * 1000
-	eType := acctest.RandString(5)
+	eType := testAccRandString(t, 5)
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
@@ -0,0 +1,196 @@
+package newrelic
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	nrErrors "github.com/newrelic/newrelic-client-go/pkg/errors"
+)
+
+var notificationChannelTypes = []string{
+	"EMAIL",
+	"EVENT_BRIDGE",
+	"JIRA_CLASSIC",
+	"JIRA_NEXTGEN",
+	"MOBILE_PUSH",
+	"PAGERDUTY_ACCOUNT_INTEGRATION",
+	"PAGERDUTY_SERVICE_INTEGRATION",
+	"SERVICENOW_INCIDENTS",
+	"SLACK",
+	"SLACK_COLLABORATION",
+	"SLACK_LEGACY",
+	"WEBHOOK",
+}
+
+var notificationChannelProducts = []string{
+	"ALERTS",
+	"DISCUSSIONS",
+	"ERROR_TRACKING",
+	"IINT",
+	"NTFC",
+	"PD",
+	"SHARING",
+}
+
+func resourceNewRelicNotificationChannel() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceNewRelicNotificationChannelCreate,
+		ReadContext:   resourceNewRelicNotificationChannelRead,
+		UpdateContext: resourceNewRelicNotificationChannelUpdate,
+		DeleteContext: resourceNewRelicNotificationChannelDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceImportStateWithAccountID,
+		},
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The New Relic account ID of the channel.",
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.NoZeroValues,
+				Description:  "The name of the channel.",
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(notificationChannelTypes, false),
+				Description:  "The type of the channel.",
+			},
+			"destination_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the destination the channel notifies.",
+			},
+			"product": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "IINT",
+				ValidateFunc: validation.StringInSlice(notificationChannelProducts, false),
+				Description:  "The product the channel belongs to. IINT, the default, is used by workflows.",
+			},
+			"active": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the channel is active.",
+			},
+			"property": notificationPropertySchema(),
+		},
+	}
+}
+
+func resourceNewRelicNotificationChannelCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+
+	if !providerConfig.hasNerdGraphCredentials() {
+		return diag.Errorf("err: NerdGraph support not present, but required for Create")
+	}
+
+	client := providerConfig.NewClient
+	accountID := selectAccountID(providerConfig, d)
+	input := expandNotificationChannelInput(d)
+
+	log.Printf("[INFO] Creating New Relic notification channel %s", input["name"])
+
+	created, err := createNotificationChannel(ctx, client, accountID, input)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(created.ID)
+
+	if err := d.Set("account_id", accountID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Channels are created active, an inactive one is deactivated right away.
+	if !d.Get("active").(bool) {
+		if _, err := updateNotificationChannel(ctx, client, accountID, created.ID, map[string]interface{}{"active": false}); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceNewRelicNotificationChannelRead(ctx, d, meta)
+}
+
+func resourceNewRelicNotificationChannelRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+
+	if !providerConfig.hasNerdGraphCredentials() {
+		return diag.Errorf("err: NerdGraph support not present, but required for Read")
+	}
+
+	client := providerConfig.NewClient
+	accountID := selectAccountID(providerConfig, d)
+
+	log.Printf("[INFO] Reading New Relic notification channel %s from account %d", d.Id(), accountID)
+
+	channel, err := getNotificationChannel(ctx, client, accountID, d.Id())
+	if err != nil {
+		if _, ok := err.(*nrErrors.NotFound); ok {
+			d.SetId("")
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("account_id", accountID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.FromErr(flattenNotificationChannel(channel, d))
+}
+
+func resourceNewRelicNotificationChannelUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+
+	if !providerConfig.hasNerdGraphCredentials() {
+		return diag.Errorf("err: NerdGraph support not present, but required for Update")
+	}
+
+	client := providerConfig.NewClient
+	accountID := selectAccountID(providerConfig, d)
+
+	log.Printf("[INFO] Updating New Relic notification channel %s", d.Id())
+
+	if _, err := updateNotificationChannel(ctx, client, accountID, d.Id(), expandNotificationChannelUpdate(d)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceNewRelicNotificationChannelRead(ctx, d, meta)
+}
+
+func resourceNewRelicNotificationChannelDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+
+	if !providerConfig.hasNerdGraphCredentials() {
+		return diag.Errorf("err: NerdGraph support not present, but required for Delete")
+	}
+
+	client := providerConfig.NewClient
+	accountID := selectAccountID(providerConfig, d)
+
+	log.Printf("[INFO] Deleting New Relic notification channel %s", d.Id())
+
+	if err := deleteNotificationChannel(ctx, client, accountID, d.Id()); err != nil {
+		if _, ok := err.(*nrErrors.NotFound); ok {
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,250 @@
+package newrelic
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	nrErrors "github.com/newrelic/newrelic-client-go/pkg/errors"
+)
+
+var notificationDestinationTypes = []string{
+	"EMAIL",
+	"EVENT_BRIDGE",
+	"JIRA",
+	"MOBILE_PUSH",
+	"PAGERDUTY_ACCOUNT_INTEGRATION",
+	"PAGERDUTY_SERVICE_INTEGRATION",
+	"SERVICE_NOW",
+	"SLACK",
+	"SLACK_COLLABORATION",
+	"SLACK_LEGACY",
+	"WEBHOOK",
+}
+
+// notificationPropertySchema is the schema of the key/value properties which
+// configure destinations and channels.
+func notificationPropertySchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeSet,
+		Optional:    true,
+		Description: "The key/value properties configuring the notification.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"key": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "The property key.",
+				},
+				"value": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "The property value.",
+				},
+				"label": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "The property label.",
+				},
+				"display_value": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "The value displayed for the property.",
+				},
+			},
+		},
+	}
+}
+
+func resourceNewRelicNotificationDestination() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceNewRelicNotificationDestinationCreate,
+		ReadContext:   resourceNewRelicNotificationDestinationRead,
+		UpdateContext: resourceNewRelicNotificationDestinationUpdate,
+		DeleteContext: resourceNewRelicNotificationDestinationDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceImportStateWithAccountID,
+		},
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The New Relic account ID of the destination.",
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.NoZeroValues,
+				Description:  "The name of the destination.",
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(notificationDestinationTypes, false),
+				Description:  "The type of the destination.",
+			},
+			"active": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the destination is active.",
+			},
+			"property": notificationPropertySchema(),
+			"auth_basic": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"auth_token"},
+				Description:   "Basic authentication credentials of the destination.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"user": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The user name.",
+						},
+						"password": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Sensitive:   true,
+							Description: "The password.",
+						},
+					},
+				},
+			},
+			"auth_token": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"auth_basic"},
+				Description:   "Token authentication credentials of the destination.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"prefix": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The prefix of the token, such as Bearer.",
+						},
+						"token": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Sensitive:   true,
+							Description: "The token.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceNewRelicNotificationDestinationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+
+	if !providerConfig.hasNerdGraphCredentials() {
+		return diag.Errorf("err: NerdGraph support not present, but required for Create")
+	}
+
+	client := providerConfig.NewClient
+	accountID := selectAccountID(providerConfig, d)
+	input := expandNotificationDestinationInput(d)
+
+	log.Printf("[INFO] Creating New Relic notification destination %s", input["name"])
+
+	created, err := createNotificationDestination(ctx, client, accountID, input)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(created.ID)
+
+	if err := d.Set("account_id", accountID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Destinations are created active, an inactive one is deactivated
+	// right away.
+	if !d.Get("active").(bool) {
+		if _, err := updateNotificationDestination(ctx, client, accountID, created.ID, map[string]interface{}{"active": false}); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceNewRelicNotificationDestinationRead(ctx, d, meta)
+}
+
+func resourceNewRelicNotificationDestinationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+
+	if !providerConfig.hasNerdGraphCredentials() {
+		return diag.Errorf("err: NerdGraph support not present, but required for Read")
+	}
+
+	client := providerConfig.NewClient
+	accountID := selectAccountID(providerConfig, d)
+
+	log.Printf("[INFO] Reading New Relic notification destination %s from account %d", d.Id(), accountID)
+
+	destination, err := getNotificationDestination(ctx, client, accountID, d.Id())
+	if err != nil {
+		if _, ok := err.(*nrErrors.NotFound); ok {
+			d.SetId("")
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("account_id", accountID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.FromErr(flattenNotificationDestination(destination, d))
+}
+
+func resourceNewRelicNotificationDestinationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+
+	if !providerConfig.hasNerdGraphCredentials() {
+		return diag.Errorf("err: NerdGraph support not present, but required for Update")
+	}
+
+	client := providerConfig.NewClient
+	accountID := selectAccountID(providerConfig, d)
+
+	log.Printf("[INFO] Updating New Relic notification destination %s", d.Id())
+
+	if _, err := updateNotificationDestination(ctx, client, accountID, d.Id(), expandNotificationDestinationUpdate(d)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceNewRelicNotificationDestinationRead(ctx, d, meta)
+}
+
+func resourceNewRelicNotificationDestinationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+
+	if !providerConfig.hasNerdGraphCredentials() {
+		return diag.Errorf("err: NerdGraph support not present, but required for Delete")
+	}
+
+	client := providerConfig.NewClient
+	accountID := selectAccountID(providerConfig, d)
+
+	log.Printf("[INFO] Deleting New Relic notification destination %s", d.Id())
+
+	if err := deleteNotificationDestination(ctx, client, accountID, d.Id()); err != nil {
+		if _, ok := err.(*nrErrors.NotFound); ok {
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,128 @@
+//go:build unit
+// +build unit
+
+package newrelic
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testNotificationDestinationConfig(name string, password string) map[string]interface{} {
+	return map[string]interface{}{
+		"name": name,
+		"type": "WEBHOOK",
+		"property": []interface{}{
+			map[string]interface{}{"key": "url", "value": "https://example.com/hook"},
+		},
+		"auth_basic": []interface{}{
+			map[string]interface{}{"user": "admin", "password": password},
+		},
+	}
+}
+
+func TestNewRelicNotificationDestination_Basic(t *testing.T) {
+	f := newFakeBackend(t)
+	meta := testFakeBackendMeta(t, f)
+	r := resourceNewRelicNotificationDestination()
+
+	state := testFakeBackendApply(t, meta, r, nil, testNotificationDestinationConfig("tf-test", "secret-1"))
+	id := state.ID
+
+	assert.Equal(t, fmt.Sprint(fakeBackendAccountID), state.Attributes["account_id"])
+	assert.Equal(t, "true", state.Attributes["active"])
+	assert.Equal(t, "admin", state.Attributes["auth_basic.0.user"])
+	assert.Equal(t, "secret-1", state.Attributes["auth_basic.0.password"])
+
+	// The password isn't returned, the one in state is kept.
+	state, diags := r.RefreshWithoutUpgrade(context.Background(), state, meta)
+	require.False(t, diags.HasError(), "%+v", diags)
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(testNotificationDestinationConfig("tf-test", "secret-1")), meta)
+	require.NoError(t, err)
+	assert.Nil(t, diff)
+
+	// Renaming updates the destination in place, without sending the credentials.
+	state = testFakeBackendApply(t, meta, r, state, testNotificationDestinationConfig("tf-test-updated", "secret-1"))
+	assert.Equal(t, id, state.ID)
+	assert.Equal(t, "tf-test-updated", f.notificationDestinations[id]["name"])
+
+	config := testNotificationDestinationConfig("tf-test-updated", "secret-1")
+	delete(config, "auth_basic")
+	config["active"] = false
+	state = testFakeBackendApply(t, meta, r, state, config)
+	assert.Equal(t, id, state.ID)
+	assert.Equal(t, "false", state.Attributes["active"])
+	assert.Nil(t, f.notificationDestinations[id]["auth"])
+
+	testFakeBackendApply(t, meta, r, state, nil)
+	assert.Empty(t, f.notificationDestinations)
+}
+
+func TestNewRelicNotificationDestination_Inactive(t *testing.T) {
+	f := newFakeBackend(t)
+	meta := testFakeBackendMeta(t, f)
+	r := resourceNewRelicNotificationDestination()
+
+	config := testNotificationDestinationConfig("tf-test", "secret-1")
+	config["active"] = false
+
+	state := testFakeBackendApply(t, meta, r, nil, config)
+	assert.Equal(t, "false", state.Attributes["active"])
+	assert.Equal(t, false, f.notificationDestinations[state.ID]["active"])
+}
+
+func TestNewRelicNotificationDestination_Import(t *testing.T) {
+	f := newFakeBackend(t)
+	meta := testFakeBackendMeta(t, f)
+	r := resourceNewRelicNotificationDestination()
+
+	state := testFakeBackendApply(t, meta, r, nil, testNotificationDestinationConfig("tf-test", "secret-1"))
+
+	d := r.Data(&terraform.InstanceState{ID: fmt.Sprintf("%s:%d", state.ID, 12345)})
+	imported, err := resourceImportStateWithAccountID(context.Background(), d, meta)
+	require.NoError(t, err)
+	require.Len(t, imported, 1)
+	assert.Equal(t, state.ID, imported[0].Id())
+	assert.Equal(t, 12345, imported[0].Get("account_id"))
+
+	d = r.Data(&terraform.InstanceState{ID: state.ID})
+	imported, err = resourceImportStateWithAccountID(context.Background(), d, meta)
+	require.NoError(t, err)
+	assert.Equal(t, state.ID, imported[0].Id())
+
+	refreshed, diags := r.RefreshWithoutUpgrade(context.Background(), &terraform.InstanceState{ID: state.ID}, meta)
+	require.False(t, diags.HasError(), "%+v", diags)
+	assert.Equal(t, "tf-test", refreshed.Attributes["name"])
+	assert.Equal(t, "admin", refreshed.Attributes["auth_basic.0.user"])
+	assert.Empty(t, refreshed.Attributes["auth_basic.0.password"])
+
+	_, err = resourceImportStateWithAccountID(context.Background(), r.Data(&terraform.InstanceState{ID: state.ID + ":abc"}), meta)
+	assert.Error(t, err)
+}
+
+func TestDataSourceNewRelicNotificationDestination(t *testing.T) {
+	f := newFakeBackend(t)
+	meta := testFakeBackendMeta(t, f)
+
+	testFakeBackendApply(t, meta, resourceNewRelicNotificationDestination(), nil, testNotificationDestinationConfig("tf-test-2", "secret"))
+	state := testFakeBackendApply(t, meta, resourceNewRelicNotificationDestination(), nil, testNotificationDestinationConfig("tf-test", "secret"))
+
+	ds := dataSourceNewRelicNotificationDestination()
+
+	d := schema.TestResourceDataRaw(t, ds.Schema, map[string]interface{}{"name": "tf-test"})
+	diags := ds.ReadContext(context.Background(), d, meta)
+	require.False(t, diags.HasError(), "%+v", diags)
+	assert.Equal(t, state.ID, d.Id())
+	assert.Equal(t, "WEBHOOK", d.Get("type"))
+	assert.Equal(t, 1, d.Get("property").(*schema.Set).Len())
+
+	d = schema.TestResourceDataRaw(t, ds.Schema, map[string]interface{}{"name": "tf-test-missing"})
+	diags = ds.ReadContext(context.Background(), d, meta)
+	assert.True(t, diags.HasError())
+}
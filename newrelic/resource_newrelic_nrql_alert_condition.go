@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/newrelic/newrelic-client-go/pkg/alerts"
@@ -120,6 +121,16 @@ func resourceNewRelicNrqlAlertCondition() *schema.Resource {
 		ReadContext:   resourceNewRelicNrqlAlertConditionRead,
 		UpdateContext: resourceNewRelicNrqlAlertConditionUpdate,
 		DeleteContext: resourceNewRelicNrqlAlertConditionDelete,
+		CustomizeDiff: customdiff.All(
+			customizeNrqlConditionStreamingDiff,
+			validateNrqlAlertConditionDiff,
+			// NerdGraph exposes a separate mutation per condition type, and an existing
+			// condition cannot be converted from one type to another, so a type change
+			// requires the condition to be replaced.
+			customdiff.ForceNewIfChange("type", func(ctx context.Context, old, new, meta interface{}) bool {
+				return old.(string) != "" && !strings.EqualFold(old.(string), new.(string))
+			}),
+		),
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceImportStateWithMetadata(2, "type"),
 		},
@@ -328,6 +339,39 @@ func resourceNewRelicNrqlAlertCondition() *schema.Resource {
 				Description:  "If using the 'static' fill option, this value will be used for filling gaps in the signal.",
 				RequiredWith: []string{"fill_option"},
 			},
+			"aggregation_method": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				Description:   "The method that determines when an aggregation window is considered complete and ready for evaluation. Valid values are: 'EVENT_FLOW', 'EVENT_TIMER', or 'CADENCE' (case insensitive).",
+				ConflictsWith: []string{"nrql.0.evaluation_offset", "nrql.0.since_value"},
+				ValidateFunc:  validation.StringInSlice([]string{"EVENT_FLOW", "EVENT_TIMER", "CADENCE"}, true),
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return strings.EqualFold(old, new) // Case fold this attribute when diffing
+				},
+			},
+			"aggregation_delay": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				Description:   "How long to wait, in seconds, for late data to arrive in each aggregation window. Used with the 'EVENT_FLOW' and 'CADENCE' aggregation methods.",
+				ConflictsWith: []string{"nrql.0.evaluation_offset", "nrql.0.since_value", "aggregation_timer"},
+				ValidateFunc:  validation.IntBetween(0, 3600),
+			},
+			"aggregation_timer": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				Description:   "How long to wait, in seconds, after each data point arrives before an aggregation window is evaluated. Used with the 'EVENT_TIMER' aggregation method.",
+				ConflictsWith: []string{"nrql.0.evaluation_offset", "nrql.0.since_value", "aggregation_delay"},
+				ValidateFunc:  validation.IntBetween(0, 1200),
+			},
+			"slide_by": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "The duration, in seconds, by which overlapping aggregation windows slide. Must be smaller than and a factor of 'aggregation_window'.",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
 			// Baseline ONLY
 			"baseline_direction": {
 				Type:          schema.TypeString,
@@ -362,6 +406,11 @@ func resourceNewRelicNrqlAlertConditionCreate(ctx context.Context, d *schema.Res
 		return diag.FromErr(err)
 	}
 
+	signal, err := expandStreamingSignal(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	log.Printf("[INFO] Creating New Relic NRQL alert condition %s via NerdGraph API", conditionInput.Name)
 
 	var condition *alerts.NrqlAlertCondition
@@ -386,6 +435,12 @@ func resourceNewRelicNrqlAlertConditionCreate(ctx context.Context, d *schema.Res
 
 	d.SetId(serializeIDs([]int{d.Get("policy_id").(int), conditionID}))
 
+	if signal != nil {
+		if err := updateNrqlConditionStreamingSignal(ctx, providerConfig, accountID, condition.ID, d.Get("type").(string), signal); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	return resourceNewRelicNrqlAlertConditionRead(ctx, d, meta)
 }
 
@@ -422,7 +477,16 @@ func resourceNewRelicNrqlAlertConditionRead(ctx context.Context, d *schema.Resou
 		return diag.FromErr(err)
 	}
 
-	return diag.FromErr(flattenNrqlAlertCondition(accountID, nrqlCondition, d))
+	if err := flattenNrqlAlertCondition(accountID, nrqlCondition, d); err != nil {
+		return diag.FromErr(err)
+	}
+
+	signal, err := getNrqlConditionStreamingSignal(ctx, providerConfig, accountID, strconv.Itoa(conditionID))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.FromErr(flattenStreamingSignal(d, signal))
 }
 
 func resourceNewRelicNrqlAlertConditionUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -437,11 +501,23 @@ func resourceNewRelicNrqlAlertConditionUpdate(ctx context.Context, d *schema.Res
 
 	conditionID := strconv.Itoa(ids[1])
 
+	// Type changes are planned as a replacement, so reaching this point with a
+	// changed type means the update mutation would target the wrong condition type.
+	if d.HasChange("type") {
+		oldType, newType := d.GetChange("type")
+		return diag.Errorf("cannot update NRQL alert condition %s from type `%s` to `%s` in place, the condition must be replaced", conditionID, oldType, newType)
+	}
+
 	conditionInput, err := expandNrqlAlertConditionInput(d)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
+	signal, err := expandStreamingSignal(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	switch d.Get("type").(string) {
 	case "baseline":
 		_, err = client.Alerts.UpdateNrqlConditionBaselineMutationWithContext(ctx, accountID, conditionID, *conditionInput)
@@ -455,6 +531,12 @@ func resourceNewRelicNrqlAlertConditionUpdate(ctx context.Context, d *schema.Res
 		return diag.FromErr(err)
 	}
 
+	if signal != nil {
+		if err := updateNrqlConditionStreamingSignal(ctx, providerConfig, accountID, conditionID, d.Get("type").(string), signal); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	return resourceNewRelicNrqlAlertConditionRead(ctx, d, meta)
 }
 
@@ -479,3 +561,294 @@ func resourceNewRelicNrqlAlertConditionDelete(ctx context.Context, d *schema.Res
 
 	return nil
 }
+
+// validateNrqlAlertConditionDiff performs the cross-attribute validation that
+// NerdGraph would otherwise only report at apply time. Values which are not yet
+// known during plan are skipped.
+func validateNrqlAlertConditionDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("type") {
+		return nil
+	}
+
+	var errs []string
+	conditionType := strings.ToLower(d.Get("type").(string))
+
+	// Baseline and outlier conditions ignore `value_function`, so it is only checked for static conditions.
+	valueFunction := strings.ToLower(d.Get("value_function").(string))
+	if conditionType == "static" && valueFunction == "" && d.NewValueKnown("value_function") {
+		errs = append(errs, fmt.Sprintf("attribute `%s` is required for nrql alert conditions of type `%s`", "value_function", conditionType))
+	}
+
+	baselineDirection := d.Get("baseline_direction").(string)
+	switch {
+	case conditionType == "baseline" && baselineDirection == "" && d.NewValueKnown("baseline_direction"):
+		errs = append(errs, fmt.Sprintf("attribute `%s` is required for nrql alert conditions of type `%s`", "baseline_direction", conditionType))
+	case conditionType != "baseline" && baselineDirection != "":
+		errs = append(errs, fmt.Sprintf("attribute `%s` is only supported for nrql alert conditions of type `baseline`, got type `%s`", "baseline_direction", conditionType))
+	}
+
+	expectedGroups := d.Get("expected_groups").(int)
+	if conditionType == "outlier" {
+		if expectedGroups < 0 {
+			errs = append(errs, fmt.Sprintf("attribute `%s` must be 0 or greater, got: %d", "expected_groups", expectedGroups))
+		}
+
+		if expectedGroups == 1 && d.NewValueKnown("expected_groups") {
+			if v, ok := d.GetOkExists("open_violation_on_group_overlap"); ok && v.(bool) {
+				errs = append(errs, fmt.Sprintf("attribute `%s` must be set to false when `expected_groups` is 1", "open_violation_on_group_overlap"))
+			}
+			if v, ok := d.GetOkExists("ignore_overlap"); ok && !v.(bool) {
+				errs = append(errs, fmt.Sprintf("attribute `%s` must be set to true when `expected_groups` is 1", "ignore_overlap"))
+			}
+		}
+	} else if expectedGroups != 0 {
+		errs = append(errs, fmt.Sprintf("attribute `%s` is only supported for nrql alert conditions of type `outlier`, got type `%s`", "expected_groups", conditionType))
+	}
+
+	if d.NewValueKnown("fill_option") && d.NewValueKnown("fill_value") {
+		fillOption := strings.ToLower(d.Get("fill_option").(string))
+		_, hasFillValue := d.GetOkExists("fill_value")
+
+		if fillOption == "static" && !hasFillValue {
+			errs = append(errs, fmt.Sprintf("attribute `%s` is required when `fill_option` is `static`", "fill_value"))
+		}
+
+		if fillOption != "static" && d.Get("fill_value").(float64) != 0 {
+			errs = append(errs, fmt.Sprintf("attribute `%s` can only be used when `fill_option` is `static`, got `fill_option` %q", "fill_value", fillOption))
+		}
+	}
+
+	// The API defaults the aggregation window to 60 seconds when it has not been configured.
+	aggregationWindow := 60
+	if d.NewValueKnown("aggregation_window") {
+		if v := d.Get("aggregation_window").(int); v > 0 {
+			aggregationWindow = v
+		}
+	} else {
+		aggregationWindow = 0
+	}
+
+	if d.NewValueKnown("aggregation_method") {
+		errs = append(errs, validateNrqlConditionStreamingDiff(d, aggregationWindow)...)
+	}
+
+	terms := d.Get("term").(*schema.Set)
+	for _, t := range terms.List() {
+		term := t.(map[string]interface{})
+		_, thresholdKnown := d.GetOkExists(fmt.Sprintf("term.%d.threshold", terms.F(t)))
+		errs = append(errs, validateNrqlConditionTermDiff(term, term["priority"].(string), conditionType, valueFunction, aggregationWindow, thresholdKnown)...)
+	}
+
+	for _, priority := range []string{"critical", "warning"} {
+		for i, t := range d.Get(priority).([]interface{}) {
+			if t == nil {
+				continue
+			}
+
+			_, thresholdKnown := d.GetOkExists(fmt.Sprintf("%s.%d.threshold", priority, i))
+			errs = append(errs, validateNrqlConditionTermDiff(t.(map[string]interface{}), priority, conditionType, valueFunction, aggregationWindow, thresholdKnown)...)
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf(strings.Join(errs, ", "))
+	}
+
+	return nil
+}
+
+// validateNrqlConditionTermDiff validates a single critical or warning term
+// against the condition's type, value function and aggregation window. An
+// aggregation window of 0 means the window is not known yet, in which case
+// the schema-level check that durations are a multiple of 60 still applies.
+// The threshold is only checked when it is known.
+func validateNrqlConditionTermDiff(term map[string]interface{}, priority, conditionType, valueFunction string, aggregationWindow int, thresholdKnown bool) []string {
+	var errs []string
+
+	var duration int
+	if v, ok := term["duration"].(int); ok && v > 0 {
+		duration = v * 60 // convert min to sec
+	} else if v, ok := term["threshold_duration"].(int); ok {
+		duration = v
+	}
+
+	if duration > 0 {
+		minVal, maxVal := 120, 3600
+
+		if conditionType == "static" {
+			maxVal = 7200
+			if valueFunction == "single_value" {
+				minVal = 60
+			}
+		}
+
+		if duration < minVal || duration > maxVal {
+			errs = append(errs, fmt.Sprintf("`%s` term `threshold_duration` must be between %d and %d seconds inclusive for nrql alert conditions of type `%s`, got: %d", priority, minVal, maxVal, conditionType, duration))
+		}
+
+		if aggregationWindow > 0 && duration%aggregationWindow != 0 {
+			errs = append(errs, fmt.Sprintf("`%s` term `threshold_duration` must be a multiple of `aggregation_window` (%d), got: %d", priority, aggregationWindow, duration))
+		}
+	}
+
+	switch conditionType {
+	case "baseline", "outlier":
+		if operator, ok := term["operator"].(string); ok && operator != "" && !strings.EqualFold(operator, "above") {
+			errs = append(errs, fmt.Sprintf("`%s` term `operator` must be `above` for nrql alert conditions of type `%s`, got: %s", priority, conditionType, operator))
+		}
+	}
+
+	if conditionType == "baseline" && thresholdKnown {
+		if threshold, ok := term["threshold"].(float64); ok && (threshold < 1 || threshold > 1000) {
+			errs = append(errs, fmt.Sprintf("`%s` term `threshold` must be between 1 and 1000 inclusive for nrql alert conditions of type `baseline`, got: %g", priority, threshold))
+		}
+	}
+
+	return errs
+}
+
+// nrqlConditionStreamingMethodFields are the settings used by each
+// aggregation method, besides the ones it shares with the others.
+var nrqlConditionStreamingMethodFields = map[string]string{
+	"EVENT_FLOW":  "aggregation_delay",
+	"EVENT_TIMER": "aggregation_timer",
+	"CADENCE":     "aggregation_delay",
+}
+
+// customizeNrqlConditionStreamingDiff drops the setting of the previous
+// aggregation method when the method changes. The settings are computed, so
+// the API default of the previous method would otherwise stay in state and be
+// validated and sent along with the new method.
+func customizeNrqlConditionStreamingDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.HasChange("aggregation_method") || !d.NewValueKnown("aggregation_method") {
+		return nil
+	}
+
+	method := strings.ToUpper(d.Get("aggregation_method").(string))
+
+	for _, field := range []string{"aggregation_delay", "aggregation_timer"} {
+		if field != nrqlConditionStreamingMethodFields[method] && !d.HasChange(field) {
+			if err := d.SetNewComputed(field); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateNrqlConditionStreamingDiff validates the streaming aggregation
+// settings against the configured aggregation method and window.
+func validateNrqlConditionStreamingDiff(d *schema.ResourceDiff, aggregationWindow int) []string {
+	var errs []string
+
+	// The API defaults to EVENT_FLOW when no aggregation method has been configured.
+	method := strings.ToUpper(d.Get("aggregation_method").(string))
+	if method == "" {
+		method = "EVENT_FLOW"
+	}
+
+	if v, ok := d.GetOk("aggregation_timer"); ok && method != "EVENT_TIMER" {
+		errs = append(errs, fmt.Sprintf("attribute `%s` can only be used when `aggregation_method` is `EVENT_TIMER`, got `aggregation_method` %q and `aggregation_timer` %d", "aggregation_timer", method, v.(int)))
+	}
+
+	if v, ok := d.GetOkExists("aggregation_delay"); ok {
+		delay := v.(int)
+
+		switch method {
+		case "EVENT_TIMER":
+			errs = append(errs, fmt.Sprintf("attribute `%s` can only be used when `aggregation_method` is `EVENT_FLOW` or `CADENCE`", "aggregation_delay"))
+		case "EVENT_FLOW":
+			if delay > 1200 {
+				errs = append(errs, fmt.Sprintf("attribute `%s` must be between 0 and 1200 inclusive when `aggregation_method` is `EVENT_FLOW`, got: %d", "aggregation_delay", delay))
+			}
+		}
+	}
+
+	if v, ok := d.GetOk("slide_by"); ok && aggregationWindow > 0 {
+		slideBy := v.(int)
+
+		if slideBy >= aggregationWindow || aggregationWindow%slideBy != 0 {
+			errs = append(errs, fmt.Sprintf("attribute `%s` must be smaller than and a factor of `aggregation_window` (%d), got: %d", "slide_by", aggregationWindow, slideBy))
+		}
+	}
+
+	return errs
+}
+
+// newrelic-client-go does not model the streaming aggregation settings of a
+// condition's signal yet, so they are set with this NerdGraph mutation after
+// the client created or updated the condition, and read back alongside the
+// client's own NRQL condition query.
+const (
+	getNrqlConditionStreamingSignalQuery = `query($accountId: Int!, $id: ID!) {
+		actor {
+			account(id: $accountId) {
+				alerts {
+					nrqlCondition(id: $id) {
+						signal {
+							aggregationMethod
+							aggregationDelay
+							aggregationTimer
+							slideBy
+						}
+					}
+				}
+			}
+		}
+	}`
+
+	updateNrqlConditionStreamingSignalMutation = `mutation($accountId: Int!, $id: ID!, $condition: AlertsNrqlConditionUpdate%[1]sInput!) {
+		alertsNrqlCondition%[1]sUpdate(accountId: $accountId, id: $id, condition: $condition) {
+			id
+		}
+	}`
+)
+
+var nrqlConditionTypeMutationNames = map[string]string{
+	"baseline": "Baseline",
+	"outlier":  "Outlier",
+	"static":   "Static",
+}
+
+type nrqlConditionStreamingSignalResponse struct {
+	Actor struct {
+		Account struct {
+			Alerts struct {
+				NrqlCondition struct {
+					Signal *nrqlConditionStreamingSignal `json:"signal"`
+				} `json:"nrqlCondition"`
+			} `json:"alerts"`
+		} `json:"account"`
+	} `json:"actor"`
+}
+
+func getNrqlConditionStreamingSignal(ctx context.Context, providerConfig *ProviderConfig, accountID int, conditionID string) (*nrqlConditionStreamingSignal, error) {
+	resp := nrqlConditionStreamingSignalResponse{}
+	vars := map[string]interface{}{
+		"accountId": accountID,
+		"id":        conditionID,
+	}
+
+	if err := providerConfig.NewClient.NerdGraph.QueryWithResponseAndContext(ctx, getNrqlConditionStreamingSignalQuery, vars, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Actor.Account.Alerts.NrqlCondition.Signal, nil
+}
+
+// updateNrqlConditionStreamingSignal sets the streaming aggregation settings
+// of a condition. The whole signal is sent, since the API replaces it.
+func updateNrqlConditionStreamingSignal(ctx context.Context, providerConfig *ProviderConfig, accountID int, conditionID string, conditionType string, signal *nrqlConditionStreamingSignal) error {
+	typeName := nrqlConditionTypeMutationNames[strings.ToLower(conditionType)]
+
+	vars := map[string]interface{}{
+		"accountId": accountID,
+		"id":        conditionID,
+		"condition": map[string]interface{}{
+			"signal": signal,
+		},
+	}
+
+	return providerConfig.NewClient.NerdGraph.QueryWithResponseAndContext(ctx, fmt.Sprintf(updateNrqlConditionStreamingSignalMutation, typeName), vars, &struct{}{})
+}
@@ -9,14 +9,13 @@ import (
 	"strconv"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccNewRelicNrqlAlertCondition_Basic(t *testing.T) {
 	resourceName := "newrelic_nrql_alert_condition.foo"
-	rName := acctest.RandString(5)
+	rName := testAccRandString(t, 5)
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -56,7 +55,7 @@ func TestAccNewRelicNrqlAlertCondition_Basic(t *testing.T) {
 }
 
 func TestAccNewRelicNrqlAlertCondition_MissingPolicy(t *testing.T) {
-	rName := acctest.RandString(5)
+	rName := testAccRandString(t, 5)
 	conditionType := "outlier"
 	conditionalAttr := `expected_groups = 2
 	open_violation_on_group_overlap = true`
@@ -94,8 +93,8 @@ func TestAccNewRelicNrqlAlertCondition_MissingPolicy(t *testing.T) {
 }
 
 func TestAccNewRelicNrqlAlertCondition_NerdGraphThresholdDurationValidationErrors(t *testing.T) {
-	rNameBaseline := acctest.RandString(5)
-	rNameOutlier := acctest.RandString(5)
+	rNameBaseline := testAccRandString(t, 5)
+	rNameOutlier := testAccRandString(t, 5)
 	conditionalAttrBaseline := `baseline_direction = "lower_only"`
 	conditionalAttrOutlier := `expected_groups = 2
 	open_violation_on_group_overlap = true`
@@ -162,7 +161,7 @@ func TestAccNewRelicNrqlAlertCondition_NerdGraphThresholdDurationValidationError
 
 func TestAccNewRelicNrqlAlertCondition_NerdGraphBaseline(t *testing.T) {
 	resourceName := "newrelic_nrql_alert_condition.foo"
-	rName := acctest.RandString(5)
+	rName := testAccRandString(t, 5)
 	conditionType := "baseline"
 	conditionalAttr := `baseline_direction = "lower_only"` // value transformed to UPPERCASE in expand/flatten
 
@@ -252,7 +251,7 @@ func TestAccNewRelicNrqlAlertCondition_NerdGraphBaseline(t *testing.T) {
 
 func TestAccNewRelicNrqlAlertCondition_NerdGraphStatic(t *testing.T) {
 	resourceName := "newrelic_nrql_alert_condition.foo"
-	rName := acctest.RandString(5)
+	rName := testAccRandString(t, 5)
 	conditionType := "static"
 	conditionalAttr := `value_function = "Single_valuE"` // value transformed to UPPERCASE in expand/flatten
 
@@ -341,7 +340,7 @@ func TestAccNewRelicNrqlAlertCondition_NerdGraphStatic(t *testing.T) {
 
 func TestAccNewRelicNrqlAlertCondition_NerdGraphOutlier(t *testing.T) {
 	resourceName := "newrelic_nrql_alert_condition.foo"
-	rName := acctest.RandString(5)
+	rName := testAccRandString(t, 5)
 	conditionType := "outlier"
 	conditionalAttr := `expected_groups = 2
 	open_violation_on_group_overlap = true`
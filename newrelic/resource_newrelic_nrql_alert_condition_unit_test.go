@@ -0,0 +1,384 @@
+//go:build unit
+// +build unit
+
+package newrelic
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testNrqlAlertConditionDiffConfig(overrides map[string]interface{}) map[string]interface{} {
+	return testResourceConfig(map[string]interface{}{
+		"policy_id":                    1,
+		"name":                         "tf-test",
+		"type":                         "static",
+		"value_function":               "single_value",
+		"violation_time_limit_seconds": 3600,
+		"nrql": []interface{}{
+			map[string]interface{}{
+				"query":             "SELECT count(*) FROM Transaction",
+				"evaluation_offset": 3,
+			},
+		},
+		"critical": []interface{}{
+			map[string]interface{}{
+				"operator":              "above",
+				"threshold":             1.0,
+				"threshold_duration":    120,
+				"threshold_occurrences": "ALL",
+			},
+		},
+	}, overrides)
+}
+
+// testUnknownValue is how the SDK represents a value which is not known until
+// apply in a raw resource config.
+const testUnknownValue = "74D93920-ED26-11E3-AC10-0800200C9A66"
+
+var testNrqlAlertConditionStreamingNrql = []interface{}{
+	map[string]interface{}{
+		"query": "SELECT count(*) FROM Transaction",
+	},
+}
+
+func testNrqlAlertConditionDiff(config map[string]interface{}) error {
+	r := resourceNewRelicNrqlAlertCondition()
+	_, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), nil)
+
+	return err
+}
+
+func TestValidateNrqlAlertConditionDiff(t *testing.T) {
+	cases := map[string]struct {
+		Overrides map[string]interface{}
+		ErrorMsg  string
+	}{
+		"valid static": {},
+		"valid baseline": {
+			Overrides: map[string]interface{}{
+				"type":               "baseline",
+				"value_function":     nil,
+				"baseline_direction": "upper_only",
+			},
+		},
+		"valid outlier": {
+			Overrides: map[string]interface{}{
+				"type":                            "outlier",
+				"value_function":                  nil,
+				"expected_groups":                 2,
+				"open_violation_on_group_overlap": true,
+			},
+		},
+		"static missing value_function": {
+			Overrides: map[string]interface{}{"value_function": nil},
+			ErrorMsg:  "attribute `value_function` is required for nrql alert conditions of type `static`",
+		},
+		"baseline missing baseline_direction": {
+			Overrides: map[string]interface{}{"type": "baseline", "value_function": nil},
+			ErrorMsg:  "attribute `baseline_direction` is required for nrql alert conditions of type `baseline`",
+		},
+		"valid outlier with value_function": {
+			Overrides: map[string]interface{}{"type": "outlier"},
+		},
+		"static with expected_groups": {
+			Overrides: map[string]interface{}{"expected_groups": 2},
+			ErrorMsg:  "attribute `expected_groups` is only supported for nrql alert conditions of type `outlier`",
+		},
+		"outlier with negative expected_groups": {
+			Overrides: map[string]interface{}{
+				"type":            "outlier",
+				"value_function":  nil,
+				"expected_groups": -1,
+			},
+			ErrorMsg: "attribute `expected_groups` must be 0 or greater, got: -1",
+		},
+		"outlier group overlap with single group": {
+			Overrides: map[string]interface{}{
+				"type":                            "outlier",
+				"value_function":                  nil,
+				"expected_groups":                 1,
+				"open_violation_on_group_overlap": true,
+			},
+			ErrorMsg: "attribute `open_violation_on_group_overlap` must be set to false when `expected_groups` is 1",
+		},
+		"outlier group overlap without expected_groups": {
+			Overrides: map[string]interface{}{
+				"type":                            "outlier",
+				"value_function":                  nil,
+				"open_violation_on_group_overlap": true,
+			},
+		},
+		"static fill option without fill value": {
+			Overrides: map[string]interface{}{"fill_option": "static"},
+			ErrorMsg:  "attribute `fill_value` is required when `fill_option` is `static`",
+		},
+		"fill value without static fill option": {
+			Overrides: map[string]interface{}{"fill_option": "last_value", "fill_value": 1.0},
+			ErrorMsg:  "attribute `fill_value` can only be used when `fill_option` is `static`",
+		},
+		"sum threshold duration too short": {
+			Overrides: map[string]interface{}{
+				"value_function": "sum",
+				"critical": []interface{}{
+					map[string]interface{}{
+						"threshold":             1.0,
+						"threshold_duration":    60,
+						"threshold_occurrences": "ALL",
+					},
+				},
+			},
+			ErrorMsg: "`critical` term `threshold_duration` must be between 120 and 7200 seconds inclusive for nrql alert conditions of type `static`, got: 60",
+		},
+		"baseline threshold duration too long": {
+			Overrides: map[string]interface{}{
+				"type":               "baseline",
+				"value_function":     nil,
+				"baseline_direction": "upper_only",
+				"warning": []interface{}{
+					map[string]interface{}{
+						"operator":              "above",
+						"threshold":             1.0,
+						"threshold_duration":    7200,
+						"threshold_occurrences": "ALL",
+					},
+				},
+			},
+			ErrorMsg: "`warning` term `threshold_duration` must be between 120 and 3600 seconds inclusive for nrql alert conditions of type `baseline`, got: 7200",
+		},
+		"threshold duration not a multiple of aggregation window": {
+			Overrides: map[string]interface{}{"aggregation_window": 300},
+			ErrorMsg:  "`critical` term `threshold_duration` must be a multiple of `aggregation_window` (300), got: 120",
+		},
+		"baseline operator": {
+			Overrides: map[string]interface{}{
+				"type":               "baseline",
+				"value_function":     nil,
+				"baseline_direction": "upper_only",
+				"critical": []interface{}{
+					map[string]interface{}{
+						"operator":              "below",
+						"threshold":             1.0,
+						"threshold_duration":    120,
+						"threshold_occurrences": "ALL",
+					},
+				},
+			},
+			ErrorMsg: "`critical` term `operator` must be `above` for nrql alert conditions of type `baseline`, got: below",
+		},
+		"valid event timer": {
+			Overrides: map[string]interface{}{
+				"nrql":               testNrqlAlertConditionStreamingNrql,
+				"aggregation_method": "event_timer",
+				"aggregation_timer":  60,
+				"slide_by":           30,
+			},
+		},
+		"aggregation timer without event timer": {
+			Overrides: map[string]interface{}{
+				"nrql":               testNrqlAlertConditionStreamingNrql,
+				"aggregation_method": "cadence",
+				"aggregation_timer":  60,
+			},
+			ErrorMsg: "attribute `aggregation_timer` can only be used when `aggregation_method` is `EVENT_TIMER`",
+		},
+		"aggregation delay with event timer": {
+			Overrides: map[string]interface{}{
+				"nrql":               testNrqlAlertConditionStreamingNrql,
+				"aggregation_method": "event_timer",
+				"aggregation_delay":  60,
+			},
+			ErrorMsg: "attribute `aggregation_delay` can only be used when `aggregation_method` is `EVENT_FLOW` or `CADENCE`",
+		},
+		"aggregation delay too long for event flow": {
+			Overrides: map[string]interface{}{
+				"nrql":               testNrqlAlertConditionStreamingNrql,
+				"aggregation_method": "event_flow",
+				"aggregation_delay":  1800,
+			},
+			ErrorMsg: "attribute `aggregation_delay` must be between 0 and 1200 inclusive when `aggregation_method` is `EVENT_FLOW`, got: 1800",
+		},
+		"slide by not a factor of aggregation window": {
+			Overrides: map[string]interface{}{
+				"aggregation_window": 60,
+				"slide_by":           45,
+			},
+			ErrorMsg: "attribute `slide_by` must be smaller than and a factor of `aggregation_window` (60), got: 45",
+		},
+		"baseline threshold out of range": {
+			Overrides: map[string]interface{}{
+				"type":               "baseline",
+				"value_function":     nil,
+				"baseline_direction": "upper_only",
+				"critical": []interface{}{
+					map[string]interface{}{
+						"operator":              "above",
+						"threshold":             1001.0,
+						"threshold_duration":    120,
+						"threshold_occurrences": "ALL",
+					},
+				},
+			},
+			ErrorMsg: "`critical` term `threshold` must be between 1 and 1000 inclusive for nrql alert conditions of type `baseline`, got: 1001",
+		},
+		"baseline threshold not known yet": {
+			Overrides: map[string]interface{}{
+				"type":               "baseline",
+				"value_function":     nil,
+				"baseline_direction": "upper_only",
+				"critical": []interface{}{
+					map[string]interface{}{
+						"operator":              "above",
+						"threshold":             testUnknownValue,
+						"threshold_duration":    120,
+						"threshold_occurrences": "ALL",
+					},
+				},
+			},
+		},
+		"baseline threshold of 0": {
+			Overrides: map[string]interface{}{
+				"type":               "baseline",
+				"value_function":     nil,
+				"baseline_direction": "upper_only",
+				"critical":           nil,
+				"term": []interface{}{
+					map[string]interface{}{
+						"priority":              "critical",
+						"operator":              "above",
+						"threshold":             0.0,
+						"threshold_duration":    120,
+						"threshold_occurrences": "ALL",
+					},
+				},
+			},
+			ErrorMsg: "`critical` term `threshold` must be between 1 and 1000 inclusive for nrql alert conditions of type `baseline`, got: 0",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := testNrqlAlertConditionDiff(testNrqlAlertConditionDiffConfig(tc.Overrides))
+
+			if tc.ErrorMsg == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.ErrorMsg)
+		})
+	}
+}
+
+// testNrqlAlertConditionFakeBackend returns a backend holding the policy
+// 101, and the configuration of a condition in it with the overrides.
+func testNrqlAlertConditionFakeBackend(t *testing.T) (*fakeBackend, *ProviderConfig, func(map[string]interface{}) map[string]interface{}) {
+	f := newFakeBackend(t)
+	meta := testFakeBackendMeta(t, f)
+	policyID := f.addPolicy("tf-test")
+
+	config := func(overrides map[string]interface{}) map[string]interface{} {
+		c := testNrqlAlertConditionDiffConfig(overrides)
+		c["policy_id"] = policyID
+		return c
+	}
+
+	return f, meta, config
+}
+
+func TestNrqlAlertCondition_UpdateSameType(t *testing.T) {
+	f, meta, config := testNrqlAlertConditionFakeBackend(t)
+	r := resourceNewRelicNrqlAlertCondition()
+
+	state := testFakeBackendApply(t, meta, r, nil, config(nil))
+	require.Equal(t, "101:102", state.ID)
+
+	updated := config(map[string]interface{}{"name": "tf-test-updated"})
+	assert.False(t, testFakeBackendPlan(t, meta, r, state, updated).RequiresNew())
+
+	state = testFakeBackendApply(t, meta, r, state, updated)
+	assert.Equal(t, "101:102", state.ID)
+	assert.Equal(t, "tf-test-updated", state.Attributes["name"])
+	assert.Equal(t, []string{"create:static", "update:static"}, f.takeOperations(false))
+}
+
+func TestNrqlAlertCondition_TypeChangeForcesReplacement(t *testing.T) {
+	f, meta, config := testNrqlAlertConditionFakeBackend(t)
+	r := resourceNewRelicNrqlAlertCondition()
+
+	state := testFakeBackendApply(t, meta, r, nil, config(nil))
+	require.Equal(t, "101:102", state.ID)
+	require.Equal(t, "static", state.Attributes["type"])
+
+	baseline := config(map[string]interface{}{
+		"type":               "baseline",
+		"value_function":     nil,
+		"baseline_direction": "upper_only",
+	})
+
+	diff := testFakeBackendPlan(t, meta, r, state, baseline)
+	require.True(t, diff.RequiresNew())
+	assert.True(t, diff.Attributes["type"].RequiresNew)
+
+	state = testFakeBackendApply(t, meta, r, state, baseline)
+
+	// The replacement keeps the <policyID>:<conditionID> ID format.
+	assert.Equal(t, "101:103", state.ID)
+	assert.Equal(t, "baseline", state.Attributes["type"])
+	assert.Equal(t, []string{"create:static", "delete:102", "create:baseline"}, f.takeOperations(false))
+	assert.Nil(t, f.nrqlCondition("102"))
+}
+
+func TestNrqlAlertCondition_StreamingSettings(t *testing.T) {
+	f, meta, config := testNrqlAlertConditionFakeBackend(t)
+
+	state := testFakeBackendApply(t, meta, resourceNewRelicNrqlAlertCondition(), nil, config(map[string]interface{}{
+		"nrql":               testNrqlAlertConditionStreamingNrql,
+		"aggregation_method": "event_timer",
+		"aggregation_timer":  90,
+		"slide_by":           30,
+	}))
+
+	assert.Equal(t, "101:102", state.ID)
+	assert.Equal(t, "event_timer", state.Attributes["aggregation_method"])
+	assert.Equal(t, "90", state.Attributes["aggregation_timer"])
+	assert.Equal(t, "30", state.Attributes["slide_by"])
+
+	// The streaming settings are set once the client created the condition.
+	assert.Equal(t, []string{"create:static", "update:static"}, f.takeOperations(false))
+	signal := f.nrqlCondition("102")["signal"].(map[string]interface{})
+	assert.Equal(t, "EVENT_TIMER", signal["aggregationMethod"])
+	assert.Equal(t, float64(90), signal["aggregationTimer"])
+}
+
+func TestNrqlAlertCondition_StreamingMethodChange(t *testing.T) {
+	f, meta, config := testNrqlAlertConditionFakeBackend(t)
+	r := resourceNewRelicNrqlAlertCondition()
+
+	state := testFakeBackendApply(t, meta, r, nil, config(map[string]interface{}{
+		"nrql":               testNrqlAlertConditionStreamingNrql,
+		"aggregation_method": "event_flow",
+		"aggregation_delay":  120,
+	}))
+	require.Equal(t, "120", state.Attributes["aggregation_delay"])
+
+	// The delay of the previous method is left in state, but is neither
+	// validated nor sent with the timer.
+	state = testFakeBackendApply(t, meta, r, state, config(map[string]interface{}{
+		"nrql":               testNrqlAlertConditionStreamingNrql,
+		"aggregation_method": "event_timer",
+		"aggregation_timer":  90,
+	}))
+
+	signal := f.nrqlCondition("102")["signal"].(map[string]interface{})
+	assert.Equal(t, "EVENT_TIMER", signal["aggregationMethod"])
+	assert.NotContains(t, signal, "aggregationDelay")
+	assert.Equal(t, "event_timer", state.Attributes["aggregation_method"])
+	assert.Equal(t, "90", state.Attributes["aggregation_timer"])
+	assert.Equal(t, "0", state.Attributes["aggregation_delay"])
+}
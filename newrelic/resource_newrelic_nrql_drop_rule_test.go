@@ -8,13 +8,12 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccNewRelicNRQLDropRule_Data(t *testing.T) {
-	rand := acctest.RandString(5)
+	rand := testAccRandString(t, 5)
 	description := fmt.Sprintf("nrql_drop_rule_%s", rand)
 	resourceName := "newrelic_nrql_drop_rule.foo"
 
@@ -41,7 +40,7 @@ func TestAccNewRelicNRQLDropRule_Data(t *testing.T) {
 }
 
 func TestAccNewRelicNRQLDropRule_Attributes(t *testing.T) {
-	rand := acctest.RandString(5)
+	rand := testAccRandString(t, 5)
 	description := fmt.Sprintf("nrql_drop_rule_%s", rand)
 	resourceName := "newrelic_nrql_drop_rule.foo"
 
@@ -68,7 +67,7 @@ func TestAccNewRelicNRQLDropRule_Attributes(t *testing.T) {
 }
 
 func TestAccNewRelicNRQLDropRule_AccountIDInheritance(t *testing.T) {
-	rand := acctest.RandString(5)
+	rand := testAccRandString(t, 5)
 	description := fmt.Sprintf("nrql_drop_rule_%s", rand)
 	resourceName := "newrelic_nrql_drop_rule.foo"
 
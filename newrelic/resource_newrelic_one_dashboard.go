@@ -2,12 +2,18 @@ package newrelic
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/newrelic/newrelic-client-go/pkg/common"
+	"github.com/newrelic/newrelic-client-go/pkg/dashboards"
+	"github.com/newrelic/newrelic-client-go/pkg/entities"
 	"github.com/newrelic/newrelic-client-go/pkg/errors"
 )
 
@@ -17,6 +23,10 @@ func resourceNewRelicOneDashboard() *schema.Resource {
 		ReadContext:   resourceNewRelicOneDashboardRead,
 		UpdateContext: resourceNewRelicOneDashboardUpdate,
 		DeleteContext: resourceNewRelicOneDashboardDelete,
+		CustomizeDiff: customdiff.All(
+			customizeDiffDefaultTags,
+			validateDashboardLayoutDiff,
+		),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -52,6 +62,12 @@ func resourceNewRelicOneDashboard() *schema.Resource {
 				ValidateFunc: validation.StringInSlice([]string{"private", "public_read_only", "public_read_write"}, false),
 				Description:  "Determines who can see or edit the dashboard. Valid values are private, public_read_only, public_read_write. Defaults to public_read_only.",
 			},
+			"variable": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Dashboard-local variable definitions, referenced in the widgets' NRQL queries as {{name}}.",
+				Elem:        dashboardVariableSchemaElem(),
+			},
 			// Computed
 			"guid": {
 				Type:        schema.TypeString,
@@ -63,13 +79,14 @@ func resourceNewRelicOneDashboard() *schema.Resource {
 				Computed:    true,
 				Description: "The URL of the dashboard.",
 			},
+			"default_tags": defaultTagsSchema(),
 		},
 	}
 }
 
 // dashboardPageElem returns the schema for a New Relic dashboard Page
 func dashboardPageSchemaElem() *schema.Resource {
-	return &schema.Resource{
+	r := &schema.Resource{
 		Schema: map[string]*schema.Schema{
 			"description": {
 				Type:        schema.TypeString,
@@ -160,8 +177,221 @@ func dashboardPageSchemaElem() *schema.Resource {
 				Description: "A JSON widget.",
 				Elem:        dashboardWidgetJSONSchemaElem(),
 			},
+			"widget_stacked_bar": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "A stacked bar widget.",
+				Elem:        dashboardWidgetStackedBarSchemaElem(),
+			},
+			"widget_passthrough": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "A widget of a visualization without a block of its own, such as a traffic light or a custom visualization.",
+				Elem:        dashboardWidgetPassthroughSchemaElem(),
+			},
 		},
 	}
+
+	for _, w := range dashboardQueriesOnlyWidgets {
+		r.Schema[w.block] = &schema.Schema{
+			Type:        schema.TypeList,
+			Optional:    true,
+			Description: w.description,
+			Elem:        dashboardWidgetQueriesOnlySchemaElem(),
+		}
+	}
+
+	return r
+}
+
+// dashboardVariableSchemaElem returns the schema for a New Relic dashboard variable
+func dashboardVariableSchemaElem() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The variable identifier, referenced in NRQL queries as {{name}}.",
+			},
+			"title": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "A human-friendly display string for this variable.",
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"enum", "nrql", "string"}, false),
+				Description:  "Specifies the data type of the variable and where its possible values may come from. Valid values are enum, nrql, string.",
+			},
+			"default_values": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Default values for this variable.",
+			},
+			"is_multi_selection": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Indicates whether this variable supports multiple selection or not.",
+			},
+			"item": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "List of possible values for variables of type enum.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"title": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "A human-friendly display string for this value.",
+						},
+						"value": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "A possible variable value.",
+						},
+					},
+				},
+			},
+			"nrql_query": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Configuration for variables of type nrql.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"account_ids": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeInt},
+							Description: "New Relic account ID(s) to issue the query against. Defaults to the account of the dashboard.",
+						},
+						"query": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "NRQL formatted query returning the possible values of the variable.",
+						},
+					},
+				},
+			},
+			"replacement_strategy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "default",
+				ValidateFunc: validation.StringInSlice([]string{"default", "identifier", "number", "string"}, false),
+				Description:  "Indicates the strategy to apply when replacing a variable in a NRQL query. Valid values are default, identifier, number, string. Defaults to default.",
+			},
+		},
+	}
+}
+
+// dashboardGridColumns is the number of columns of a dashboard page.
+const dashboardGridColumns = 12
+
+// validateDashboardLayoutDiff checks that the widgets with a configured
+// position fit in the columns of their page and don't overlap, which the API
+// would otherwise fix by moving them around, and that the widgets with only a
+// row can be placed in it.
+func validateDashboardLayoutDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	var errs []string
+
+	for _, p := range d.Get("page").([]interface{}) {
+		page := p.(map[string]interface{})
+
+		var titles []string
+		var layouts []dashboards.DashboardWidgetLayoutInput
+		var widgets []dashboards.DashboardWidgetInput
+
+		for _, widgetType := range dashboardPageWidgetBlocks(page) {
+			for _, w := range page[widgetType].([]interface{}) {
+				widget := w.(map[string]interface{})
+				title := widget["title"].(string)
+				layout := dashboards.DashboardWidgetLayoutInput{
+					Column: widget["column"].(int),
+					Height: widget["height"].(int),
+					Row:    widget["row"].(int),
+					Width:  widget["width"].(int),
+				}
+
+				if layout.Height == 0 || layout.Width == 0 {
+					continue
+				}
+
+				widgets = append(widgets, dashboards.DashboardWidgetInput{Title: title, Layout: layout})
+
+				if layout.Column == 0 {
+					continue
+				}
+
+				if end := layout.Column + layout.Width - 1; end > dashboardGridColumns {
+					errs = append(errs, fmt.Sprintf("widget %q on page %q ends at column %d, past the %d columns of the page", title, page["name"], end, dashboardGridColumns))
+				}
+
+				// Widgets without a row are placed when applied
+				if layout.Row == 0 {
+					continue
+				}
+
+				for i, other := range layouts {
+					if dashboardWidgetsOverlap(layout, other) {
+						errs = append(errs, fmt.Sprintf("widgets %q and %q on page %q overlap", titles[i], title, page["name"]))
+					}
+				}
+
+				titles = append(titles, title)
+				layouts = append(layouts, layout)
+			}
+		}
+
+		// Widgets are placed as they will be when applied.
+		if err := layoutDashboardWidgets(widgets); err != nil {
+			errs = append(errs, fmt.Sprintf("%s on page %q", err, page["name"]))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, ", "))
+	}
+
+	return nil
+}
+
+// dashboardPageWidgetBlocks returns the widget blocks of a page, in the order
+// in which expandDashboardPageInput adds their widgets to the page.
+func dashboardPageWidgetBlocks(page map[string]interface{}) []string {
+	blocks := []string{
+		"widget_area",
+		"widget_bar",
+		"widget_billboard",
+		"widget_bullet",
+		"widget_funnel",
+		"widget_heatmap",
+		"widget_histogram",
+		"widget_line",
+		"widget_markdown",
+		"widget_pie",
+		"widget_table",
+		"widget_json",
+		"widget_stacked_bar",
+	}
+
+	for _, w := range dashboardQueriesOnlyWidgets {
+		blocks = append(blocks, w.block)
+	}
+
+	// The raw dashboard's widgets are in a single block.
+	blocks = append(blocks, "widget_passthrough", "widget")
+
+	var present []string
+	for _, block := range blocks {
+		if _, ok := page[block].([]interface{}); ok {
+			present = append(present, block)
+		}
+	}
+
+	return present
 }
 
 func dashboardWidgetSchemaBase() map[string]*schema.Schema {
@@ -177,8 +407,9 @@ func dashboardWidgetSchemaBase() map[string]*schema.Schema {
 			Description: "A title for the widget.",
 		},
 		"column": {
-			Type:     schema.TypeInt,
-			Required: true,
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(1),
 		},
 		"height": {
 			Type:         schema.TypeInt,
@@ -187,8 +418,9 @@ func dashboardWidgetSchemaBase() map[string]*schema.Schema {
 			ValidateFunc: validation.IntAtLeast(1),
 		},
 		"row": {
-			Type:     schema.TypeInt,
-			Required: true,
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(1),
 		},
 		"width": {
 			Type:         schema.TypeInt,
@@ -228,6 +460,13 @@ func dashboardWidgetNRQLQuerySchemaElem() *schema.Resource {
 func dashboardWidgetAreaSchemaElem() *schema.Resource {
 	s := dashboardWidgetSchemaBase()
 
+	s["y_axis_left"] = dashboardWidgetYAxisLeftSchema()
+	s["null_values"] = dashboardWidgetNullValuesSchema()
+	s["units"] = dashboardWidgetUnitsSchema()
+	s["colors"] = dashboardWidgetColorsSchema()
+	s["legend_enabled"] = dashboardWidgetLegendEnabledSchema()
+	s["facet_show_other_series"] = dashboardWidgetFacetShowOtherSeriesSchema()
+
 	return &schema.Resource{
 		Schema: s,
 	}
@@ -238,6 +477,11 @@ func dashboardWidgetBarSchemaElem() *schema.Resource {
 
 	s["linked_entity_guids"] = dashboardWidgetLinkedEntityGUIDsSchema()
 
+	s["units"] = dashboardWidgetUnitsSchema()
+	s["colors"] = dashboardWidgetColorsSchema()
+	s["facet_show_other_series"] = dashboardWidgetFacetShowOtherSeriesSchema()
+	s["filter_current_dashboard"] = dashboardWidgetFilterCurrentDashboardSchema()
+
 	return &schema.Resource{
 		Schema: s,
 	}
@@ -258,6 +502,8 @@ func dashboardWidgetBillboardSchemaElem() *schema.Resource {
 		Description: "The warning threshold value.",
 	}
 
+	s["units"] = dashboardWidgetUnitsSchema()
+
 	return &schema.Resource{
 		Schema: s,
 	}
@@ -304,6 +550,14 @@ func dashboardWidgetHistogramSchemaElem() *schema.Resource {
 func dashboardWidgetLineSchemaElem() *schema.Resource {
 	s := dashboardWidgetSchemaBase()
 
+	s["y_axis_left"] = dashboardWidgetYAxisLeftSchema()
+	s["null_values"] = dashboardWidgetNullValuesSchema()
+	s["units"] = dashboardWidgetUnitsSchema()
+	s["colors"] = dashboardWidgetColorsSchema()
+	s["legend_enabled"] = dashboardWidgetLegendEnabledSchema()
+	s["facet_show_other_series"] = dashboardWidgetFacetShowOtherSeriesSchema()
+	s["filter_current_dashboard"] = dashboardWidgetFilterCurrentDashboardSchema()
+
 	return &schema.Resource{
 		Schema: s,
 	}
@@ -338,6 +592,12 @@ func dashboardWidgetPieSchemaElem() *schema.Resource {
 
 	s["linked_entity_guids"] = dashboardWidgetLinkedEntityGUIDsSchema()
 
+	s["units"] = dashboardWidgetUnitsSchema()
+	s["colors"] = dashboardWidgetColorsSchema()
+	s["legend_enabled"] = dashboardWidgetLegendEnabledSchema()
+	s["facet_show_other_series"] = dashboardWidgetFacetShowOtherSeriesSchema()
+	s["filter_current_dashboard"] = dashboardWidgetFilterCurrentDashboardSchema()
+
 	return &schema.Resource{
 		Schema: s,
 	}
@@ -348,11 +608,296 @@ func dashboardWidgetTableSchemaElem() *schema.Resource {
 
 	s["linked_entity_guids"] = dashboardWidgetLinkedEntityGUIDsSchema()
 
+	s["units"] = dashboardWidgetUnitsSchema()
+	s["facet_show_other_series"] = dashboardWidgetFacetShowOtherSeriesSchema()
+	s["filter_current_dashboard"] = dashboardWidgetFilterCurrentDashboardSchema()
+
+	return &schema.Resource{
+		Schema: s,
+	}
+}
+
+func dashboardWidgetStackedBarSchemaElem() *schema.Resource {
+	s := dashboardWidgetSchemaBase()
+
+	s["y_axis_left"] = dashboardWidgetYAxisLeftSchema()
+	s["null_values"] = dashboardWidgetNullValuesSchema()
+	s["units"] = dashboardWidgetUnitsSchema()
+	s["colors"] = dashboardWidgetColorsSchema()
+	s["legend_enabled"] = dashboardWidgetLegendEnabledSchema()
+	s["facet_show_other_series"] = dashboardWidgetFacetShowOtherSeriesSchema()
+
+	return &schema.Resource{
+		Schema: s,
+	}
+}
+
+// dashboardQueriesOnlyWidget is a widget block of a visualization which has
+// no settings other than its queries.
+type dashboardQueriesOnlyWidget struct {
+	visualizationID string
+	block           string
+	description     string
+}
+
+var dashboardQueriesOnlyWidgets = []dashboardQueriesOnlyWidget{
+	{visualizationID: "logger.log-table-widget", block: "widget_log_table", description: "A log table widget."},
+	{visualizationID: "viz.event-feed", block: "widget_event_feed", description: "An event feed widget."},
+}
+
+// dashboardQueriesOnlyWidgetBlock returns the block of a queries-only widget
+// visualization, if any.
+func dashboardQueriesOnlyWidgetBlock(visualizationID string) (string, bool) {
+	for _, w := range dashboardQueriesOnlyWidgets {
+		if w.visualizationID == visualizationID {
+			return w.block, true
+		}
+	}
+
+	return "", false
+}
+
+func dashboardWidgetQueriesOnlySchemaElem() *schema.Resource {
+	s := dashboardWidgetSchemaBase()
+
+	return &schema.Resource{
+		Schema: s,
+	}
+}
+
+// The visualizations with a widget block of their own, which can't be used in
+// a passthrough widget as they would be read back into their own block.
+var dashboardWidgetVisualizationIDs = []string{
+	"viz.area",
+	"viz.bar",
+	"viz.billboard",
+	"viz.bullet",
+	"viz.event-feed",
+	"viz.funnel",
+	"viz.heatmap",
+	"viz.histogram",
+	"viz.json",
+	"viz.line",
+	"viz.markdown",
+	"viz.pie",
+	"viz.stacked-bar",
+	"viz.table",
+	"logger.log-table-widget",
+}
+
+func dashboardWidgetPassthroughSchemaElem() *schema.Resource {
+	s := dashboardWidgetSchemaBase()
+
+	delete(s, "nrql_query") // Queries are part of the configuration
+
+	s["visualization_id"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Required:     true,
+		ValidateFunc: validation.StringNotInSlice(dashboardWidgetVisualizationIDs, false),
+		Description:  "The visualization ID of the widget.",
+	}
+
+	s["configuration"] = &schema.Schema{
+		Type:             schema.TypeString,
+		Required:         true,
+		ValidateFunc:     validation.StringIsJSON,
+		Description:      "The raw JSON configuration of the widget.",
+		DiffSuppressFunc: structure.SuppressJsonDiff,
+	}
+
 	return &schema.Resource{
 		Schema: s,
 	}
 }
 
+// The null values handling options of a chart.
+var dashboardWidgetNullValueOptions = []string{"default", "preserve", "remove", "zero"}
+
+// The units a chart can display its values in.
+var dashboardWidgetUnitOptions = []string{
+	"BITS",
+	"BITS_PER_SECOND",
+	"BYTES",
+	"BYTES_PER_SECOND",
+	"CELSIUS",
+	"COUNT",
+	"HERTZ",
+	"MESSAGES_PER_SECOND",
+	"MS",
+	"OPERATIONS_PER_SECOND",
+	"PAGES_PER_SECOND",
+	"PERCENTAGE",
+	"REQUESTS_PER_SECOND",
+	"SECONDS",
+	"TIMESTAMP",
+}
+
+func dashboardWidgetYAxisLeftSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "The bounds of the left Y axis.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"min": {
+					Type:        schema.TypeFloat,
+					Optional:    true,
+					Default:     0,
+					Description: "The minimum value of the axis.",
+				},
+				"max": {
+					Type:        schema.TypeFloat,
+					Required:    true,
+					Description: "The maximum value of the axis.",
+				},
+			},
+		},
+	}
+}
+
+func dashboardWidgetNullValuesSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "How null values are displayed.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"null_value": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					ValidateFunc: validation.StringInSlice(dashboardWidgetNullValueOptions, false),
+					Description:  "How null values are displayed for every series. Valid values are default, preserve, remove, zero.",
+				},
+				"series_override": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					Description: "How null values are displayed for a series.",
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"series_name": {
+								Type:        schema.TypeString,
+								Required:    true,
+								Description: "The name of the series.",
+							},
+							"null_value": {
+								Type:         schema.TypeString,
+								Required:     true,
+								ValidateFunc: validation.StringInSlice(dashboardWidgetNullValueOptions, false),
+								Description:  "How null values are displayed for the series. Valid values are default, preserve, remove, zero.",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dashboardWidgetUnitsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "The units the values are displayed in.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"unit": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					ValidateFunc: validation.StringInSlice(dashboardWidgetUnitOptions, false),
+					Description:  "The unit of every series.",
+				},
+				"series_override": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					Description: "The unit of a series.",
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"series_name": {
+								Type:        schema.TypeString,
+								Required:    true,
+								Description: "The name of the series.",
+							},
+							"unit": {
+								Type:         schema.TypeString,
+								Required:     true,
+								ValidateFunc: validation.StringInSlice(dashboardWidgetUnitOptions, false),
+								Description:  "The unit of the series.",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dashboardWidgetColorsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "The colors of the series.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"color": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "The color of every series, as a hex color code.",
+				},
+				"series_override": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					Description: "The color of a series.",
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"series_name": {
+								Type:        schema.TypeString,
+								Required:    true,
+								Description: "The name of the series.",
+							},
+							"color": {
+								Type:        schema.TypeString,
+								Required:    true,
+								Description: "The color of the series, as a hex color code.",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dashboardWidgetLegendEnabledSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     true,
+		Description: "Whether the legend is displayed.",
+	}
+}
+
+func dashboardWidgetFacetShowOtherSeriesSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: "Whether the facets beyond the query's limit are grouped into an Other series.",
+	}
+}
+
+func dashboardWidgetFilterCurrentDashboardSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: "Whether clicking a facet filters the widget's dashboard page.",
+	}
+}
+
 func dashboardWidgetLinkedEntityGUIDsSchema() *schema.Schema {
 	return &schema.Schema{
 		Type: schema.TypeList,
@@ -384,7 +929,7 @@ func resourceNewRelicOneDashboardCreate(ctx context.Context, d *schema.ResourceD
 
 	log.Printf("[INFO] Creating New Relic One dashboard: %s", dashboard.Name)
 
-	created, err := client.Dashboards.DashboardCreateWithContext(ctx, accountID, *dashboard)
+	created, err := createDashboard(ctx, client, accountID, *dashboard)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -400,6 +945,14 @@ func resourceNewRelicOneDashboardCreate(ctx context.Context, d *schema.ResourceD
 
 	d.SetId(string(guid))
 
+	if _, err := updateDashboardNewPageFilters(ctx, providerConfig, created.EntityResult.Pages, d, defaultInfo); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := updateDefaultTags(ctx, providerConfig, guid, d); err != nil {
+		return diag.FromErr(err)
+	}
+
 	return resourceNewRelicOneDashboardRead(ctx, d, meta)
 }
 
@@ -425,7 +978,16 @@ func resourceNewRelicOneDashboardRead(ctx context.Context, d *schema.ResourceDat
 		return diag.FromErr(err)
 	}
 
-	return diag.FromErr(flattenDashboardEntity(dashboard, d))
+	variables, err := getDashboardVariables(ctx, client, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := flattenDashboardEntity(dashboard, variables, d); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.FromErr(readDefaultTags(ctx, providerConfig, common.EntityGUID(d.Id()), d))
 }
 
 func resourceNewRelicOneDashboardUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -448,14 +1010,26 @@ func resourceNewRelicOneDashboardUpdate(ctx context.Context, d *schema.ResourceD
 
 	log.Printf("[INFO] Updating New Relic One dashboard '%s' (%s)", dashboard.Name, d.Id())
 
-	result, err := client.Dashboards.DashboardUpdateWithContext(ctx, *dashboard, common.EntityGUID(d.Id()))
+	result, err := updateDashboard(ctx, client, d.Id(), *dashboard)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
+	filtered, err := updateDashboardNewPageFilters(ctx, providerConfig, result.EntityResult.Pages, d, defaultInfo)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if filtered != nil {
+		result = filtered
+	}
+
+	if err := updateDefaultTags(ctx, providerConfig, common.EntityGUID(d.Id()), d); err != nil {
+		return diag.FromErr(err)
+	}
+
 	// We have to use the Update Result, not a re-read of the entity as the changes take
 	// some amount of time to be re-indexed
-	return diag.FromErr(flattenDashboardUpdateResult(result, d))
+	return diag.FromErr(flattenDashboardUpdateResult(result, dashboard.Variables, d))
 }
 
 func resourceNewRelicOneDashboardDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -472,3 +1046,59 @@ func resourceNewRelicOneDashboardDelete(ctx context.Context, d *schema.ResourceD
 
 	return nil
 }
+
+// updateDashboardNewPageFilters links the widgets filtering the current
+// dashboard to their page when it has just been created, as its GUID was not
+// known before. The result is nil when there was nothing to link.
+func updateDashboardNewPageFilters(ctx context.Context, providerConfig *ProviderConfig, pages []entities.DashboardPage, d *schema.ResourceData, defaultInfo map[string]interface{}) (*dashboards.DashboardUpdateResult, error) {
+	configured := d.Get("page").([]interface{})
+
+	pending := false
+	for i, v := range configured {
+		p := v.(map[string]interface{})
+		if p["guid"].(string) != "" || i >= len(pages) {
+			continue
+		}
+
+		if dashboardPageFiltersCurrentDashboard(p) {
+			pending = true
+		}
+		p["guid"] = string(pages[i].GUID)
+	}
+
+	if !pending {
+		return nil, nil
+	}
+
+	if err := d.Set("page", configured); err != nil {
+		return nil, err
+	}
+
+	dashboard, err := expandDashboardInput(d, defaultInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("[INFO] Linking the widgets of New Relic One dashboard %s to their new pages", d.Id())
+
+	return updateDashboard(ctx, providerConfig.NewClient, d.Id(), *dashboard)
+}
+
+// dashboardPageFiltersCurrentDashboard returns whether a widget of a page
+// filters the current dashboard.
+func dashboardPageFiltersCurrentDashboard(page map[string]interface{}) bool {
+	for k, v := range page {
+		widgets, ok := v.([]interface{})
+		if !ok || !strings.HasPrefix(k, "widget_") {
+			continue
+		}
+
+		for _, w := range widgets {
+			if filter, ok := w.(map[string]interface{})["filter_current_dashboard"]; ok && filter.(bool) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
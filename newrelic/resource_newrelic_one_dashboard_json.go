@@ -0,0 +1,227 @@
+package newrelic
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/newrelic/newrelic-client-go/pkg/common"
+	"github.com/newrelic/newrelic-client-go/pkg/errors"
+)
+
+func resourceNewRelicOneDashboardJSON() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceNewRelicOneDashboardJSONCreate,
+		ReadContext:   resourceNewRelicOneDashboardJSONRead,
+		UpdateContext: resourceNewRelicOneDashboardJSONUpdate,
+		DeleteContext: resourceNewRelicOneDashboardJSONDelete,
+		CustomizeDiff: customizeDiffDefaultTags,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			// Required
+			"json": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: suppressDashboardJSONDiff,
+				Description:      "The dashboard in the JSON format exported by New Relic.",
+			},
+			// Optional
+			"account_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "The New Relic account ID where you want to create the dashboard.",
+			},
+			// Computed
+			"guid": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The unique entity identifier of the dashboard in New Relic.",
+			},
+			"permalink": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The URL of the dashboard.",
+			},
+			"default_tags": defaultTagsSchema(),
+		},
+	}
+}
+
+// suppressDashboardJSONDiff ignores the differences between two exports of the
+// same dashboard, like the order of their keys or their null values.
+func suppressDashboardJSONDiff(k, old, new string, d *schema.ResourceData) bool {
+	accountID := d.Get("account_id").(int)
+
+	dashboard, err := expandDashboardJSONInput(old, accountID)
+	if err != nil {
+		return false
+	}
+
+	normalized, err := normalizeDashboardJSON(*dashboard)
+	if err != nil {
+		return false
+	}
+
+	return dashboardJSONEquals(new, accountID, normalized)
+}
+
+func resourceNewRelicOneDashboardJSONCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+
+	if !providerConfig.hasNerdGraphCredentials() {
+		return diag.Errorf("err: NerdGraph support not present, but required for Create")
+	}
+
+	client := providerConfig.NewClient
+	accountID := selectAccountID(providerConfig, d)
+
+	dashboard, err := expandDashboardJSONInput(d.Get("json").(string), accountID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Creating New Relic One dashboard: %s", dashboard.Name)
+
+	created, err := createDashboard(ctx, client, accountID, expandDashboardJSONMutationInput(*dashboard, nil))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	guid := created.EntityResult.GUID
+	if guid == "" {
+		var errMessages string
+		for _, e := range created.Errors {
+			errMessages += "[" + string(e.Type) + ": " + e.Description + "]"
+		}
+
+		return diag.Errorf("err: newrelic_one_dashboard_json Create failed: %s", errMessages)
+	}
+
+	d.SetId(string(guid))
+
+	if err := updateDefaultTags(ctx, providerConfig, guid, d); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceNewRelicOneDashboardJSONRead(ctx, d, meta)
+}
+
+// resourceNewRelicOneDashboardJSONRead NerdGraph => Terraform reader
+func resourceNewRelicOneDashboardJSONRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+
+	if !providerConfig.hasNerdGraphCredentials() {
+		return diag.Errorf("err: NerdGraph support not present, but required for Read")
+	}
+
+	client := providerConfig.NewClient
+
+	log.Printf("[INFO] Reading New Relic One dashboard %s", d.Id())
+
+	dashboard, err := client.Dashboards.GetDashboardEntityWithContext(ctx, common.EntityGUID(d.Id()))
+	if err != nil {
+		if _, ok := err.(*errors.NotFound); ok {
+			d.SetId("")
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	variables, err := getDashboardVariables(ctx, client, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_ = d.Set("account_id", dashboard.AccountID)
+	_ = d.Set("guid", dashboard.GUID)
+	_ = d.Set("permalink", dashboard.Permalink)
+
+	remote, err := normalizeDashboardJSON(flattenDashboardJSON(dashboard, variables))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Keep the JSON as written unless the dashboard changed outside of Terraform
+	if !dashboardJSONEquals(d.Get("json").(string), dashboard.AccountID, remote) {
+		_ = d.Set("json", remote)
+	}
+
+	return diag.FromErr(readDefaultTags(ctx, providerConfig, common.EntityGUID(d.Id()), d))
+}
+
+// dashboardJSONEquals returns whether a dashboard JSON export is the same as a
+// normalized one.
+func dashboardJSONEquals(raw string, accountID int, normalized string) bool {
+	dashboard, err := expandDashboardJSONInput(raw, accountID)
+	if err != nil {
+		return false
+	}
+
+	out, err := normalizeDashboardJSON(*dashboard)
+	if err != nil {
+		return false
+	}
+
+	return out == normalized
+}
+
+func resourceNewRelicOneDashboardJSONUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+
+	if !providerConfig.hasNerdGraphCredentials() {
+		return diag.Errorf("err: NerdGraph support not present, but required for Update")
+	}
+
+	client := providerConfig.NewClient
+	accountID := selectAccountID(providerConfig, d)
+
+	dashboard, err := expandDashboardJSONInput(d.Get("json").(string), accountID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The pages of the JSON export have no GUID, the current ones are reused
+	current, err := client.Dashboards.GetDashboardEntityWithContext(ctx, common.EntityGUID(d.Id()))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Updating New Relic One dashboard '%s' (%s)", dashboard.Name, d.Id())
+
+	result, err := updateDashboard(ctx, client, d.Id(), expandDashboardJSONMutationInput(*dashboard, current.Pages))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := updateDefaultTags(ctx, providerConfig, common.EntityGUID(d.Id()), d); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// We have to use the Update Result, not a re-read of the entity as the changes take
+	// some amount of time to be re-indexed
+	_ = d.Set("account_id", result.EntityResult.AccountID)
+	_ = d.Set("guid", result.EntityResult.GUID)
+
+	return nil
+}
+
+func resourceNewRelicOneDashboardJSONDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*ProviderConfig).NewClient
+
+	log.Printf("[INFO] Deleting New Relic One dashboard %v", d.Id())
+
+	if _, err := client.Dashboards.DashboardDeleteWithContext(ctx, common.EntityGUID(d.Id())); err != nil {
+		if _, ok := err.(*errors.NotFound); ok {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+//go:build integration
+// +build integration
+
+package newrelic
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// TestAccNewRelicOneDashboardJSON_CreateOnePage Ensure that we can create a NR1 Dashboard from its JSON export
+func TestAccNewRelicOneDashboardJSON_CreateOnePage(t *testing.T) {
+	rName := fmt.Sprintf("tf-test-%s", testAccRandString(t, 5))
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNewRelicOneDashboardDestroy,
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: testAccCheckNewRelicOneDashboardJSONConfig(rName, strconv.Itoa(testAccountID), "# Notes"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicOneDashboardExists("newrelic_one_dashboard_json.bar", 0),
+				),
+			},
+			// Test: Update
+			{
+				Config: testAccCheckNewRelicOneDashboardJSONConfig(rName, strconv.Itoa(testAccountID), "# Release notes"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicOneDashboardExists("newrelic_one_dashboard_json.bar", 5),
+				),
+			},
+			// Import
+			{
+				ResourceName:      "newrelic_one_dashboard_json.bar",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// The imported JSON is normalized
+				ImportStateVerifyIgnore: []string{"json"},
+			},
+		},
+	})
+}
+
+// testAccCheckNewRelicOneDashboardJSONConfig contains a one page dashboard in the JSON export format
+func testAccCheckNewRelicOneDashboardJSONConfig(dashboardName string, accountID string, text string) string {
+	return `
+resource "newrelic_one_dashboard_json" "bar" {
+  json = <<EOT
+{
+  "name": "` + dashboardName + `",
+  "description": null,
+  "permissions": "PRIVATE",
+  "pages": [
+    {
+      "name": "` + dashboardName + `",
+      "description": null,
+      "widgets": [
+        {
+          "title": "Transactions",
+          "layout": {"column": 1, "row": 1, "width": 4, "height": 3},
+          "linkedEntityGuids": null,
+          "visualization": {"id": "viz.line"},
+          "rawConfiguration": {
+            "legend": {"enabled": true},
+            "nrqlQueries": [
+              {
+                "accountId": ` + accountID + `,
+                "query": "FROM Transaction SELECT count(*) TIMESERIES"
+              }
+            ]
+          }
+        },
+        {
+          "title": "Notes",
+          "layout": {"column": 5, "row": 1, "width": 4, "height": 3},
+          "linkedEntityGuids": null,
+          "visualization": {"id": "viz.markdown"},
+          "rawConfiguration": {"text": "` + text + `"}
+        }
+      ]
+    }
+  ]
+}
+EOT
+}`
+}
@@ -0,0 +1,194 @@
+//go:build unit
+// +build unit
+
+package newrelic
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testOneDashboardJSON is a dashboard as exported by New Relic, without the
+// account of its queries.
+const testOneDashboardJSON = `{
+  "name": "tf-test",
+  "description": null,
+  "permissions": "PUBLIC_READ_WRITE",
+  "pages": [
+    {
+      "name": "tf-test",
+      "description": null,
+      "widgets": [
+        {
+          "title": "Transactions",
+          "layout": {"column": 1, "row": 1, "width": 4, "height": 3},
+          "linkedEntityGuids": null,
+          "visualization": {"id": "viz.line"},
+          "rawConfiguration": {
+            "facet": {"showOtherSeries": false},
+            "legend": {"enabled": true},
+            "nrqlQueries": [{"query": "FROM Transaction SELECT count(*) WHERE appName IN ({{apps}}) TIMESERIES"}]
+          }
+        },
+        {
+          "title": "Notes",
+          "layout": {"column": 5, "row": 1, "width": 4, "height": 3},
+          "linkedEntityGuids": null,
+          "visualization": {"id": "viz.markdown"},
+          "rawConfiguration": {"text": "# Notes"}
+        }
+      ]
+    }
+  ],
+  "variables": [
+    {
+      "name": "apps",
+      "items": null,
+      "defaultValues": [],
+      "nrqlQuery": {"accountIds": [], "query": "FROM Transaction SELECT uniques(appName)"},
+      "title": "Applications",
+      "type": "NRQL",
+      "isMultiSelection": true
+    }
+  ]
+}`
+
+func TestResourceNewRelicOneDashboardJSON_FakeBackend(t *testing.T) {
+	f := newFakeBackend(t)
+	meta := testFakeBackendMeta(t, f)
+	r := resourceNewRelicOneDashboardJSON()
+
+	config := map[string]interface{}{"json": testOneDashboardJSON}
+
+	state := testFakeBackendApply(t, meta, r, nil, config)
+	require.Len(t, f.dashboards, 1)
+
+	dashboard := f.dashboards[state.ID]
+	assert.Equal(t, "PUBLIC_READ_WRITE", dashboard["permissions"])
+	assert.Equal(t, "DEFAULT", dashboard["variables"].([]interface{})[0].(map[string]interface{})["replacementStrategy"])
+
+	page := dashboard["pages"].([]interface{})[0].(map[string]interface{})
+	widgets := page["widgets"].([]interface{})
+	require.Len(t, widgets, 2)
+
+	line := widgets[0].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"id": "viz.line"}, line["visualization"])
+	assert.Equal(t, float64(fakeBackendAccountID), line["rawConfiguration"].(map[string]interface{})["nrqlQueries"].([]interface{})[0].(map[string]interface{})["accountId"])
+
+	markdown := widgets[1].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"id": "viz.markdown"}, markdown["visualization"])
+	assert.Equal(t, "# Notes", markdown["configuration"].(map[string]interface{})["markdown"].(map[string]interface{})["text"])
+
+	// The JSON is kept as written when nothing changed.
+	assert.Equal(t, testOneDashboardJSON, state.Attributes["json"])
+	assert.Equal(t, "1", state.Attributes["account_id"])
+	assert.NotEmpty(t, state.Attributes["permalink"])
+
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), meta)
+	require.NoError(t, err)
+	assert.Nil(t, diff)
+
+	// Reformatting the JSON or setting the defaults doesn't change the plan.
+	reformatted := strings.Replace(testOneDashboardJSON, `"permissions": "PUBLIC_READ_WRITE",`, `"permissions": "PUBLIC_READ_WRITE", "variables": [], `, 1)
+	reformatted = strings.Replace(reformatted, `"variables": [
+    {`, `"variables": [
+    {"replacementStrategy": "DEFAULT",`, 1)
+	diff, err = r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(map[string]interface{}{"json": reformatted}), meta)
+	require.NoError(t, err)
+	assert.Nil(t, diff)
+
+	// Updating keeps the pages of the dashboard.
+	config = map[string]interface{}{"json": strings.Replace(testOneDashboardJSON, "# Notes", "# Release notes", 1)}
+
+	state = testFakeBackendApply(t, meta, r, state, config)
+	updated := f.dashboards[state.ID]["pages"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, page["guid"], updated["guid"])
+	assert.Equal(t, "# Release notes", updated["widgets"].([]interface{})[1].(map[string]interface{})["configuration"].(map[string]interface{})["markdown"].(map[string]interface{})["text"])
+
+	refreshed, diags := r.RefreshWithoutUpgrade(context.Background(), state, meta)
+	require.False(t, diags.HasError(), "%+v", diags)
+	assert.Equal(t, config["json"], refreshed.Attributes["json"])
+
+	// Changes made outside of Terraform show up in the plan.
+	f.dashboards[state.ID]["name"] = "tf-test-renamed"
+
+	refreshed, diags = r.RefreshWithoutUpgrade(context.Background(), state, meta)
+	require.False(t, diags.HasError(), "%+v", diags)
+	assert.Contains(t, refreshed.Attributes["json"], `"name":"tf-test-renamed"`)
+
+	diff, err = r.Diff(context.Background(), refreshed, terraform.NewResourceConfigRaw(config), meta)
+	require.NoError(t, err)
+	require.NotNil(t, diff)
+	assert.Contains(t, diff.Attributes, "json")
+
+	// Importing reads the dashboard in the JSON export format.
+	imported, diags := r.RefreshWithoutUpgrade(context.Background(), &terraform.InstanceState{ID: state.ID}, meta)
+	require.False(t, diags.HasError(), "%+v", diags)
+	assert.Equal(t, refreshed.Attributes["json"], imported.Attributes["json"])
+
+	testFakeBackendApply(t, meta, r, state, nil)
+	assert.Empty(t, f.dashboards)
+}
+
+func TestResourceNewRelicOneDashboardJSON_WidgetDefaults_FakeBackend(t *testing.T) {
+	f := newFakeBackend(t)
+	f.dashboardWidgetDefaults = map[string]interface{}{
+		"legend":          map[string]interface{}{"enabled": true},
+		"platformOptions": map[string]interface{}{"ignoreTimeRange": false},
+		"yAxisLeft":       map[string]interface{}{"zero": true},
+	}
+	meta := testFakeBackendMeta(t, f)
+	r := resourceNewRelicOneDashboardJSON()
+
+	withoutLegend := strings.Replace(testOneDashboardJSON, `"legend": {"enabled": true},`, "", 1)
+	config := map[string]interface{}{"json": withoutLegend}
+
+	state := testFakeBackendApply(t, meta, r, nil, config)
+
+	widgets := f.dashboards[state.ID]["pages"].([]interface{})[0].(map[string]interface{})["widgets"].([]interface{})
+	cfg := widgets[0].(map[string]interface{})["rawConfiguration"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"enabled": true}, cfg["legend"])
+	assert.Equal(t, map[string]interface{}{"ignoreTimeRange": false}, cfg["platformOptions"])
+
+	// The settings filled in by the API don't change the plan.
+	refreshed, diags := r.RefreshWithoutUpgrade(context.Background(), state, meta)
+	require.False(t, diags.HasError(), "%+v", diags)
+	assert.Equal(t, withoutLegend, refreshed.Attributes["json"])
+
+	diff, err := r.Diff(context.Background(), refreshed, terraform.NewResourceConfigRaw(config), meta)
+	require.NoError(t, err)
+	assert.Nil(t, diff)
+
+	imported, diags := r.RefreshWithoutUpgrade(context.Background(), &terraform.InstanceState{ID: state.ID}, meta)
+	require.False(t, diags.HasError(), "%+v", diags)
+	assert.NotContains(t, imported.Attributes["json"], "platformOptions")
+	assert.NotContains(t, imported.Attributes["json"], "legend")
+
+	diff, err = r.Diff(context.Background(), imported, terraform.NewResourceConfigRaw(config), meta)
+	require.NoError(t, err)
+	assert.Nil(t, diff)
+
+	// Turning off a setting which is on by default does.
+	config = map[string]interface{}{"json": strings.Replace(testOneDashboardJSON, `"legend": {"enabled": true}`, `"legend": {"enabled": false}`, 1)}
+
+	diff, err = r.Diff(context.Background(), refreshed, terraform.NewResourceConfigRaw(config), meta)
+	require.NoError(t, err)
+	require.NotNil(t, diff)
+	assert.Contains(t, diff.Attributes, "json")
+
+	state = testFakeBackendApply(t, meta, r, refreshed, config)
+	cfg = f.dashboards[state.ID]["pages"].([]interface{})[0].(map[string]interface{})["widgets"].([]interface{})[0].(map[string]interface{})["rawConfiguration"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"enabled": false}, cfg["legend"])
+
+	refreshed, diags = r.RefreshWithoutUpgrade(context.Background(), state, meta)
+	require.False(t, diags.HasError(), "%+v", diags)
+
+	diff, err = r.Diff(context.Background(), refreshed, terraform.NewResourceConfigRaw(config), meta)
+	require.NoError(t, err)
+	assert.Nil(t, diff)
+}
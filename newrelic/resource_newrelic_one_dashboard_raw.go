@@ -5,6 +5,7 @@ import (
 	"log"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -18,6 +19,10 @@ func resourceNewRelicOneDashboardRaw() *schema.Resource {
 		ReadContext:   resourceNewRelicOneDashboardRawRead,
 		UpdateContext: resourceNewRelicOneDashboardRawUpdate,
 		DeleteContext: resourceNewRelicOneDashboardRawDelete,
+		CustomizeDiff: customdiff.All(
+			customizeDiffDefaultTags,
+			validateDashboardLayoutDiff,
+		),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -64,6 +69,7 @@ func resourceNewRelicOneDashboardRaw() *schema.Resource {
 				Computed:    true,
 				Description: "The URL of the dashboard.",
 			},
+			"default_tags": defaultTagsSchema(),
 		},
 	}
 }
@@ -137,8 +143,9 @@ func dashboardRawWidgetSchemaBase() map[string]*schema.Schema {
 			Description: "A title for the widget.",
 		},
 		"column": {
-			Type:     schema.TypeInt,
-			Required: true,
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(1),
 		},
 		"height": {
 			Type:         schema.TypeInt,
@@ -147,8 +154,9 @@ func dashboardRawWidgetSchemaBase() map[string]*schema.Schema {
 			ValidateFunc: validation.IntAtLeast(1),
 		},
 		"row": {
-			Type:     schema.TypeInt,
-			Required: true,
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(1),
 		},
 		"width": {
 			Type:         schema.TypeInt,
@@ -195,6 +203,10 @@ func resourceNewRelicOneDashboardRawCreate(ctx context.Context, d *schema.Resour
 
 	d.SetId(string(guid))
 
+	if err := updateDefaultTags(ctx, providerConfig, guid, d); err != nil {
+		return diag.FromErr(err)
+	}
+
 	return resourceNewRelicOneDashboardRawRead(ctx, d, meta)
 }
 
@@ -220,7 +232,11 @@ func resourceNewRelicOneDashboardRawRead(ctx context.Context, d *schema.Resource
 		return diag.FromErr(err)
 	}
 
-	return diag.FromErr(flattenDashboardRawEntity(dashboard, d))
+	if err := flattenDashboardRawEntity(dashboard, d); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.FromErr(readDefaultTags(ctx, providerConfig, common.EntityGUID(d.Id()), d))
 }
 
 func resourceNewRelicOneDashboardRawUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -248,6 +264,10 @@ func resourceNewRelicOneDashboardRawUpdate(ctx context.Context, d *schema.Resour
 		return diag.FromErr(err)
 	}
 
+	if err := updateDefaultTags(ctx, providerConfig, common.EntityGUID(d.Id()), d); err != nil {
+		return diag.FromErr(err)
+	}
+
 	// We have to use the Update Result, not a re-read of the entity as the changes take
 	// some amount of time to be re-indexed
 	return diag.FromErr(flattenDashboardRawUpdateResult(result, d))
@@ -8,7 +8,6 @@ import (
 	"strconv"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/newrelic/newrelic-client-go/pkg/common"
@@ -16,7 +15,7 @@ import (
 
 // TestAccNewRelicOneDashboardRaw_CreateOnePage Ensure that we can create a NR1 Dashboard
 func TestAccNewRelicOneDashboardRaw_CreateOnePage(t *testing.T) {
-	rName := fmt.Sprintf("tf-test-%s", acctest.RandString(5))
+	rName := fmt.Sprintf("tf-test-%s", testAccRandString(t, 5))
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
@@ -10,7 +10,6 @@ import (
 	"testing"
 	"time"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/newrelic/newrelic-client-go/pkg/common"
@@ -18,7 +17,7 @@ import (
 
 // TestAccNewRelicOneDashboard_CreateOnePage Ensure that we can create a NR1 Dashboard
 func TestAccNewRelicOneDashboard_CreateOnePage(t *testing.T) {
-	rName := fmt.Sprintf("tf-test-%s", acctest.RandString(5))
+	rName := fmt.Sprintf("tf-test-%s", testAccRandString(t, 5))
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
@@ -43,7 +42,7 @@ func TestAccNewRelicOneDashboard_CreateOnePage(t *testing.T) {
 
 // TestAccNewRelicOneDashboard_CreateTwoPages Ensure we can create a Two page NR1 Dashboard
 func TestAccNewRelicOneDashboard_CreateTwoPages(t *testing.T) {
-	rName := fmt.Sprintf("tf-test-%s", acctest.RandString(5))
+	rName := fmt.Sprintf("tf-test-%s", testAccRandString(t, 5))
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
@@ -69,7 +68,7 @@ func TestAccNewRelicOneDashboard_CreateTwoPages(t *testing.T) {
 
 // TestAccNewRelicOneDashboard_CrossAccountQueries Ensures we can have different account IDs for NRQL queries
 func TestAccNewRelicOneDashboard_CrossAccountQueries(t *testing.T) {
-	rName := fmt.Sprintf("tf-test-%s", acctest.RandString(5))
+	rName := fmt.Sprintf("tf-test-%s", testAccRandString(t, 5))
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
@@ -94,7 +93,7 @@ func TestAccNewRelicOneDashboard_CrossAccountQueries(t *testing.T) {
 
 // TestAccNewRelicOneDashboard_PageRename Ensure we can change the name of a NR1 Dashboard
 func TestAccNewRelicOneDashboard_PageRename(t *testing.T) {
-	rName := fmt.Sprintf("tf-test-%s", acctest.RandString(5))
+	rName := fmt.Sprintf("tf-test-%s", testAccRandString(t, 5))
 	rNameUpdated := fmt.Sprintf("%s-updated", rName)
 
 	resource.ParallelTest(t, resource.TestCase{
@@ -118,9 +117,37 @@ func TestAccNewRelicOneDashboard_PageRename(t *testing.T) {
 	})
 }
 
+// TestAccNewRelicOneDashboard_Variables checks that widgets can reference dashboard variables
+func TestAccNewRelicOneDashboard_Variables(t *testing.T) {
+	rName := fmt.Sprintf("tf-test-%s", testAccRandString(t, 5))
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNewRelicOneDashboardDestroy,
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: testAccCheckNewRelicOneDashboardConfig_Variables(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicOneDashboardExists("newrelic_one_dashboard.bar", 0),
+					resource.TestCheckResourceAttr("newrelic_one_dashboard.bar", "variable.#", "2"),
+				),
+			},
+			// Test: Remove the variables
+			{
+				Config: testAccCheckNewRelicOneDashboardConfig_OnePageFull(rName, strconv.Itoa(testAccountID)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicOneDashboardExists("newrelic_one_dashboard.bar", 5), // Sleep waiting for entity re-indexing
+					resource.TestCheckResourceAttr("newrelic_one_dashboard.bar", "variable.#", "0"),
+				),
+			},
+		},
+	})
+}
+
 // TestAccNewRelicOneDashboard_InvalidNRQL checks for proper response if a widget is not configured correctly
 func TestAccNewRelicOneDashboard_InvalidNRQL(t *testing.T) {
-	rName := fmt.Sprintf("tf-test-%s", acctest.RandString(5))
+	rName := fmt.Sprintf("tf-test-%s", testAccRandString(t, 5))
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
@@ -160,6 +187,53 @@ resource "newrelic_one_dashboard" "bar" {
 }`
 }
 
+// testAccCheckNewRelicOneDashboardConfig_Variables generates a TF config snippet for a
+// dashboard whose widget references its variables
+func testAccCheckNewRelicOneDashboardConfig_Variables(dashboardName string) string {
+	return `
+resource "newrelic_one_dashboard" "bar" {
+  name = "` + dashboardName + `"
+  permissions = "private"
+
+  variable {
+    name               = "apps"
+    title              = "Applications"
+    type               = "nrql"
+    default_values     = ["*"]
+    is_multi_selection = true
+
+    nrql_query {
+      query = "FROM Transaction SELECT uniques(appName)"
+    }
+  }
+
+  variable {
+    name                 = "transaction"
+    type                 = "enum"
+    replacement_strategy = "string"
+
+    item {
+      title = "Index"
+      value = "WebTransaction/Go/index"
+    }
+  }
+
+  page {
+    name = "` + dashboardName + `"
+
+    widget_line {
+      title = "line widget"
+      row = 1
+      column = 1
+
+      nrql_query {
+        query = "FROM Transaction SELECT count(*) WHERE appName IN ({{apps}}) AND name = {{transaction}} TIMESERIES"
+      }
+    }
+  }
+}`
+}
+
 // testAccCheckNewRelicOneDashboardConfig_PageSimple generates a basic dashboard page
 func testAccCheckNewRelicOneDashboardConfig_PageSimple(pageName string) string {
 	return `
@@ -268,6 +342,29 @@ func testAccCheckNewRelicOneDashboardConfig_PageFull(pageName string, accountID
       nrql_query {
         query      = "FROM Transaction SELECT 2 TIMESERIES"
       }
+
+      y_axis_left {
+        min = 0
+        max = 10
+      }
+
+      null_values {
+        null_value = "zero"
+      }
+
+      units {
+        unit = "COUNT"
+      }
+
+      colors {
+        series_override {
+          series_name = "2"
+          color       = "#ff0000"
+        }
+      }
+
+      legend_enabled          = false
+      facet_show_other_series = true
     }
 
     widget_markdown {
@@ -285,6 +382,7 @@ func testAccCheckNewRelicOneDashboardConfig_PageFull(pageName string, accountID
         query      = "FROM Transaction SELECT count(*) FACET name"
       }
       linked_entity_guids = ["MjUyMDUyOHxWSVp8REFTSEJPQVJEfDE2NDYzMDQ"]
+      filter_current_dashboard = true
     }
 
     widget_table {
@@ -300,11 +398,54 @@ func testAccCheckNewRelicOneDashboardConfig_PageFull(pageName string, accountID
     widget_json {
       title = "JSON widget"
       row = 13
-      column = 1
+      column = 5
       nrql_query {
         query      = "FROM Transaction SELECT average(duration) FACET appName"
       }
     }
+
+    widget_stacked_bar {
+      title = "stacked bar widget"
+      row = 16
+      column = 1
+
+      nrql_query {
+        query = "FROM Transaction SELECT count(*) FACET appName TIMESERIES"
+      }
+    }
+
+    widget_log_table {
+      title = "log table widget"
+      row = 16
+      column = 5
+
+      nrql_query {
+        query = "FROM Log SELECT *"
+      }
+    }
+
+    widget_event_feed {
+      title = "event feed widget"
+      row = 16
+      column = 9
+
+      nrql_query {
+        query = "FROM Transaction SELECT *"
+      }
+    }
+
+    widget_passthrough {
+      title = "traffic light widget"
+      row = 19
+      column = 1
+      visualization_id = "viz.traffic-light"
+      configuration = jsonencode({
+        nrqlQueries = [{
+          accountId = ` + accountID + `
+          query     = "FROM Transaction SELECT count(*)"
+        }]
+      })
+    }
   }
 `
 }
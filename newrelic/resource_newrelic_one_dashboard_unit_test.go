@@ -0,0 +1,536 @@
+//go:build unit
+// +build unit
+
+package newrelic
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testOneDashboardConfig(overrides map[string]interface{}) map[string]interface{} {
+	return testResourceConfig(map[string]interface{}{
+		"name": "tf-test",
+		"page": []interface{}{
+			map[string]interface{}{
+				"name": "tf-test",
+				"widget_line": []interface{}{
+					map[string]interface{}{
+						"title":  "Transactions",
+						"row":    1,
+						"column": 1,
+						"nrql_query": []interface{}{
+							map[string]interface{}{"query": "FROM Transaction SELECT count(*) WHERE appName IN ({{apps}}) TIMESERIES"},
+						},
+					},
+				},
+			},
+		},
+	}, overrides)
+}
+
+func TestResourceNewRelicOneDashboard_Variables_FakeBackend(t *testing.T) {
+	f := newFakeBackend(t)
+	meta := testFakeBackendMeta(t, f)
+	r := resourceNewRelicOneDashboard()
+
+	config := testOneDashboardConfig(map[string]interface{}{
+		"variable": []interface{}{
+			map[string]interface{}{
+				"name":               "apps",
+				"title":              "Applications",
+				"type":               "nrql",
+				"default_values":     []interface{}{"*"},
+				"is_multi_selection": true,
+				"nrql_query": []interface{}{
+					map[string]interface{}{"query": "FROM Transaction SELECT uniques(appName)"},
+				},
+			},
+			map[string]interface{}{
+				"name":                 "env",
+				"type":                 "enum",
+				"replacement_strategy": "string",
+				"item": []interface{}{
+					map[string]interface{}{"title": "Production", "value": "production"},
+					map[string]interface{}{"value": "staging"},
+				},
+			},
+		},
+	})
+
+	state := testFakeBackendApply(t, meta, r, nil, config)
+	require.Len(t, f.dashboards, 1)
+
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{
+			"name":                "apps",
+			"title":               "Applications",
+			"type":                "NRQL",
+			"defaultValues":       []interface{}{map[string]interface{}{"value": map[string]interface{}{"string": "*"}}},
+			"isMultiSelection":    true,
+			"nrqlQuery":           map[string]interface{}{"accountIds": []interface{}{float64(fakeBackendAccountID)}, "query": "FROM Transaction SELECT uniques(appName)"},
+			"replacementStrategy": "DEFAULT",
+		},
+		map[string]interface{}{
+			"name":             "env",
+			"type":             "ENUM",
+			"isMultiSelection": false,
+			"items": []interface{}{
+				map[string]interface{}{"title": "Production", "value": "production"},
+				map[string]interface{}{"value": "staging"},
+			},
+			"replacementStrategy": "STRING",
+		},
+	}, f.dashboards[state.ID]["variables"])
+
+	assert.Equal(t, "2", state.Attributes["variable.#"])
+	assert.Equal(t, "nrql", state.Attributes["variable.0.type"])
+	assert.Equal(t, "1", state.Attributes["variable.0.nrql_query.0.account_ids.0"])
+	assert.Equal(t, "string", state.Attributes["variable.1.replacement_strategy"])
+
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), meta)
+	require.NoError(t, err)
+	assert.Nil(t, diff)
+
+	// Removing the variables clears them from the dashboard.
+	config = testOneDashboardConfig(nil)
+
+	state = testFakeBackendApply(t, meta, r, state, config)
+	assert.Equal(t, []interface{}{}, f.dashboards[state.ID]["variables"])
+	assert.Equal(t, "0", state.Attributes["variable.#"])
+
+	refreshed, diags := r.RefreshWithoutUpgrade(context.Background(), state, meta)
+	require.False(t, diags.HasError(), "%+v", diags)
+	assert.Equal(t, "0", refreshed.Attributes["variable.#"])
+
+	testFakeBackendApply(t, meta, r, state, nil)
+	assert.Empty(t, f.dashboards)
+}
+
+func TestResourceNewRelicOneDashboard_RawWidgets_FakeBackend(t *testing.T) {
+	f := newFakeBackend(t)
+	meta := testFakeBackendMeta(t, f)
+	r := resourceNewRelicOneDashboard()
+
+	widget := func(title string, column int) map[string]interface{} {
+		return map[string]interface{}{
+			"title":  title,
+			"row":    1,
+			"column": column,
+			"nrql_query": []interface{}{
+				map[string]interface{}{"query": "FROM Log SELECT *"},
+			},
+		}
+	}
+
+	config := testOneDashboardConfig(map[string]interface{}{
+		"page": []interface{}{
+			map[string]interface{}{
+				"name":               "tf-test",
+				"widget_stacked_bar": []interface{}{widget("Stacked bar", 1)},
+				"widget_log_table":   []interface{}{widget("Log table", 5)},
+				"widget_event_feed":  []interface{}{widget("Event feed", 9)},
+				"widget_passthrough": []interface{}{
+					map[string]interface{}{
+						"title":            "Traffic light",
+						"row":              7,
+						"column":           1,
+						"visualization_id": "viz.traffic-light",
+						"configuration":    `{"nrqlQueries": [{"accountId": 1, "query": "FROM Transaction SELECT count(*)"}]}`,
+					},
+				},
+			},
+		},
+	})
+
+	state := testFakeBackendApply(t, meta, r, nil, config)
+	require.Len(t, f.dashboards, 1)
+
+	var visualizations []interface{}
+	for _, w := range f.dashboards[state.ID]["pages"].([]interface{})[0].(map[string]interface{})["widgets"].([]interface{}) {
+		visualizations = append(visualizations, w.(map[string]interface{})["visualization"].(map[string]interface{})["id"])
+	}
+	assert.ElementsMatch(t, []interface{}{"viz.stacked-bar", "logger.log-table-widget", "viz.event-feed", "viz.traffic-light"}, visualizations)
+
+	assert.Equal(t, "FROM Log SELECT *", state.Attributes["page.0.widget_log_table.0.nrql_query.0.query"])
+	assert.Equal(t, "viz.traffic-light", state.Attributes["page.0.widget_passthrough.0.visualization_id"])
+
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), meta)
+	require.NoError(t, err)
+	assert.Nil(t, diff)
+
+	// A widget of an unknown visualization added outside of Terraform is kept.
+	page := f.dashboards[state.ID]["pages"].([]interface{})[0].(map[string]interface{})
+	page["widgets"] = append(page["widgets"].([]interface{}), map[string]interface{}{
+		"id":               "1",
+		"title":            "Custom",
+		"layout":           map[string]interface{}{"row": 4, "column": 1, "width": 4, "height": 3},
+		"visualization":    map[string]interface{}{"id": "abc123.custom-viz"},
+		"rawConfiguration": map[string]interface{}{"text": "custom"},
+	})
+
+	refreshed, diags := r.RefreshWithoutUpgrade(context.Background(), state, meta)
+	require.False(t, diags.HasError(), "%+v", diags)
+	assert.Equal(t, "2", refreshed.Attributes["page.0.widget_passthrough.#"])
+	assert.Equal(t, "abc123.custom-viz", refreshed.Attributes["page.0.widget_passthrough.1.visualization_id"])
+	assert.JSONEq(t, `{"text": "custom"}`, refreshed.Attributes["page.0.widget_passthrough.1.configuration"])
+
+	testFakeBackendApply(t, meta, r, state, nil)
+	assert.Empty(t, f.dashboards)
+}
+
+func TestResourceNewRelicOneDashboard_PassthroughVisualizationID(t *testing.T) {
+	r := resourceNewRelicOneDashboard()
+
+	config := testOneDashboardConfig(map[string]interface{}{
+		"page": []interface{}{
+			map[string]interface{}{
+				"name": "tf-test",
+				"widget_passthrough": []interface{}{
+					map[string]interface{}{
+						"title":            "Line",
+						"row":              1,
+						"column":           1,
+						"visualization_id": "viz.line",
+						"configuration":    `{}`,
+					},
+				},
+			},
+		},
+	})
+
+	diags := r.Validate(terraform.NewResourceConfigRaw(config))
+	require.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, "viz.line")
+}
+
+func TestResourceNewRelicOneDashboard_WidgetDisplaySettings_FakeBackend(t *testing.T) {
+	f := newFakeBackend(t)
+	meta := testFakeBackendMeta(t, f)
+	r := resourceNewRelicOneDashboard()
+
+	config := testOneDashboardConfig(map[string]interface{}{
+		"page": []interface{}{
+			map[string]interface{}{
+				"name": "tf-test",
+				"widget_line": []interface{}{
+					map[string]interface{}{
+						"title":  "Duration",
+						"row":    1,
+						"column": 1,
+						"nrql_query": []interface{}{
+							map[string]interface{}{"query": "FROM Transaction SELECT average(duration) FACET appName TIMESERIES"},
+						},
+						"y_axis_left": []interface{}{
+							map[string]interface{}{"max": 10.5},
+						},
+						"null_values": []interface{}{
+							map[string]interface{}{
+								"null_value": "zero",
+								"series_override": []interface{}{
+									map[string]interface{}{"series_name": "web", "null_value": "remove"},
+								},
+							},
+						},
+						"units": []interface{}{
+							map[string]interface{}{"unit": "SECONDS"},
+						},
+						"colors": []interface{}{
+							map[string]interface{}{
+								"series_override": []interface{}{
+									map[string]interface{}{"series_name": "web", "color": "#ff0000"},
+								},
+							},
+						},
+						"legend_enabled":           false,
+						"facet_show_other_series":  true,
+						"filter_current_dashboard": true,
+					},
+				},
+				"widget_billboard": []interface{}{
+					map[string]interface{}{
+						"title":    "Errors",
+						"row":      1,
+						"column":   5,
+						"critical": 5.0,
+						"nrql_query": []interface{}{
+							map[string]interface{}{"query": "FROM TransactionError SELECT count(*)"},
+						},
+					},
+				},
+			},
+		},
+	})
+
+	state := testFakeBackendApply(t, meta, r, nil, config)
+	require.Len(t, f.dashboards, 1)
+
+	page := f.dashboards[state.ID]["pages"].([]interface{})[0].(map[string]interface{})
+	var line map[string]interface{}
+	for _, w := range page["widgets"].([]interface{}) {
+		if w.(map[string]interface{})["visualization"].(map[string]interface{})["id"] == "viz.line" {
+			line = w.(map[string]interface{})
+		}
+	}
+	require.NotNil(t, line)
+	linked := line["linkedEntities"].([]interface{})
+	require.Len(t, linked, 1)
+	assert.Equal(t, page["guid"], linked[0].(map[string]interface{})["guid"])
+	assert.JSONEq(t, `{
+		"nrqlQueries": [{"accountId": 1, "query": "FROM Transaction SELECT average(duration) FACET appName TIMESERIES"}],
+		"yAxisLeft": {"min": 0, "max": 10.5},
+		"nullValues": {"nullValue": "zero", "seriesOverrides": [{"nullValue": "remove", "seriesName": "web"}]},
+		"units": {"unit": "SECONDS"},
+		"colors": {"seriesOverrides": [{"color": "#ff0000", "seriesName": "web"}]},
+		"legend": {"enabled": false},
+		"facet": {"showOtherSeries": true}
+	}`, testJSONString(t, line["rawConfiguration"]))
+
+	assert.Equal(t, "true", state.Attributes["page.0.widget_line.0.filter_current_dashboard"])
+	assert.Equal(t, "10.5", state.Attributes["page.0.widget_line.0.y_axis_left.0.max"])
+	assert.Equal(t, "remove", state.Attributes["page.0.widget_line.0.null_values.0.series_override.0.null_value"])
+	assert.Equal(t, "false", state.Attributes["page.0.widget_line.0.legend_enabled"])
+	assert.Equal(t, "5", state.Attributes["page.0.widget_billboard.0.critical"])
+
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), meta)
+	require.NoError(t, err)
+	assert.Nil(t, diff)
+
+	// The widgets of a page added by an update are linked to it as well.
+	config["page"] = append(config["page"].([]interface{}), map[string]interface{}{
+		"name": "tf-test-2",
+		"widget_bar": []interface{}{
+			map[string]interface{}{
+				"title":  "Throughput",
+				"row":    1,
+				"column": 1,
+				"nrql_query": []interface{}{
+					map[string]interface{}{"query": "FROM Transaction SELECT count(*) FACET appName"},
+				},
+				"linked_entity_guids":      []interface{}{"abc123"},
+				"filter_current_dashboard": true,
+			},
+		},
+	})
+
+	state = testFakeBackendApply(t, meta, r, state, config)
+	page = f.dashboards[state.ID]["pages"].([]interface{})[1].(map[string]interface{})
+	bar := page["widgets"].([]interface{})[0].(map[string]interface{})
+	var guids []interface{}
+	for _, e := range bar["linkedEntities"].([]interface{}) {
+		guids = append(guids, e.(map[string]interface{})["guid"])
+	}
+	assert.Equal(t, []interface{}{"abc123", page["guid"]}, guids)
+
+	refreshed, diags := r.RefreshWithoutUpgrade(context.Background(), state, meta)
+	require.False(t, diags.HasError(), "%+v", diags)
+	assert.Equal(t, "true", refreshed.Attributes["page.1.widget_bar.0.filter_current_dashboard"])
+	assert.Equal(t, "1", refreshed.Attributes["page.1.widget_bar.0.linked_entity_guids.#"])
+
+	testFakeBackendApply(t, meta, r, state, nil)
+	assert.Empty(t, f.dashboards)
+}
+
+func testJSONString(t *testing.T, v interface{}) string {
+	b, err := json.Marshal(v)
+	require.NoError(t, err)
+
+	return string(b)
+}
+
+func TestResourceNewRelicOneDashboard_LayoutValidation(t *testing.T) {
+	meta := testFakeBackendMeta(t, newFakeBackend(t))
+
+	widget := func(title string, row int, column int, width int) map[string]interface{} {
+		return map[string]interface{}{
+			"title":  title,
+			"row":    row,
+			"column": column,
+			"width":  width,
+			"nrql_query": []interface{}{
+				map[string]interface{}{"query": "FROM Transaction SELECT count(*)"},
+			},
+		}
+	}
+
+	tests := map[string]struct {
+		widgets []interface{}
+		err     string
+	}{
+		"fits": {
+			widgets: []interface{}{widget("Left", 1, 1, 6), widget("Right", 1, 7, 6), widget("Below", 4, 1, 12)},
+		},
+		"past the last column": {
+			widgets: []interface{}{widget("Wide", 1, 10, 4)},
+			err:     `widget "Wide" on page "tf-test" ends at column 13, past the 12 columns of the page`,
+		},
+		"overlap": {
+			widgets: []interface{}{widget("First", 1, 1, 6), widget("Second", 3, 6, 4)},
+			err:     `widgets "First" and "Second" on page "tf-test" overlap`,
+		},
+		"past the last column without a row": {
+			widgets: []interface{}{widget("Wide", 0, 11, 4)},
+			err:     `widget "Wide" on page "tf-test" ends at column 14, past the 12 columns of the page`,
+		},
+		"room left in the row": {
+			widgets: []interface{}{widget("Left", 1, 1, 8), widget("Right", 1, 0, 4)},
+		},
+		"no room left in the row": {
+			widgets: []interface{}{widget("Full", 1, 1, 12), widget("Squeezed", 1, 0, 4)},
+			err:     `no room left in row 1 for widget "Squeezed" on page "tf-test"`,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := testOneDashboardConfig(map[string]interface{}{
+				"page": []interface{}{
+					map[string]interface{}{
+						"name":        "tf-test",
+						"widget_line": tc.widgets,
+					},
+				},
+			})
+
+			_, err := resourceNewRelicOneDashboard().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), meta)
+			if tc.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.err)
+		})
+	}
+
+	// The raw dashboard is validated the same way.
+	config := map[string]interface{}{
+		"name": "tf-test",
+		"page": []interface{}{
+			map[string]interface{}{
+				"name": "tf-test",
+				"widget": []interface{}{
+					map[string]interface{}{"title": "First", "row": 1, "column": 1, "visualization_id": "viz.custom", "configuration": `{}`},
+					map[string]interface{}{"title": "Second", "row": 2, "column": 2, "visualization_id": "viz.custom", "configuration": `{}`},
+				},
+			},
+		},
+	}
+
+	_, err := resourceNewRelicOneDashboardRaw().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), meta)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `widgets "First" and "Second" on page "tf-test" overlap`)
+}
+
+func TestResourceNewRelicOneDashboard_FlowLayout_FakeBackend(t *testing.T) {
+	f := newFakeBackend(t)
+	meta := testFakeBackendMeta(t, f)
+	r := resourceNewRelicOneDashboard()
+
+	line := func(title string) map[string]interface{} {
+		return map[string]interface{}{
+			"title": title,
+			"nrql_query": []interface{}{
+				map[string]interface{}{"query": "FROM Transaction SELECT count(*) TIMESERIES"},
+			},
+		}
+	}
+
+	pinned := line("Pinned")
+	pinned["column"] = 9
+
+	config := testOneDashboardConfig(map[string]interface{}{
+		"page": []interface{}{
+			map[string]interface{}{
+				"name":        "tf-test",
+				"widget_line": []interface{}{line("First"), line("Second"), pinned, line("Third")},
+				"widget_markdown": []interface{}{
+					map[string]interface{}{"title": "Notes", "row": 1, "column": 1, "text": "# Notes"},
+				},
+			},
+		},
+	})
+
+	state := testFakeBackendApply(t, meta, r, nil, config)
+
+	// Widgets without a position flow around the placed ones, in order.
+	for title, position := range map[string][2]float64{"First": {1, 5}, "Second": {1, 9}, "Pinned": {4, 9}, "Third": {4, 1}} {
+		layout := testFakeDashboardWidgetLayout(t, f, state.ID, title)
+		assert.Equal(t, position[0], layout["row"], "row of widget %q", title)
+		assert.Equal(t, position[1], layout["column"], "column of widget %q", title)
+	}
+
+	// Only the positions of the configuration are kept in state.
+	assert.Equal(t, "0", state.Attributes["page.0.widget_line.0.row"])
+	assert.Equal(t, "0", state.Attributes["page.0.widget_line.0.column"])
+	assert.Equal(t, "0", state.Attributes["page.0.widget_line.2.row"])
+	assert.Equal(t, "9", state.Attributes["page.0.widget_line.2.column"])
+	assert.Equal(t, "1", state.Attributes["page.0.widget_markdown.0.row"])
+
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), meta)
+	require.NoError(t, err)
+	assert.Nil(t, diff)
+
+	state, diags := r.RefreshWithoutUpgrade(context.Background(), state, meta)
+	require.False(t, diags.HasError(), "%+v", diags)
+	assert.Equal(t, "0", state.Attributes["page.0.widget_line.0.row"])
+
+	testFakeBackendApply(t, meta, r, state, nil)
+	assert.Empty(t, f.dashboards)
+}
+
+func TestResourceNewRelicOneDashboard_FlowLayoutChanges_FakeBackend(t *testing.T) {
+	f := newFakeBackend(t)
+	meta := testFakeBackendMeta(t, f)
+	r := resourceNewRelicOneDashboard()
+
+	markdown := func(title string, width int) map[string]interface{} {
+		return map[string]interface{}{"title": title, "width": width, "text": "# " + title}
+	}
+
+	config := func(widgets ...interface{}) map[string]interface{} {
+		return testOneDashboardConfig(map[string]interface{}{
+			"page": []interface{}{
+				map[string]interface{}{"name": "tf-test", "widget_markdown": widgets},
+			},
+		})
+	}
+
+	state := testFakeBackendApply(t, meta, r, nil, config(markdown("First", 4), markdown("Second", 4)))
+	assert.Equal(t, float64(5), testFakeDashboardWidgetLayout(t, f, state.ID, "Second")["column"])
+
+	// Widening a placed widget moves the next ones instead of failing the plan.
+	state = testFakeBackendApply(t, meta, r, state, config(markdown("First", 6), markdown("Second", 4)))
+	assert.Equal(t, float64(1), testFakeDashboardWidgetLayout(t, f, state.ID, "First")["column"])
+	assert.Equal(t, float64(7), testFakeDashboardWidgetLayout(t, f, state.ID, "Second")["column"])
+
+	// Inserting a widget shifts the ones after it in the list.
+	state = testFakeBackendApply(t, meta, r, state, config(markdown("Zeroth", 4), markdown("First", 6), markdown("Second", 4)))
+	assert.Equal(t, float64(1), testFakeDashboardWidgetLayout(t, f, state.ID, "Zeroth")["column"])
+	assert.Equal(t, float64(5), testFakeDashboardWidgetLayout(t, f, state.ID, "First")["column"])
+	assert.Equal(t, float64(4), testFakeDashboardWidgetLayout(t, f, state.ID, "Second")["row"])
+
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config(markdown("Zeroth", 4), markdown("First", 6), markdown("Second", 4))), meta)
+	require.NoError(t, err)
+	assert.Nil(t, diff)
+}
+
+// testFakeDashboardWidgetLayout returns the layout of a widget of a dashboard
+// of the fake backend.
+func testFakeDashboardWidgetLayout(t *testing.T, f *fakeBackend, guid string, title string) map[string]interface{} {
+	for _, p := range f.dashboards[guid]["pages"].([]interface{}) {
+		for _, w := range p.(map[string]interface{})["widgets"].([]interface{}) {
+			widget := w.(map[string]interface{})
+			if widget["title"] == title {
+				return widget["layout"].(map[string]interface{})
+			}
+		}
+	}
+
+	require.Failf(t, "widget not found", "no widget %q on dashboard %s", title, guid)
+	return nil
+}
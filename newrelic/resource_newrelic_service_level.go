@@ -0,0 +1,319 @@
+package newrelic
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	nrErrors "github.com/newrelic/newrelic-client-go/pkg/errors"
+)
+
+// serviceLevelEventsQuerySchema is the schema of the valid, good and bad
+// events definitions of a service level.
+func serviceLevelEventsQuerySchema(required bool, description string) *schema.Schema {
+	s := &schema.Schema{
+		Type:        schema.TypeList,
+		MaxItems:    1,
+		Description: description,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"from": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.NoZeroValues,
+					Description:  "The event type or metric to fetch the events from, such as Transaction.",
+				},
+				"where": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "The NRQL condition filtering the events, such as duration < 0.5.",
+				},
+			},
+		},
+	}
+
+	if required {
+		s.Required = true
+	} else {
+		s.Optional = true
+		s.ExactlyOneOf = []string{"events.0.good_events", "events.0.bad_events"}
+	}
+
+	return s
+}
+
+func resourceNewRelicServiceLevel() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceNewRelicServiceLevelCreate,
+		ReadContext:   resourceNewRelicServiceLevelRead,
+		UpdateContext: resourceNewRelicServiceLevelUpdate,
+		DeleteContext: resourceNewRelicServiceLevelDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"guid": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The GUID of the entity the service level is attached to.",
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.NoZeroValues,
+				Description:  "The name of the service level.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The description of the service level.",
+			},
+			"events": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MaxItems:    1,
+				Description: "The events that define the service level indicator.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"account_id": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Computed:    true,
+							ForceNew:    true,
+							Description: "The New Relic account ID to fetch the events from.",
+						},
+						"valid_events": serviceLevelEventsQuerySchema(true, "The definition of the valid events."),
+						"good_events":  serviceLevelEventsQuerySchema(false, "The definition of the good events."),
+						"bad_events":   serviceLevelEventsQuerySchema(false, "The definition of the bad events."),
+					},
+				},
+			},
+			"objective": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "The objectives of the service level.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The name of the objective.",
+						},
+						"description": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The description of the objective.",
+						},
+						"target": {
+							Type:         schema.TypeFloat,
+							Required:     true,
+							ValidateFunc: validation.FloatBetween(0, 100),
+							Description:  "The target percentage of the objective.",
+						},
+						"time_window": {
+							Type:        schema.TypeList,
+							Required:    true,
+							MaxItems:    1,
+							Description: "The time window of the objective.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"rolling": {
+										Type:        schema.TypeList,
+										Required:    true,
+										MaxItems:    1,
+										Description: "The rolling time window of the objective.",
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"count": {
+													Type:         schema.TypeInt,
+													Required:     true,
+													ValidateFunc: validation.IntInSlice([]int{1, 7, 14, 28, 30}),
+													Description:  "The number of units of the time window, one of 1, 7, 14, 28 or 30.",
+												},
+												"unit": {
+													Type:         schema.TypeString,
+													Required:     true,
+													ValidateFunc: validation.StringInSlice([]string{"DAY"}, false),
+													Description:  "The unit of the time window, DAY.",
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+						"attainment_query": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The NRQL query measuring the attainment of the objective.",
+						},
+					},
+				},
+			},
+			"sli_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the service level indicator.",
+			},
+			"sli_guid": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The GUID of the service level indicator, the sli.guid attribute of its metrics.",
+			},
+			"valid_events_metric": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the metric counting the valid events.",
+			},
+			"good_events_metric": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the metric counting the good events.",
+			},
+			"bad_events_metric": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the metric counting the bad events.",
+			},
+			"indicator_query": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The NRQL query measuring the value of the service level indicator.",
+			},
+			"valid_events_query": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The NRQL query measuring the valid events.",
+			},
+			"good_events_query": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The NRQL query measuring the good events.",
+			},
+		},
+	}
+}
+
+func resourceNewRelicServiceLevelCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+
+	if !providerConfig.hasNerdGraphCredentials() {
+		return diag.Errorf("err: NerdGraph support not present, but required for Create")
+	}
+
+	client := providerConfig.NewClient
+	entityGUID := d.Get("guid").(string)
+	indicator := expandServiceLevelCreateInput(d, providerConfig.AccountID)
+
+	log.Printf("[INFO] Creating New Relic service level %s for entity %s", indicator["name"], entityGUID)
+
+	created, err := createServiceLevel(ctx, client, entityGUID, indicator)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(serviceLevelID(entityGUID, created.ID))
+
+	return resourceNewRelicServiceLevelRead(ctx, d, meta)
+}
+
+func resourceNewRelicServiceLevelRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+
+	if !providerConfig.hasNerdGraphCredentials() {
+		return diag.Errorf("err: NerdGraph support not present, but required for Read")
+	}
+
+	client := providerConfig.NewClient
+
+	entityGUID, sliID, err := parseServiceLevelID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Reading New Relic service level %s of entity %s", sliID, entityGUID)
+
+	indicator, err := getServiceLevel(ctx, client, entityGUID, sliID)
+	if err != nil {
+		if _, ok := err.(*nrErrors.NotFound); ok {
+			d.SetId("")
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	return diag.FromErr(flattenServiceLevel(indicator, entityGUID, d))
+}
+
+func resourceNewRelicServiceLevelUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+
+	if !providerConfig.hasNerdGraphCredentials() {
+		return diag.Errorf("err: NerdGraph support not present, but required for Update")
+	}
+
+	client := providerConfig.NewClient
+
+	_, sliID, err := parseServiceLevelID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Updating New Relic service level %s", sliID)
+
+	if _, err := updateServiceLevel(ctx, client, sliID, expandServiceLevelUpdateInput(d)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceNewRelicServiceLevelRead(ctx, d, meta)
+}
+
+func resourceNewRelicServiceLevelDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+
+	if !providerConfig.hasNerdGraphCredentials() {
+		return diag.Errorf("err: NerdGraph support not present, but required for Delete")
+	}
+
+	client := providerConfig.NewClient
+
+	_, sliID, err := parseServiceLevelID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Deleting New Relic service level %s", sliID)
+
+	if _, err := client.ServiceLevel.ServiceLevelDeleteWithContext(ctx, sliID); err != nil {
+		if _, ok := err.(*nrErrors.NotFound); ok {
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// serviceLevelID returns the ID of a service level, <entity_guid>:<sli_id>, as
+// service levels are read through the entity they are attached to.
+func serviceLevelID(entityGUID string, sliID string) string {
+	return fmt.Sprintf("%s:%s", entityGUID, sliID)
+}
+
+func parseServiceLevelID(id string) (string, string, error) {
+	split := strings.Split(id, ":")
+
+	if len(split) != 2 || split[0] == "" || split[1] == "" {
+		return "", "", fmt.Errorf("unhandled id format %s, expected <entity_guid>:<sli_id>", id)
+	}
+
+	return split[0], split[1], nil
+}
@@ -0,0 +1,154 @@
+//go:build integration
+// +build integration
+
+package newrelic
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccNewRelicServiceLevel_Basic(t *testing.T) {
+	resourceName := "newrelic_service_level.foo"
+	rName := testAccRandString(t, 5)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNewRelicServiceLevelDestroy,
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: testAccNewRelicServiceLevelConfig(rName, 99.5),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicServiceLevelExists(resourceName),
+					resource.TestCheckResourceAttrSet(resourceName, "sli_guid"),
+					resource.TestCheckResourceAttrSet(resourceName, "indicator_query"),
+					resource.TestCheckResourceAttr(resourceName, "good_events_metric", "newrelic.sli.good"),
+				),
+			},
+			// Test: Update
+			{
+				Config: testAccNewRelicServiceLevelConfig(rName, 99.9),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicServiceLevelExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "objective.0.target", "99.9"),
+				),
+			},
+			// Test: Import
+			{
+				ImportState:       true,
+				ImportStateVerify: true,
+				ResourceName:      resourceName,
+			},
+		},
+	})
+}
+
+func testAccCheckNewRelicServiceLevelDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*ProviderConfig).NewClient
+
+	for _, r := range s.RootModule().Resources {
+		if r.Type != "newrelic_service_level" {
+			continue
+		}
+
+		entityGUID, sliID, err := parseServiceLevelID(r.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		if _, err := getServiceLevel(context.Background(), client, entityGUID, sliID); err == nil {
+			return fmt.Errorf("service level still exists: %s", r.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckNewRelicServiceLevelExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("no service level ID is set")
+		}
+
+		client := testAccProvider.Meta().(*ProviderConfig).NewClient
+
+		entityGUID, sliID, err := parseServiceLevelID(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		_, err = getServiceLevel(context.Background(), client, entityGUID, sliID)
+
+		return err
+	}
+}
+
+func testAccNewRelicServiceLevelConfig(name string, target float64) string {
+	return fmt.Sprintf(`
+data "newrelic_entity" "app" {
+	name   = "%[1]s"
+	type   = "APPLICATION"
+	domain = "APM"
+}
+
+resource "newrelic_service_level" "foo" {
+	guid = data.newrelic_entity.app.guid
+	name = "tf-test-%[2]s"
+
+	events {
+		valid_events {
+			from  = "Transaction"
+			where = "appName = '%[1]s'"
+		}
+
+		good_events {
+			from  = "Transaction"
+			where = "appName = '%[1]s' AND duration < 0.5"
+		}
+	}
+
+	objective {
+		target = %[3]v
+
+		time_window {
+			rolling {
+				count = 7
+				unit  = "DAY"
+			}
+		}
+	}
+}
+
+resource "newrelic_nrql_alert_condition" "foo" {
+	policy_id = newrelic_alert_policy.foo.id
+	name      = "tf-test-%[2]s"
+
+	nrql {
+		query             = "SELECT 100 - clamp_max(sum(${newrelic_service_level.foo.good_events_metric}) / sum(${newrelic_service_level.foo.valid_events_metric}) * 100, 100) FROM Metric WHERE sli.guid = '${newrelic_service_level.foo.sli_guid}'"
+		evaluation_offset = 3
+	}
+
+	critical {
+		operator              = "above"
+		threshold             = 1
+		threshold_duration    = 300
+		threshold_occurrences = "ALL"
+	}
+}
+
+resource "newrelic_alert_policy" "foo" {
+	name = "tf-test-%[2]s"
+}
+`, testAccExpectedApplicationName, name, target)
+}
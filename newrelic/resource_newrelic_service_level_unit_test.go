@@ -0,0 +1,120 @@
+//go:build unit
+// +build unit
+
+package newrelic
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testServiceLevelConfig(name string, target float64, goodEvents bool) map[string]interface{} {
+	events := map[string]interface{}{
+		"valid_events": []interface{}{
+			map[string]interface{}{"from": "Transaction", "where": "appName = 'checkout'"},
+		},
+	}
+
+	if goodEvents {
+		events["good_events"] = []interface{}{
+			map[string]interface{}{"from": "Transaction", "where": "appName = 'checkout' AND duration < 0.5"},
+		}
+	}
+
+	return map[string]interface{}{
+		"guid":   testCassetteGUID(fmt.Sprint(fakeBackendAccountID)),
+		"name":   name,
+		"events": []interface{}{events},
+		"objective": []interface{}{
+			map[string]interface{}{
+				"target": target,
+				"time_window": []interface{}{
+					map[string]interface{}{
+						"rolling": []interface{}{
+							map[string]interface{}{"count": 7, "unit": "DAY"},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestNewRelicServiceLevel_Basic(t *testing.T) {
+	f := newFakeBackend(t)
+	meta := testFakeBackendMeta(t, f)
+	r := resourceNewRelicServiceLevel()
+
+	config := testServiceLevelConfig("tf-test", 99.5, true)
+
+	state := testFakeBackendApply(t, meta, r, nil, config)
+	sliID := state.Attributes["sli_id"]
+
+	assert.Equal(t, serviceLevelID(config["guid"].(string), sliID), state.ID)
+	assert.Equal(t, fmt.Sprint(fakeBackendAccountID), state.Attributes["events.0.account_id"])
+	assert.Equal(t, serviceLevelGUID(fakeBackendAccountID, sliID), state.Attributes["sli_guid"])
+	assert.Equal(t, "newrelic.sli.good", state.Attributes["good_events_metric"])
+	assert.Equal(t, "newrelic.sli.valid", state.Attributes["valid_events_metric"])
+	assert.NotEmpty(t, state.Attributes["indicator_query"])
+	assert.Contains(t, state.Attributes["objective.0.attainment_query"], "99.5")
+	assert.Equal(t, "0", state.Attributes["events.0.bad_events.#"])
+
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), meta)
+	require.NoError(t, err)
+	assert.Nil(t, diff)
+
+	// The objectives are updated in place.
+	updated := testServiceLevelConfig("tf-test", 99.9, true)
+	diff, err = r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(updated), meta)
+	require.NoError(t, err)
+	require.NotNil(t, diff)
+	assert.False(t, diff.RequiresNew())
+
+	state = testFakeBackendApply(t, meta, r, state, updated)
+	assert.Equal(t, sliID, state.Attributes["sli_id"])
+	assert.Equal(t, "99.9", state.Attributes["objective.0.target"])
+
+	// Import through the ID.
+	imported, diags := r.RefreshWithoutUpgrade(context.Background(), &terraform.InstanceState{ID: state.ID}, meta)
+	require.False(t, diags.HasError(), "%+v", diags)
+	assert.Equal(t, state.Attributes, imported.Attributes)
+
+	testFakeBackendApply(t, meta, r, state, nil)
+	assert.Empty(t, f.serviceLevels)
+}
+
+func TestNewRelicServiceLevel_Validation(t *testing.T) {
+	r := resourceNewRelicServiceLevel()
+
+	missing := testServiceLevelConfig("tf-test", 99.5, false)
+	diags := r.Validate(terraform.NewResourceConfigRaw(missing))
+	assert.True(t, diags.HasError(), "either good or bad events are required")
+
+	both := testServiceLevelConfig("tf-test", 99.5, true)
+	events := both["events"].([]interface{})[0].(map[string]interface{})
+	events["bad_events"] = []interface{}{
+		map[string]interface{}{"from": "TransactionError"},
+	}
+	diags = r.Validate(terraform.NewResourceConfigRaw(both))
+	assert.True(t, diags.HasError(), "good and bad events conflict")
+
+	diags = r.Validate(terraform.NewResourceConfigRaw(testServiceLevelConfig("tf-test", 99.5, true)))
+	assert.False(t, diags.HasError(), "%+v", diags)
+}
+
+func TestParseServiceLevelID(t *testing.T) {
+	guid, id, err := parseServiceLevelID("MXxBUE18QVBQTElDQVRJT058NDI:123")
+	require.NoError(t, err)
+	assert.Equal(t, "MXxBUE18QVBQTElDQVRJT058NDI", guid)
+	assert.Equal(t, "123", id)
+
+	for _, id := range []string{"123", "a:b:c", ":123"} {
+		_, _, err := parseServiceLevelID(id)
+		assert.Error(t, err, id)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/newrelic/newrelic-client-go/pkg/common"
 	"github.com/newrelic/newrelic-client-go/pkg/errors"
 	"github.com/newrelic/newrelic-client-go/pkg/synthetics"
 )
@@ -18,6 +19,7 @@ func resourceNewRelicSyntheticsMonitor() *schema.Resource {
 		ReadContext:   resourceNewRelicSyntheticsMonitorRead,
 		UpdateContext: resourceNewRelicSyntheticsMonitorUpdate,
 		DeleteContext: resourceNewRelicSyntheticsMonitorDelete,
+		CustomizeDiff: customizeDiffDefaultTags,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -95,6 +97,7 @@ func resourceNewRelicSyntheticsMonitor() *schema.Resource {
 				Optional:    true,
 				Description: "Fail the monitor check if redirected.",
 			},
+			"default_tags": defaultTagsSchema(),
 		},
 	}
 }
@@ -193,7 +196,8 @@ func readSyntheticsMonitorStruct(monitor *synthetics.Monitor, d *schema.Resource
 }
 
 func resourceNewRelicSyntheticsMonitorCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*ProviderConfig).NewClient
+	providerConfig := meta.(*ProviderConfig)
+	client := providerConfig.NewClient
 	monitorStruct := buildSyntheticsMonitorStruct(d)
 
 	log.Printf("[INFO] Creating New Relic Synthetics monitor %s", monitorStruct.Name)
@@ -204,11 +208,17 @@ func resourceNewRelicSyntheticsMonitorCreate(ctx context.Context, d *schema.Reso
 	}
 
 	d.SetId(monitor.ID)
+
+	if err := updateDefaultTags(ctx, providerConfig, getSyntheticsMonitorGUID(providerConfig, d.Id()), d); err != nil {
+		return diag.FromErr(err)
+	}
+
 	return resourceNewRelicSyntheticsMonitorRead(ctx, d, meta)
 }
 
 func resourceNewRelicSyntheticsMonitorRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*ProviderConfig).NewClient
+	providerConfig := meta.(*ProviderConfig)
+	client := providerConfig.NewClient
 
 	log.Printf("[INFO] Reading New Relic Synthetics monitor %s", d.Id())
 
@@ -224,11 +234,12 @@ func resourceNewRelicSyntheticsMonitorRead(ctx context.Context, d *schema.Resour
 
 	readSyntheticsMonitorStruct(monitor, d)
 
-	return nil
+	return diag.FromErr(readDefaultTags(ctx, providerConfig, getSyntheticsMonitorGUID(providerConfig, d.Id()), d))
 }
 
 func resourceNewRelicSyntheticsMonitorUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*ProviderConfig).NewClient
+	providerConfig := meta.(*ProviderConfig)
+	client := providerConfig.NewClient
 	log.Printf("[INFO] Updating New Relic Synthetics monitor %s", d.Id())
 
 	_, err := client.Synthetics.UpdateMonitorWithContext(ctx, *buildSyntheticsUpdateMonitorArgs(d))
@@ -236,6 +247,10 @@ func resourceNewRelicSyntheticsMonitorUpdate(ctx context.Context, d *schema.Reso
 		return diag.FromErr(err)
 	}
 
+	if err := updateDefaultTags(ctx, providerConfig, getSyntheticsMonitorGUID(providerConfig, d.Id()), d); err != nil {
+		return diag.FromErr(err)
+	}
+
 	return resourceNewRelicSyntheticsMonitorRead(ctx, d, meta)
 }
 
@@ -250,3 +265,9 @@ func resourceNewRelicSyntheticsMonitorDelete(ctx context.Context, d *schema.Reso
 
 	return nil
 }
+
+// Synthetics monitors are managed through the REST API, which doesn't return
+// the monitor's entity GUID.
+func getSyntheticsMonitorGUID(providerConfig *ProviderConfig, monitorID string) common.EntityGUID {
+	return getEntityGUID(providerConfig.AccountID, "SYNTH", "MONITOR", monitorID)
+}
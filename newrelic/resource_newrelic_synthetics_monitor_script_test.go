@@ -7,16 +7,15 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccNewRelicSyntheticsMonitorScript_Basic(t *testing.T) {
 	resourceName := "newrelic_synthetics_monitor_script.foo_script"
-	rName := acctest.RandString(5)
-	scriptText := acctest.RandString(5)
-	scriptTextUpdated := acctest.RandString(5)
+	rName := testAccRandString(t, 5)
+	scriptText := testAccRandString(t, 5)
+	scriptTextUpdated := testAccRandString(t, 5)
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -7,14 +7,13 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccNewRelicSyntheticsMonitor_Basic(t *testing.T) {
 	resourceName := "newrelic_synthetics_monitor.foo"
-	rName := acctest.RandString(5)
+	rName := testAccRandString(t, 5)
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -47,7 +46,7 @@ func TestAccNewRelicSyntheticsMonitor_Basic(t *testing.T) {
 
 func TestAccNewRelicSyntheticsMonitor_OptionalArgs(t *testing.T) {
 	resourceName := "newrelic_synthetics_monitor.foo"
-	rName := acctest.RandString(5)
+	rName := testAccRandString(t, 5)
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -80,7 +79,7 @@ func TestAccNewRelicSyntheticsMonitor_OptionalArgs(t *testing.T) {
 
 func TestAccNewRelicSyntheticsMonitor_Browser(t *testing.T) {
 	resourceName := "newrelic_synthetics_monitor.foo"
-	rName := acctest.RandString(5)
+	rName := testAccRandString(t, 5)
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -113,7 +112,7 @@ func TestAccNewRelicSyntheticsMonitor_Browser(t *testing.T) {
 
 func TestAccNewRelicSyntheticsMonitor_ScriptBrowser(t *testing.T) {
 	resourceName := "newrelic_synthetics_monitor.foo"
-	rName := acctest.RandString(5)
+	rName := testAccRandString(t, 5)
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -146,7 +145,7 @@ func TestAccNewRelicSyntheticsMonitor_ScriptBrowser(t *testing.T) {
 
 func TestAccNewRelicSyntheticsMonitor_ScriptAPI(t *testing.T) {
 	resourceName := "newrelic_synthetics_monitor.foo"
-	rName := acctest.RandString(5)
+	rName := testAccRandString(t, 5)
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
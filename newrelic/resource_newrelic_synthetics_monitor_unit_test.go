@@ -0,0 +1,65 @@
+//go:build unit
+// +build unit
+
+package newrelic
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestNewRelicSyntheticsMonitor_FakeBackend(t *testing.T) {
+	f := newFakeBackend(t)
+	resourceName := "newrelic_synthetics_monitor.foo"
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: f.providerFactories(),
+		CheckDestroy: func(s *terraform.State) error {
+			if len(f.monitors) > 0 {
+				return fmt.Errorf("synthetics monitors still exist: %v", f.monitors)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: f.providerBlock() + testFakeNewRelicSyntheticsMonitorConfig("tf-test", 5),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "tf-test"),
+					resource.TestCheckResourceAttr(resourceName, "frequency", "5"),
+					resource.TestCheckResourceAttr(resourceName, "locations.#", "1"),
+				),
+			},
+			// Test: Update
+			{
+				Config: f.providerBlock() + testFakeNewRelicSyntheticsMonitorConfig("tf-test-updated", 10),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "tf-test-updated"),
+					resource.TestCheckResourceAttr(resourceName, "frequency", "10"),
+				),
+			},
+			// Test: Import
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testFakeNewRelicSyntheticsMonitorConfig(name string, frequency int) string {
+	return fmt.Sprintf(`
+resource "newrelic_synthetics_monitor" "foo" {
+  name      = "%s"
+  type      = "SIMPLE"
+  frequency = %d
+  status    = "DISABLED"
+  locations = ["AWS_US_EAST_1"]
+  uri       = "https://example.com"
+}
+`, name, frequency)
+}
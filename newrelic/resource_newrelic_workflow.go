@@ -0,0 +1,315 @@
+package newrelic
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	nrErrors "github.com/newrelic/newrelic-client-go/pkg/errors"
+)
+
+var workflowMutingRulesHandlings = []string{
+	"DONT_NOTIFY_FULLY_MUTED_ISSUES",
+	"DONT_NOTIFY_FULLY_OR_PARTIALLY_MUTED_ISSUES",
+	"NOTIFY_ALL_ISSUES",
+}
+
+var workflowPredicateOperators = []string{
+	"CONTAINS",
+	"DOES_NOT_CONTAIN",
+	"DOES_NOT_EQUAL",
+	"DOES_NOT_EXACTLY_MATCH",
+	"ENDS_WITH",
+	"EQUAL",
+	"EXACTLY_MATCHES",
+	"GREATER_OR_EQUAL",
+	"GREATER_THAN",
+	"IS",
+	"IS_NOT",
+	"LESS_OR_EQUAL",
+	"LESS_THAN",
+	"STARTS_WITH",
+}
+
+func resourceNewRelicWorkflow() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceNewRelicWorkflowCreate,
+		ReadContext:   resourceNewRelicWorkflowRead,
+		UpdateContext: resourceNewRelicWorkflowUpdate,
+		DeleteContext: resourceNewRelicWorkflowDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceImportStateWithAccountID,
+		},
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The New Relic account ID of the workflow.",
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.NoZeroValues,
+				Description:  "The name of the workflow.",
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the workflow is enabled.",
+			},
+			"destinations_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the destinations of the workflow are notified.",
+			},
+			"enrichments_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the enrichments of the workflow are run.",
+			},
+			"muting_rules_handling": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "NOTIFY_ALL_ISSUES",
+				ValidateFunc: validation.StringInSlice(workflowMutingRulesHandlings, false),
+				Description:  "How muted issues are handled. One of NOTIFY_ALL_ISSUES, DONT_NOTIFY_FULLY_MUTED_ISSUES or DONT_NOTIFY_FULLY_OR_PARTIALLY_MUTED_ISSUES.",
+			},
+			"issues_filter": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MaxItems:    1,
+				Description: "The filter selecting the issues the workflow handles.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"filter_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the filter.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The name of the filter.",
+						},
+						"type": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice([]string{"FILTER", "VIEW"}, false),
+							Description:  "The type of the filter, FILTER or VIEW.",
+						},
+						"predicate": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "The conditions an issue must meet, all of them must match.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"attribute": {
+										Type:        schema.TypeString,
+										Required:    true,
+										Description: "The issue attribute, such as labels.policyIds or priority.",
+									},
+									"operator": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validation.StringInSlice(workflowPredicateOperators, false),
+										Description:  "The operator comparing the attribute to the values.",
+									},
+									"values": {
+										Type:        schema.TypeList,
+										Required:    true,
+										MinItems:    1,
+										Elem:        &schema.Schema{Type: schema.TypeString},
+										Description: "The values compared to the attribute.",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"enrichments": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "The enrichments adding data to the notifications of the workflow.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"nrql": {
+							Type:        schema.TypeList,
+							Required:    true,
+							MinItems:    1,
+							Description: "An enrichment running NRQL queries.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"enrichment_id": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The ID of the enrichment.",
+									},
+									"name": {
+										Type:        schema.TypeString,
+										Required:    true,
+										Description: "The name of the enrichment.",
+									},
+									"configuration": {
+										Type:        schema.TypeList,
+										Required:    true,
+										MinItems:    1,
+										Description: "The NRQL queries of the enrichment.",
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"query": {
+													Type:        schema.TypeString,
+													Required:    true,
+													Description: "The NRQL query.",
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"destination": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				MinItems:    1,
+				Description: "The notification channels the workflow notifies.",
+				Set: func(v interface{}) int {
+					return schema.HashString(v.(map[string]interface{})["channel_id"])
+				},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"channel_id": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The ID of the notification channel.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the notification channel.",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the notification channel.",
+						},
+					},
+				},
+			},
+			"last_run": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The last time the workflow ran.",
+			},
+		},
+	}
+}
+
+func resourceNewRelicWorkflowCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+
+	if !providerConfig.hasNerdGraphCredentials() {
+		return diag.Errorf("err: NerdGraph support not present, but required for Create")
+	}
+
+	client := providerConfig.NewClient
+	accountID := selectAccountID(providerConfig, d)
+	input := expandWorkflowCreateInput(d)
+
+	log.Printf("[INFO] Creating New Relic workflow %s", input["name"])
+
+	created, err := createWorkflow(ctx, client, accountID, input)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(created.ID)
+
+	if err := d.Set("account_id", accountID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceNewRelicWorkflowRead(ctx, d, meta)
+}
+
+func resourceNewRelicWorkflowRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+
+	if !providerConfig.hasNerdGraphCredentials() {
+		return diag.Errorf("err: NerdGraph support not present, but required for Read")
+	}
+
+	client := providerConfig.NewClient
+	accountID := selectAccountID(providerConfig, d)
+
+	log.Printf("[INFO] Reading New Relic workflow %s from account %d", d.Id(), accountID)
+
+	w, err := getWorkflow(ctx, client, accountID, d.Id())
+	if err != nil {
+		if _, ok := err.(*nrErrors.NotFound); ok {
+			d.SetId("")
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("account_id", accountID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.FromErr(flattenWorkflow(w, d))
+}
+
+func resourceNewRelicWorkflowUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+
+	if !providerConfig.hasNerdGraphCredentials() {
+		return diag.Errorf("err: NerdGraph support not present, but required for Update")
+	}
+
+	client := providerConfig.NewClient
+	accountID := selectAccountID(providerConfig, d)
+
+	log.Printf("[INFO] Updating New Relic workflow %s", d.Id())
+
+	if _, err := updateWorkflow(ctx, client, accountID, expandWorkflowUpdateInput(d)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceNewRelicWorkflowRead(ctx, d, meta)
+}
+
+func resourceNewRelicWorkflowDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+
+	if !providerConfig.hasNerdGraphCredentials() {
+		return diag.Errorf("err: NerdGraph support not present, but required for Delete")
+	}
+
+	client := providerConfig.NewClient
+	accountID := selectAccountID(providerConfig, d)
+
+	log.Printf("[INFO] Deleting New Relic workflow %s", d.Id())
+
+	if err := deleteWorkflow(ctx, client, accountID, d.Id()); err != nil {
+		if _, ok := err.(*nrErrors.NotFound); ok {
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
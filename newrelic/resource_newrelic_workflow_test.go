@@ -0,0 +1,174 @@
+//go:build integration
+// +build integration
+
+package newrelic
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/newrelic/newrelic-client-go/pkg/errors"
+)
+
+func TestAccNewRelicWorkflow_Basic(t *testing.T) {
+	resourceName := "newrelic_workflow.foo"
+	rName := testAccRandString(t, 5)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNewRelicWorkflowDestroy,
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: testAccNewRelicWorkflowConfig(rName, "SELECT count(*) FROM Log"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicWorkflowExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "destination.#", "1"),
+					resource.TestCheckResourceAttrSet(resourceName, "issues_filter.0.filter_id"),
+					resource.TestCheckResourceAttrSet(resourceName, "enrichments.0.nrql.0.enrichment_id"),
+				),
+			},
+			// Test: Update
+			{
+				Config: testAccNewRelicWorkflowConfig(rName, "SELECT count(*) FROM Log FACET level"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNewRelicWorkflowExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "enrichments.0.nrql.0.configuration.0.query", "SELECT count(*) FROM Log FACET level"),
+				),
+			},
+			// Test: Import
+			{
+				ImportState:       true,
+				ImportStateVerify: true,
+				ResourceName:      resourceName,
+			},
+			{
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"auth_basic.0.password"},
+				ResourceName:            "newrelic_notification_destination.foo",
+			},
+			{
+				ImportState:       true,
+				ImportStateVerify: true,
+				ResourceName:      "newrelic_notification_channel.foo",
+			},
+		},
+	})
+}
+
+func testAccCheckNewRelicWorkflowDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*ProviderConfig).NewClient
+
+	for _, r := range s.RootModule().Resources {
+		var err error
+
+		switch r.Type {
+		case "newrelic_workflow":
+			_, err = getWorkflow(context.Background(), client, testAccountID, r.Primary.ID)
+		case "newrelic_notification_channel":
+			_, err = getNotificationChannel(context.Background(), client, testAccountID, r.Primary.ID)
+		case "newrelic_notification_destination":
+			_, err = getNotificationDestination(context.Background(), client, testAccountID, r.Primary.ID)
+		default:
+			continue
+		}
+
+		if err == nil {
+			return fmt.Errorf("%s still exists: %s", r.Type, r.Primary.ID)
+		}
+
+		if _, ok := err.(*errors.NotFound); !ok {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckNewRelicWorkflowExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("no workflow ID is set")
+		}
+
+		client := testAccProvider.Meta().(*ProviderConfig).NewClient
+
+		_, err := getWorkflow(context.Background(), client, testAccountID, rs.Primary.ID)
+
+		return err
+	}
+}
+
+func testAccNewRelicWorkflowConfig(name string, query string) string {
+	return fmt.Sprintf(`
+resource "newrelic_alert_policy" "foo" {
+	name = "tf-test-%[1]s"
+}
+
+resource "newrelic_notification_destination" "foo" {
+	name = "tf-test-%[1]s"
+	type = "WEBHOOK"
+
+	property {
+		key   = "url"
+		value = "https://example.com/hook"
+	}
+
+	auth_basic {
+		user     = "admin"
+		password = "tf-test-password"
+	}
+}
+
+resource "newrelic_notification_channel" "foo" {
+	name           = "tf-test-%[1]s"
+	type           = "WEBHOOK"
+	destination_id = newrelic_notification_destination.foo.id
+
+	property {
+		key   = "payload"
+		value = "{\"issue\": \"{{ issueTitle }}\"}"
+		label = "Payload Template"
+	}
+}
+
+resource "newrelic_workflow" "foo" {
+	name = "tf-test-%[1]s"
+
+	issues_filter {
+		name = "tf-test-%[1]s"
+		type = "FILTER"
+
+		predicate {
+			attribute = "labels.policyIds"
+			operator  = "EXACTLY_MATCHES"
+			values    = [newrelic_alert_policy.foo.id]
+		}
+	}
+
+	enrichments {
+		nrql {
+			name = "Log count"
+
+			configuration {
+				query = "%[2]s"
+			}
+		}
+	}
+
+	destination {
+		channel_id = newrelic_notification_channel.foo.id
+	}
+}
+`, name, query)
+}
@@ -0,0 +1,202 @@
+//go:build unit
+// +build unit
+
+package newrelic
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testWorkflowChannels creates a destination and its channels.
+func testWorkflowChannels(t *testing.T, meta *ProviderConfig, names ...string) []string {
+	destination := testFakeBackendApply(t, meta, resourceNewRelicNotificationDestination(), nil, testNotificationDestinationConfig("tf-test", "secret"))
+
+	ids := make([]string, len(names))
+	for i, name := range names {
+		channel := testFakeBackendApply(t, meta, resourceNewRelicNotificationChannel(), nil, map[string]interface{}{
+			"name":           name,
+			"type":           "WEBHOOK",
+			"destination_id": destination.ID,
+			"property": []interface{}{
+				map[string]interface{}{"key": "payload", "value": "{}", "label": "Payload"},
+			},
+		})
+		ids[i] = channel.ID
+	}
+
+	return ids
+}
+
+func testWorkflowConfig(name string, channelIDs []string, queries ...string) map[string]interface{} {
+	destinations := make([]interface{}, len(channelIDs))
+	for i, id := range channelIDs {
+		destinations[i] = map[string]interface{}{"channel_id": id}
+	}
+
+	config := map[string]interface{}{
+		"name": name,
+		"issues_filter": []interface{}{
+			map[string]interface{}{
+				"name": "tf-test-filter",
+				"type": "FILTER",
+				"predicate": []interface{}{
+					map[string]interface{}{
+						"attribute": "labels.policyIds",
+						"operator":  "EXACTLY_MATCHES",
+						"values":    []interface{}{"123"},
+					},
+				},
+			},
+		},
+		"destination": destinations,
+	}
+
+	if len(queries) > 0 {
+		configurations := make([]interface{}, len(queries))
+		for i, q := range queries {
+			configurations[i] = map[string]interface{}{"query": q}
+		}
+
+		config["enrichments"] = []interface{}{
+			map[string]interface{}{
+				"nrql": []interface{}{
+					map[string]interface{}{"name": "Log count", "configuration": configurations},
+				},
+			},
+		}
+	}
+
+	return config
+}
+
+func TestNewRelicNotificationChannel_Basic(t *testing.T) {
+	f := newFakeBackend(t)
+	meta := testFakeBackendMeta(t, f)
+	r := resourceNewRelicNotificationChannel()
+
+	ids := testWorkflowChannels(t, meta, "tf-test")
+	state, diags := r.RefreshWithoutUpgrade(context.Background(), &terraform.InstanceState{ID: ids[0]}, meta)
+	require.False(t, diags.HasError(), "%+v", diags)
+	assert.Equal(t, "IINT", state.Attributes["product"])
+	assert.Equal(t, "true", state.Attributes["active"])
+
+	config := map[string]interface{}{
+		"name":           "tf-test-updated",
+		"type":           "WEBHOOK",
+		"destination_id": state.Attributes["destination_id"],
+		"active":         false,
+	}
+
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), meta)
+	require.NoError(t, err)
+	assert.False(t, diff.RequiresNew())
+
+	state = testFakeBackendApply(t, meta, r, state, config)
+	assert.Equal(t, ids[0], state.ID)
+	assert.Equal(t, "tf-test-updated", f.notificationChannels[ids[0]]["name"])
+	assert.Equal(t, "0", state.Attributes["property.#"])
+
+	testFakeBackendApply(t, meta, r, state, nil)
+	assert.Empty(t, f.notificationChannels)
+}
+
+func TestNewRelicWorkflow_Basic(t *testing.T) {
+	f := newFakeBackend(t)
+	meta := testFakeBackendMeta(t, f)
+	r := resourceNewRelicWorkflow()
+
+	plan := func(state *terraform.InstanceState, config map[string]interface{}) *terraform.InstanceDiff {
+		diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), meta)
+		require.NoError(t, err)
+		return diff
+	}
+
+	channelIDs := testWorkflowChannels(t, meta, "tf-test-1", "tf-test-2")
+
+	state := testFakeBackendApply(t, meta, r, nil, testWorkflowConfig("tf-test", channelIDs[:1], "SELECT count(*) FROM Log"))
+	id := state.ID
+	filterID := state.Attributes["issues_filter.0.filter_id"]
+	enrichmentID := state.Attributes["enrichments.0.nrql.0.enrichment_id"]
+
+	assert.NotEmpty(t, filterID)
+	assert.NotEmpty(t, enrichmentID)
+	assert.Equal(t, "NOTIFY_ALL_ISSUES", state.Attributes["muting_rules_handling"])
+	assert.Equal(t, "1", state.Attributes["destination.#"])
+	assert.Nil(t, plan(state, testWorkflowConfig("tf-test", channelIDs[:1], "SELECT count(*) FROM Log")))
+
+	// Adding a destination and changing the query updates the workflow in
+	// place, keeping its filter and enrichment.
+	config := testWorkflowConfig("tf-test", channelIDs, "SELECT count(*) FROM Log FACET level")
+	diff := plan(state, config)
+	require.NotNil(t, diff)
+	assert.False(t, diff.RequiresNew())
+
+	state = testFakeBackendApply(t, meta, r, state, config)
+	assert.Equal(t, id, state.ID)
+	assert.Equal(t, "2", state.Attributes["destination.#"])
+	assert.Equal(t, filterID, state.Attributes["issues_filter.0.filter_id"])
+	assert.Equal(t, enrichmentID, state.Attributes["enrichments.0.nrql.0.enrichment_id"])
+	assert.Equal(t, "SELECT count(*) FROM Log FACET level", state.Attributes["enrichments.0.nrql.0.configuration.0.query"])
+	assert.Nil(t, plan(state, config))
+
+	// Removing the enrichments.
+	state = testFakeBackendApply(t, meta, r, state, testWorkflowConfig("tf-test", channelIDs))
+	assert.Equal(t, "0", state.Attributes["enrichments.#"])
+	assert.Empty(t, f.workflows[id]["enrichments"])
+
+	testFakeBackendApply(t, meta, r, state, nil)
+	assert.Empty(t, f.workflows)
+	assert.Len(t, f.notificationChannels, 2, "the channels of a workflow are not deleted with it")
+}
+
+func TestDataSourceNewRelicWorkflow(t *testing.T) {
+	f := newFakeBackend(t)
+	meta := testFakeBackendMeta(t, f)
+
+	channelIDs := testWorkflowChannels(t, meta, "tf-test-1", "tf-test-2")
+	state := testFakeBackendApply(t, meta, resourceNewRelicWorkflow(), nil, testWorkflowConfig("tf-test", channelIDs))
+
+	ds := dataSourceNewRelicWorkflow()
+	d := schema.TestResourceDataRaw(t, ds.Schema, map[string]interface{}{"name": "tf-test"})
+	diags := ds.ReadContext(context.Background(), d, meta)
+	require.False(t, diags.HasError(), "%+v", diags)
+	assert.Equal(t, state.ID, d.Id())
+	assert.Equal(t, state.Attributes["issues_filter.0.filter_id"], d.Get("issues_filter_id"))
+	assert.ElementsMatch(t, []interface{}{channelIDs[0], channelIDs[1]}, d.Get("channel_ids"))
+
+	channels := dataSourceNewRelicNotificationChannel()
+	d = schema.TestResourceDataRaw(t, channels.Schema, map[string]interface{}{"name": "tf-test-2"})
+	diags = channels.ReadContext(context.Background(), d, meta)
+	require.False(t, diags.HasError(), "%+v", diags)
+	assert.Equal(t, channelIDs[1], d.Id())
+	assert.Equal(t, "IINT", d.Get("product"))
+}
+
+func TestGetWorkflows_AllPages(t *testing.T) {
+	f := newFakeBackend(t)
+	meta := testFakeBackendMeta(t, f)
+
+	channelIDs := testWorkflowChannels(t, meta, "tf-test-1", "tf-test-2", "tf-test-3")
+	for _, name := range []string{"tf-test-1", "tf-test-2", "tf-test-3"} {
+		testFakeBackendApply(t, meta, resourceNewRelicWorkflow(), nil, testWorkflowConfig(name, channelIDs[:1]))
+	}
+
+	// The fake backend returns one entity per page.
+	workflows, err := getWorkflows(context.Background(), meta.NewClient, meta.AccountID, map[string]interface{}{"name": "tf-test"})
+	require.NoError(t, err)
+	assert.Len(t, workflows, 3)
+
+	channels, err := getNotificationChannels(context.Background(), meta.NewClient, meta.AccountID, map[string]interface{}{"name": "tf-test"})
+	require.NoError(t, err)
+	assert.Len(t, channels, 3)
+
+	destinations, err := getNotificationDestinations(context.Background(), meta.NewClient, meta.AccountID, nil)
+	require.NoError(t, err)
+	assert.Len(t, destinations, 1)
+}
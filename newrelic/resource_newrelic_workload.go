@@ -9,6 +9,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/newrelic/newrelic-client-go/pkg/common"
 	"github.com/newrelic/newrelic-client-go/pkg/errors"
 )
 
@@ -18,6 +19,7 @@ func resourceNewRelicWorkload() *schema.Resource {
 		ReadContext:   resourceNewRelicWorkloadRead,
 		UpdateContext: resourceNewRelicWorkloadUpdate,
 		DeleteContext: resourceNewRelicWorkloadDelete,
+		CustomizeDiff: customizeDiffDefaultTags,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -82,12 +84,14 @@ func resourceNewRelicWorkload() *schema.Resource {
 				Computed:    true,
 				Description: "The URL of the workload.",
 			},
+			"default_tags": defaultTagsSchema(),
 		},
 	}
 }
 
 func resourceNewRelicWorkloadCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*ProviderConfig).NewClient
+	providerConfig := meta.(*ProviderConfig)
+	client := providerConfig.NewClient
 	createInput := expandWorkloadCreateInput(d)
 	accountID := d.Get("account_id").(int)
 
@@ -105,11 +109,17 @@ func resourceNewRelicWorkloadCreate(ctx context.Context, d *schema.ResourceData,
 	}
 
 	d.SetId(ids.String())
+
+	if err := updateDefaultTags(ctx, providerConfig, common.EntityGUID(ids.GUID), d); err != nil {
+		return diag.FromErr(err)
+	}
+
 	return resourceNewRelicWorkloadRead(ctx, d, meta)
 }
 
 func resourceNewRelicWorkloadRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*ProviderConfig).NewClient
+	providerConfig := meta.(*ProviderConfig)
+	client := providerConfig.NewClient
 
 	ids, err := parseWorkloadIDs(d.Id())
 	if err != nil {
@@ -126,11 +136,16 @@ func resourceNewRelicWorkloadRead(ctx context.Context, d *schema.ResourceData, m
 		return diag.FromErr(err)
 	}
 
-	return diag.FromErr(flattenWorkload(workload, d))
+	if err := flattenWorkload(workload, d); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.FromErr(readDefaultTags(ctx, providerConfig, common.EntityGUID(ids.GUID), d))
 }
 
 func resourceNewRelicWorkloadUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*ProviderConfig).NewClient
+	providerConfig := meta.(*ProviderConfig)
+	client := providerConfig.NewClient
 	updateInput := expandWorkloadUpdateInput(d)
 
 	log.Printf("[INFO] Updating New Relic One workload %s", d.Id())
@@ -145,6 +160,10 @@ func resourceNewRelicWorkloadUpdate(ctx context.Context, d *schema.ResourceData,
 		return diag.FromErr(err)
 	}
 
+	if err := updateDefaultTags(ctx, providerConfig, common.EntityGUID(ids.GUID), d); err != nil {
+		return diag.FromErr(err)
+	}
+
 	d.SetId(ids.String())
 
 	return resourceNewRelicWorkloadRead(ctx, d, meta)
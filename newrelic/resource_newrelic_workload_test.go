@@ -7,14 +7,13 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccNewRelicWorkload_Basic(t *testing.T) {
 	resourceName := "newrelic_workload.foo"
-	rName := acctest.RandString(5)
+	rName := testAccRandString(t, 5)
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -48,7 +47,7 @@ func TestAccNewRelicWorkload_Basic(t *testing.T) {
 
 func TestAccNewRelicWorkload_EntitiesOnly(t *testing.T) {
 	resourceName := "newrelic_workload.foo"
-	rName := acctest.RandString(5)
+	rName := testAccRandString(t, 5)
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -67,7 +66,7 @@ func TestAccNewRelicWorkload_EntitiesOnly(t *testing.T) {
 
 func TestAccNewRelicWorkload_EntitySearchQueriesOnly(t *testing.T) {
 	resourceName := "newrelic_workload.foo"
-	rName := acctest.RandString(5)
+	rName := testAccRandString(t, 5)
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -86,7 +85,7 @@ func TestAccNewRelicWorkload_EntitySearchQueriesOnly(t *testing.T) {
 
 func TestAccNewRelicWorkload_EntityScopeAccountsOnly(t *testing.T) {
 	resourceName := "newrelic_workload.foo"
-	rName := acctest.RandString(5)
+	rName := testAccRandString(t, 5)
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -0,0 +1,69 @@
+//go:build unit
+// +build unit
+
+package newrelic
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestNewRelicWorkload_FakeBackend(t *testing.T) {
+	f := newFakeBackend(t)
+	resourceName := "newrelic_workload.foo"
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: f.providerFactories(),
+		CheckDestroy: func(s *terraform.State) error {
+			if len(f.workloads) > 0 {
+				return fmt.Errorf("workloads still exist: %v", f.workloads)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: f.providerBlock() + testFakeNewRelicWorkloadConfig("tf-test", "name like 'tf-test'"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "tf-test"),
+					resource.TestCheckResourceAttr(resourceName, "account_id", "1"),
+					resource.TestCheckResourceAttr(resourceName, "entity_search_query.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "scope_account_ids.#", "1"),
+					resource.TestCheckResourceAttrSet(resourceName, "guid"),
+					resource.TestCheckResourceAttrSet(resourceName, "permalink"),
+				),
+			},
+			// Test: Update
+			{
+				Config: f.providerBlock() + testFakeNewRelicWorkloadConfig("tf-test-updated", "name like 'tf-test'"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "tf-test-updated"),
+				),
+			},
+			// Test: Import
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testFakeNewRelicWorkloadConfig(name string, query string) string {
+	return fmt.Sprintf(`
+resource "newrelic_workload" "foo" {
+  name       = "%[1]s"
+  account_id = 1
+
+  entity_search_query {
+    query = "%[2]s"
+  }
+
+  scope_account_ids = [1]
+}
+`, name, query)
+}
@@ -0,0 +1,169 @@
+package newrelic
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/newrelic/newrelic-client-go/newrelic"
+	"github.com/newrelic/newrelic-client-go/pkg/errors"
+	"github.com/newrelic/newrelic-client-go/pkg/servicelevel"
+)
+
+// newrelic-client-go can create and delete service levels, but it can't read
+// them back, and it sends empty good and bad events definitions when one of
+// them is not set, which NerdGraph rejects. Service levels are created,
+// updated and read with these NerdGraph documents instead.
+
+const serviceLevelIndicatorFields = `
+	id
+	name
+	description
+	entityGuid
+	events {
+		account {
+			id
+		}
+		validEvents {
+			from
+			where
+		}
+		goodEvents {
+			from
+			where
+		}
+		badEvents {
+			from
+			where
+		}
+	}
+	objectives {
+		name
+		description
+		target
+		timeWindow {
+			rolling {
+				count
+				unit
+			}
+		}
+		resultQueries {
+			attainment {
+				nrql
+			}
+		}
+	}
+	resultQueries {
+		indicator {
+			nrql
+		}
+		goodEvents {
+			nrql
+		}
+		validEvents {
+			nrql
+		}
+	}`
+
+const (
+	createServiceLevelMutation = `mutation($entityGuid: EntityGuid!, $indicator: ServiceLevelIndicatorCreateInput!) {
+		serviceLevelCreate(entityGuid: $entityGuid, indicator: $indicator) {` + serviceLevelIndicatorFields + `
+		}
+	}`
+
+	updateServiceLevelMutation = `mutation($id: ID!, $indicator: ServiceLevelIndicatorUpdateInput!) {
+		serviceLevelUpdate(id: $id, indicator: $indicator) {` + serviceLevelIndicatorFields + `
+		}
+	}`
+
+	getServiceLevelsQuery = `query($entityGuid: EntityGuid!) {
+		actor {
+			entity(guid: $entityGuid) {
+				serviceLevel {
+					indicators {` + serviceLevelIndicatorFields + `
+					}
+				}
+			}
+		}
+	}`
+)
+
+// The metrics generated for every service level, identified by their
+// sli.guid attribute.
+const (
+	serviceLevelValidEventsMetric = "newrelic.sli.valid"
+	serviceLevelGoodEventsMetric  = "newrelic.sli.good"
+	serviceLevelBadEventsMetric   = "newrelic.sli.bad"
+)
+
+// serviceLevelGUID returns the GUID of the entity of a service level, which is
+// created in the account of its events.
+func serviceLevelGUID(accountID int, sliID string) string {
+	return base64.RawStdEncoding.EncodeToString([]byte(fmt.Sprintf("%d|EXT|SERVICE_LEVEL|%s", accountID, sliID)))
+}
+
+func createServiceLevel(ctx context.Context, client *newrelic.NewRelic, entityGUID string, indicator map[string]interface{}) (*servicelevel.ServiceLevelIndicator, error) {
+	var resp struct {
+		ServiceLevelCreate servicelevel.ServiceLevelIndicator `json:"serviceLevelCreate"`
+	}
+
+	vars := map[string]interface{}{
+		"entityGuid": entityGUID,
+		"indicator":  indicator,
+	}
+
+	if err := client.NerdGraph.QueryWithResponseAndContext(ctx, createServiceLevelMutation, vars, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp.ServiceLevelCreate, nil
+}
+
+func updateServiceLevel(ctx context.Context, client *newrelic.NewRelic, id string, indicator map[string]interface{}) (*servicelevel.ServiceLevelIndicator, error) {
+	var resp struct {
+		ServiceLevelUpdate servicelevel.ServiceLevelIndicator `json:"serviceLevelUpdate"`
+	}
+
+	vars := map[string]interface{}{
+		"id":        id,
+		"indicator": indicator,
+	}
+
+	if err := client.NerdGraph.QueryWithResponseAndContext(ctx, updateServiceLevelMutation, vars, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp.ServiceLevelUpdate, nil
+}
+
+func getServiceLevel(ctx context.Context, client *newrelic.NewRelic, entityGUID string, id string) (*servicelevel.ServiceLevelIndicator, error) {
+	var resp struct {
+		Actor struct {
+			Entity *struct {
+				ServiceLevel struct {
+					Indicators []servicelevel.ServiceLevelIndicator `json:"indicators"`
+				} `json:"serviceLevel"`
+			} `json:"entity"`
+		} `json:"actor"`
+	}
+
+	vars := map[string]interface{}{
+		"entityGuid": entityGUID,
+	}
+
+	if err := client.NerdGraph.QueryWithResponseAndContext(ctx, getServiceLevelsQuery, vars, &resp); err != nil {
+		return nil, err
+	}
+
+	if resp.Actor.Entity == nil {
+		return nil, errors.NewNotFoundf("no entity found for guid %s", entityGUID)
+	}
+
+	for i, indicator := range resp.Actor.Entity.ServiceLevel.Indicators {
+		if indicator.ID == id {
+			return &resp.Actor.Entity.ServiceLevel.Indicators[i], nil
+		}
+	}
+
+	return nil, errors.NewNotFoundf("no service level found for id %s", id)
+}
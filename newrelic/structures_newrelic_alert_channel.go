@@ -1,9 +1,15 @@
 package newrelic
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/newrelic/newrelic-client-go/pkg/alerts"
@@ -210,71 +216,241 @@ func flattenAlertChannelConfiguration(c *alerts.ChannelConfiguration, d *schema.
 	configResult["teams"] = c.Teams
 	configResult["user_id"] = c.UserID
 
-	if attr, ok := d.GetOk("config.0.auth_password"); ok {
-		if c.AuthPassword != "" {
-			configResult["auth_password"] = c.AuthPassword
+	// Secrets are write-only, they're kept in state only when the API returns them.
+	configResult["api_key"] = c.APIKey
+	configResult["auth_password"] = c.AuthPassword
+	configResult["service_key"] = c.ServiceKey
+	configResult["url"] = c.URL
+
+	if len(c.Headers) > 0 {
+		if _, ok := d.GetOk("config.0.headers_string"); ok {
+			h, err := json.Marshal(c.Headers)
+
+			if err != nil {
+				return nil, err
+			}
+
+			configResult["headers_string"] = string(h)
 		} else {
-			configResult["auth_password"] = attr.(string)
+			configResult["headers"] = c.Headers
 		}
 	}
 
-	if attr, ok := d.GetOk("config.0.api_key"); ok {
-		if c.APIKey != "" {
-			configResult["api_key"] = c.APIKey
-		} else {
-			configResult["api_key"] = attr.(string)
+	if _, ok := d.GetOk("config.0.payload"); ok {
+		configResult["payload"] = c.Payload
+	} else if _, ok := d.GetOk("config.0.payload_string"); ok {
+		h, err := json.Marshal(c.Payload)
+
+		if err != nil {
+			return nil, err
 		}
+
+		configResult["payload_string"] = string(h)
 	}
 
-	if attr, ok := d.GetOk("config.0.url"); ok {
-		if c.URL != "" {
-			configResult["url"] = c.URL
-		} else {
-			configResult["url"] = attr.(string)
-		}
+	return []interface{}{configResult}, nil
+}
+
+// alertChannelUpdateInputNames maps the types of the channels which can be
+// updated to their field of NerdGraph's notification channel update input.
+var alertChannelUpdateInputNames = map[string]string{
+	"email":     "email",
+	"opsgenie":  "opsGenie",
+	"pagerduty": "pagerDuty",
+	"slack":     "slack",
+	"victorops": "victorOps",
+	"webhook":   "webhook",
+}
+
+var alertChannelPayloadTypes = map[string]string{
+	"application/json":                  "JSON",
+	"application/x-www-form-urlencoded": "FORM",
+}
+
+// expandAlertChannelUpdateInput returns the NerdGraph notification channel
+// update input replacing the configuration of a channel with the given one.
+// The channel's secrets are left unchanged unless secrets is set.
+func expandAlertChannelUpdateInput(channel *alerts.Channel, secrets bool) (map[string]interface{}, error) {
+	name, ok := alertChannelUpdateInputNames[string(channel.Type)]
+	if !ok {
+		return nil, fmt.Errorf("alert channels of type %s can't be updated", channel.Type)
 	}
 
-	if attr, ok := d.GetOk("config.0.key"); ok {
-		if c.Key != "" {
-			configResult["key"] = c.Key
-		} else {
-			configResult["key"] = attr.(string)
+	c := channel.Configuration
+	input := map[string]interface{}{
+		"name": channel.Name,
+	}
+
+	switch channel.Type {
+	case "email":
+		input["emails"] = splitAlertChannelList(c.Recipients)
+
+		if c.IncludeJSONAttachment != "" {
+			includeJSON, err := strconv.ParseBool(c.IncludeJSONAttachment)
+			if err != nil {
+				return nil, err
+			}
+			input["includeJson"] = includeJSON
 		}
+	case "opsgenie":
+		if secrets {
+			input["apiKey"] = c.APIKey
+		}
+		input["recipients"] = splitAlertChannelList(c.Recipients)
+		input["tags"] = splitAlertChannelList(c.Tags)
+		input["teams"] = splitAlertChannelList(c.Teams)
+
+		if c.Region != "" {
+			input["dataCenterRegion"] = c.Region
+		}
+	case "pagerduty":
+		if secrets {
+			input["apiKey"] = c.ServiceKey
+		}
+	case "slack":
+		if secrets {
+			input["url"] = c.URL
+		}
+		input["teamChannel"] = c.Channel
+	case "victorops":
+		if secrets {
+			input["key"] = c.Key
+		}
+		input["routeKey"] = c.RouteKey
+	case "webhook":
+		input["baseUrl"] = c.BaseURL
+
+		if len(c.Payload) > 0 {
+			body, err := json.Marshal(c.Payload)
+			if err != nil {
+				return nil, err
+			}
+
+			input["customPayloadBody"] = string(body)
+			input["customPayloadType"] = alertChannelPayloadTypes[c.PayloadType]
+		}
+
+		if secrets && (c.AuthUsername != "" || c.AuthPassword != "") {
+			input["basicAuth"] = map[string]interface{}{
+				"username": c.AuthUsername,
+				"password": c.AuthPassword,
+			}
+		}
+
+		if !secrets {
+			break
+		}
+
+		keys := make([]string, 0, len(c.Headers))
+		for k := range c.Headers {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		headers := []interface{}{}
+		for _, k := range keys {
+			value, ok := c.Headers[k].(string)
+			if !ok {
+				v, err := json.Marshal(c.Headers[k])
+				if err != nil {
+					return nil, err
+				}
+				value = string(v)
+			}
+
+			headers = append(headers, map[string]interface{}{
+				"name":  k,
+				"value": value,
+			})
+		}
+		input["customHttpHeaders"] = headers
 	}
 
-	if attr, ok := d.GetOk("config.0.service_key"); ok {
-		if c.ServiceKey != "" {
-			configResult["service_key"] = c.ServiceKey
-		} else {
-			configResult["service_key"] = attr.(string)
+	return map[string]interface{}{name: input}, nil
+}
+
+// splitAlertChannelList splits the comma separated values of a channel's
+// configuration.
+func splitAlertChannelList(s string) []string {
+	values := []string{}
+
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
 		}
 	}
 
-	if _, ok := d.GetOk("config.0.headers"); ok {
-		configResult["headers"] = c.Headers
-	} else if _, ok := d.GetOk("config.0.headers_string"); ok {
-		h, err := json.Marshal(c.Headers)
+	return values
+}
 
-		if err != nil {
-			return nil, err
+// alertChannelSecretAttributes are the attributes of a channel's config which
+// the API doesn't return.
+var alertChannelSecretAttributes = []string{
+	"api_key",
+	"auth_password",
+	"headers",
+	"headers_string",
+	"key",
+	"service_key",
+	"url",
+}
+
+// alertChannelSecretHash returns the salted hash of the secrets of a channel's
+// config, in the format <salt>:<hash>.
+func alertChannelSecretHash(salt string, config map[string]interface{}) string {
+	h := sha256.New()
+	h.Write([]byte(salt))
+
+	for _, k := range alertChannelSecretAttributes {
+		var value string
+
+		switch v := config[k].(type) {
+		case string:
+			value = v
+		case map[string]interface{}:
+			if len(v) > 0 {
+				b, _ := json.Marshal(v)
+				value = string(b)
+			}
+		}
+
+		if k == "headers_string" {
+			value = stripWhitespace(value)
 		}
 
-		configResult["headers_string"] = string(h)
+		if value != "" {
+			fmt.Fprintf(h, "\x00%s=%s", k, value)
+		}
 	}
 
-	if _, ok := d.GetOk("config.0.payload"); ok {
-		configResult["payload"] = c.Payload
-	} else if _, ok := d.GetOk("config.0.payload_string"); ok {
-		h, err := json.Marshal(c.Payload)
+	return salt + ":" + hex.EncodeToString(h.Sum(nil))
+}
 
-		if err != nil {
-			return nil, err
-		}
+// alertChannelSecretSalt returns the salt of a secret hash.
+func alertChannelSecretSalt(secretHash string) string {
+	if i := strings.Index(secretHash, ":"); i > 0 {
+		return secretHash[:i]
+	}
 
-		configResult["payload_string"] = string(h)
+	return ""
+}
+
+func newAlertChannelSecretSalt() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
 	}
 
-	return []interface{}{configResult}, nil
+	return hex.EncodeToString(b), nil
+}
+
+// alertChannelConfig returns the map of a channel's config block.
+func alertChannelConfig(config interface{}) map[string]interface{} {
+	if l, ok := config.([]interface{}); ok && len(l) > 0 && l[0] != nil {
+		return l[0].(map[string]interface{})
+	}
+
+	return map[string]interface{}{}
 }
 
 func validateChannelConfiguration(config alerts.ChannelConfiguration) error {
@@ -236,3 +236,144 @@ func testFlattenAlertChannelConfig(t *testing.T, v interface{}, config alerts.Ch
 
 	}
 }
+
+func TestExpandAlertChannelUpdateInput(t *testing.T) {
+	cases := map[string]struct {
+		Channel  alerts.Channel
+		Secrets  bool
+		Expected map[string]interface{}
+		ErrorMsg string
+	}{
+		"email": {
+			Channel: alerts.Channel{
+				Name: "foo",
+				Type: "email",
+				Configuration: alerts.ChannelConfiguration{
+					Recipients:            "a@example.com, b@example.com",
+					IncludeJSONAttachment: "1",
+				},
+			},
+			Secrets: true,
+			Expected: map[string]interface{}{"email": map[string]interface{}{
+				"name":        "foo",
+				"emails":      []string{"a@example.com", "b@example.com"},
+				"includeJson": true,
+			}},
+		},
+		"pagerduty": {
+			Channel: alerts.Channel{
+				Name:          "foo",
+				Type:          "pagerduty",
+				Configuration: alerts.ChannelConfiguration{ServiceKey: "abc"},
+			},
+			Secrets: true,
+			Expected: map[string]interface{}{"pagerDuty": map[string]interface{}{
+				"name":   "foo",
+				"apiKey": "abc",
+			}},
+		},
+		"pagerduty without secrets": {
+			Channel: alerts.Channel{
+				Name:          "foo",
+				Type:          "pagerduty",
+				Configuration: alerts.ChannelConfiguration{},
+			},
+			Expected: map[string]interface{}{"pagerDuty": map[string]interface{}{
+				"name": "foo",
+			}},
+		},
+		"webhook without secrets": {
+			Channel: alerts.Channel{
+				Name: "foo",
+				Type: "webhook",
+				Configuration: alerts.ChannelConfiguration{
+					BaseURL:     "https://example.com",
+					Payload:     map[string]interface{}{"foo": "bar"},
+					PayloadType: "application/json",
+				},
+			},
+			Expected: map[string]interface{}{"webhook": map[string]interface{}{
+				"name":              "foo",
+				"baseUrl":           "https://example.com",
+				"customPayloadBody": `{"foo":"bar"}`,
+				"customPayloadType": "JSON",
+			}},
+		},
+		"webhook": {
+			Channel: alerts.Channel{
+				Name: "foo",
+				Type: "webhook",
+				Configuration: alerts.ChannelConfiguration{
+					BaseURL:     "https://example.com",
+					Headers:     map[string]interface{}{"b": "2", "a": map[string]interface{}{"c": "3"}},
+					Payload:     map[string]interface{}{"foo": "bar"},
+					PayloadType: "application/x-www-form-urlencoded",
+				},
+			},
+			Secrets: true,
+			Expected: map[string]interface{}{"webhook": map[string]interface{}{
+				"name":    "foo",
+				"baseUrl": "https://example.com",
+				"customHttpHeaders": []interface{}{
+					map[string]interface{}{"name": "a", "value": `{"c":"3"}`},
+					map[string]interface{}{"name": "b", "value": "2"},
+				},
+				"customPayloadBody": `{"foo":"bar"}`,
+				"customPayloadType": "FORM",
+			}},
+		},
+		"user": {
+			Channel: alerts.Channel{
+				Name:          "foo",
+				Type:          "user",
+				Configuration: alerts.ChannelConfiguration{UserID: "1"},
+			},
+			ErrorMsg: "alert channels of type user can't be updated",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			input, err := expandAlertChannelUpdateInput(&tc.Channel, tc.Secrets)
+
+			if tc.ErrorMsg != "" {
+				require.Error(t, err)
+				assert.Equal(t, tc.ErrorMsg, err.Error())
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.Expected, input)
+		})
+	}
+}
+
+func TestAlertChannelSecretHash(t *testing.T) {
+	config := map[string]interface{}{
+		"service_key": "abc",
+		"headers":     map[string]interface{}{"b": "2", "a": "1"},
+		"recipients":  "foo@example.com",
+	}
+
+	secretHash := alertChannelSecretHash("salt", config)
+
+	assert.Equal(t, "salt", alertChannelSecretSalt(secretHash))
+	assert.Equal(t, secretHash, alertChannelSecretHash("salt", map[string]interface{}{
+		"service_key": "abc",
+		"headers":     map[string]interface{}{"a": "1", "b": "2"},
+		"recipients":  "bar@example.com",
+	}), "only secrets are hashed")
+	assert.NotEqual(t, secretHash, alertChannelSecretHash("pepper", config))
+	assert.NotEqual(t, secretHash, alertChannelSecretHash("salt", map[string]interface{}{
+		"service_key": "abd",
+		"headers":     map[string]interface{}{"a": "1", "b": "2"},
+	}))
+	assert.NotContains(t, secretHash, "abc")
+
+	assert.Equal(t,
+		alertChannelSecretHash("salt", map[string]interface{}{"headers_string": `{"a": "1"}`}),
+		alertChannelSecretHash("salt", map[string]interface{}{"headers_string": `{"a":"1"}`}),
+	)
+
+	assert.Equal(t, "", alertChannelSecretSalt(""))
+}
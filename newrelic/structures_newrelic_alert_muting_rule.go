@@ -2,6 +2,8 @@ package newrelic
 
 import (
 	"fmt"
+	"reflect"
+	"sort"
 	"strings"
 	"time"
 
@@ -16,9 +18,7 @@ func expandMutingRuleCreateInput(d *schema.ResourceData) (alerts.MutingRuleCreat
 		Description: d.Get("description").(string),
 	}
 
-	if e, ok := d.GetOk("condition"); ok {
-		createInput.Condition = expandMutingRuleConditionGroup(e.([]interface{})[0].(map[string]interface{}))
-	}
+	createInput.Condition = expandMutingRuleConditions(d)
 
 	if e, ok := d.GetOk("schedule"); ok {
 		schedule, err := expandMutingRuleCreateSchedule(e.([]interface{})[0].(map[string]interface{}))
@@ -197,11 +197,8 @@ func expandMutingRuleUpdateInput(d *schema.ResourceData) (alerts.MutingRuleUpdat
 		Description: d.Get("description").(string),
 	}
 
-	if e, ok := d.GetOk("condition"); ok {
-		x := expandMutingRuleConditionGroup(e.([]interface{})[0].(map[string]interface{}))
-
-		updateInput.Condition = &x
-	}
+	condition := expandMutingRuleConditions(d)
+	updateInput.Condition = &condition
 
 	if e, ok := d.GetOk("schedule"); ok {
 		schedule, err := expandMutingRuleUpdateSchedule(e.([]interface{})[0].(map[string]interface{}))
@@ -214,6 +211,54 @@ func expandMutingRuleUpdateInput(d *schema.ResourceData) (alerts.MutingRuleUpdat
 	return updateInput, nil
 }
 
+// mutingRuleEntityGUIDAttribute is the attribute of the condition generated
+// for entity_guids, and mutingRuleTagAttributePrefix the prefix of the
+// attributes of the conditions generated for tags.
+const (
+	mutingRuleEntityGUIDAttribute = "entity.guid"
+	mutingRuleTagAttributePrefix  = "tag."
+)
+
+// expandMutingRuleConditions returns the condition group of a MutingRule, the
+// conditions of its condition block followed by the conditions matching its
+// entity_guids and tags. NerdGraph condition groups can't be nested, so they
+// all share the AND operator, see validateMutingRuleConditionDiff.
+func expandMutingRuleConditions(d *schema.ResourceData) alerts.MutingRuleConditionGroup {
+	conditionGroup := alerts.MutingRuleConditionGroup{Operator: "AND"}
+
+	if e, ok := d.GetOk("condition"); ok {
+		conditionGroup = expandMutingRuleConditionGroup(e.([]interface{})[0].(map[string]interface{}))
+	}
+
+	if e, ok := d.GetOk("entity_guids"); ok {
+		guids := expandMutingRuleValues(e.(*schema.Set).List())
+		sort.Strings(guids)
+
+		conditionGroup.Conditions = append(conditionGroup.Conditions, alerts.MutingRuleCondition{
+			Attribute: mutingRuleEntityGUIDAttribute,
+			Operator:  "IN",
+			Values:    guids,
+		})
+	}
+
+	tags := d.Get("tags").(map[string]interface{})
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		conditionGroup.Conditions = append(conditionGroup.Conditions, alerts.MutingRuleCondition{
+			Attribute: mutingRuleTagAttributePrefix + k,
+			Operator:  "EQUALS",
+			Values:    []string{tags[k].(string)},
+		})
+	}
+
+	return conditionGroup
+}
+
 func expandMutingRuleConditionGroup(cfg map[string]interface{}) alerts.MutingRuleConditionGroup {
 	conditionGroup := alerts.MutingRuleConditionGroup{}
 	var expandedConditions []alerts.MutingRuleCondition
@@ -267,10 +312,30 @@ func flattenMutingRule(mutingRule *alerts.MutingRule, d *schema.ResourceData) er
 	x := d.Get("condition")
 	configuredCondition := x.([]interface{})
 
+	var configuredConditions []interface{}
+	if len(configuredCondition) > 0 && configuredCondition[0] != nil {
+		configuredConditions = configuredCondition[0].(map[string]interface{})["conditions"].([]interface{})
+	}
+
+	entityGUIDs, tags, conditionGroup := flattenMutingRuleShorthands(mutingRule.Condition, configuredConditions)
+
 	_ = d.Set("enabled", mutingRule.Enabled)
-	err := d.Set("condition", flattenMutingRuleConditionGroup(mutingRule.Condition, configuredCondition))
-	if err != nil {
-		return nil
+
+	if err := d.Set("entity_guids", entityGUIDs); err != nil {
+		return err
+	}
+
+	if err := d.Set("tags", tags); err != nil {
+		return err
+	}
+
+	if len(conditionGroup.Conditions) > 0 || (len(entityGUIDs) == 0 && len(tags) == 0) {
+		err := d.Set("condition", flattenMutingRuleConditionGroup(conditionGroup, configuredCondition))
+		if err != nil {
+			return nil
+		}
+	} else {
+		_ = d.Set("condition", nil)
 	}
 
 	_ = d.Set("description", mutingRule.Description)
@@ -285,6 +350,48 @@ func flattenMutingRule(mutingRule *alerts.MutingRule, d *schema.ResourceData) er
 	return nil
 }
 
+// flattenMutingRuleShorthands collapses the conditions of a MutingRule that
+// entity_guids and tags expand to back into them, and returns the remaining
+// conditions. Conditions of the configured condition block are left in it, and
+// condition groups combined with OR are not collapsed.
+func flattenMutingRuleShorthands(in alerts.MutingRuleConditionGroup, configuredConditions []interface{}) ([]string, map[string]interface{}, alerts.MutingRuleConditionGroup) {
+	var entityGUIDs []string
+	tags := map[string]interface{}{}
+
+	if in.Operator != "AND" {
+		return entityGUIDs, tags, in
+	}
+
+	remaining := alerts.MutingRuleConditionGroup{Operator: in.Operator}
+
+	for _, c := range in.Conditions {
+		switch {
+		case mutingRuleConditionConfigured(c, configuredConditions):
+			remaining.Conditions = append(remaining.Conditions, c)
+		case c.Attribute == mutingRuleEntityGUIDAttribute && c.Operator == "IN" && entityGUIDs == nil:
+			entityGUIDs = c.Values
+		case strings.HasPrefix(c.Attribute, mutingRuleTagAttributePrefix) && c.Operator == "EQUALS" && len(c.Values) == 1 && tags[strings.TrimPrefix(c.Attribute, mutingRuleTagAttributePrefix)] == nil:
+			tags[strings.TrimPrefix(c.Attribute, mutingRuleTagAttributePrefix)] = c.Values[0]
+		default:
+			remaining.Conditions = append(remaining.Conditions, c)
+		}
+	}
+
+	return entityGUIDs, tags, remaining
+}
+
+func mutingRuleConditionConfigured(condition alerts.MutingRuleCondition, configuredConditions []interface{}) bool {
+	for _, raw := range configuredConditions {
+		configured := expandMutingRuleCondition(raw)
+
+		if configured.Attribute == condition.Attribute && configured.Operator == condition.Operator && reflect.DeepEqual(configured.Values, condition.Values) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func flattenMutingRuleConditionGroup(in alerts.MutingRuleConditionGroup, configuredCondition []interface{}) []map[string]interface{} {
 
 	condition := []map[string]interface{}{
@@ -25,3 +25,49 @@ func flattenAlertPolicy(policy *alerts.AlertsPolicy, d *schema.ResourceData, acc
 
 	return nil
 }
+
+// flattenAlertPolicyChannelIDs returns the channel IDs in state which are
+// still added to a policy, in the order of the state so that reordering them
+// doesn't show a diff.
+func flattenAlertPolicyChannelIDs(current []interface{}, channelIDs []int) []int {
+	added := make(map[int]bool, len(channelIDs))
+	for _, id := range channelIDs {
+		added[id] = true
+	}
+
+	flattened := make([]int, 0, len(current))
+
+	for _, id := range expandAlertChannelIDs(current) {
+		if added[id] {
+			flattened = append(flattened, id)
+		}
+	}
+
+	return flattened
+}
+
+// diffAlertPolicyChannelIDs returns the channels to add to and remove from a
+// policy to go from the old channel IDs to the new ones.
+func diffAlertPolicyChannelIDs(o []int, n []int) (added []int, removed []int) {
+	oldIDs := make(map[int]bool, len(o))
+	for _, id := range o {
+		oldIDs[id] = true
+	}
+
+	newIDs := make(map[int]bool, len(n))
+	for _, id := range n {
+		newIDs[id] = true
+
+		if !oldIDs[id] {
+			added = append(added, id)
+		}
+	}
+
+	for _, id := range o {
+		if !newIDs[id] {
+			removed = append(removed, id)
+		}
+	}
+
+	return added, removed
+}
@@ -0,0 +1,55 @@
+//go:build unit
+// +build unit
+
+package newrelic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlattenAlertPolicyChannelIDs(t *testing.T) {
+	cases := map[string]struct {
+		current    []interface{}
+		channelIDs []int
+		expected   []int
+	}{
+		"not managed": {
+			channelIDs: []int{3, 1, 2},
+			expected:   []int{},
+		},
+		"state order": {
+			current:    []interface{}{3, 1},
+			channelIDs: []int{1, 3},
+			expected:   []int{3, 1},
+		},
+		"removed": {
+			current:    []interface{}{3, 1},
+			channelIDs: []int{4, 3, 2},
+			expected:   []int{3},
+		},
+		"no channels": {
+			current:  []interface{}{1},
+			expected: []int{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, flattenAlertPolicyChannelIDs(tc.current, tc.channelIDs))
+		})
+	}
+}
+
+func TestDiffAlertPolicyChannelIDs(t *testing.T) {
+	added, removed := diffAlertPolicyChannelIDs([]int{1, 2, 3}, []int{3, 4, 1})
+
+	assert.Equal(t, []int{4}, added)
+	assert.Equal(t, []int{2}, removed)
+
+	added, removed = diffAlertPolicyChannelIDs([]int{1, 2}, []int{2, 1})
+
+	assert.Empty(t, added)
+	assert.Empty(t, removed)
+}
@@ -0,0 +1,200 @@
+package newrelic
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func expandNotificationProperties(cfg []interface{}) []map[string]interface{} {
+	properties := make([]map[string]interface{}, 0, len(cfg))
+
+	for _, rawCfg := range cfg {
+		cfg := rawCfg.(map[string]interface{})
+
+		property := map[string]interface{}{
+			"key":   cfg["key"],
+			"value": cfg["value"],
+		}
+
+		if label, ok := cfg["label"]; ok && label != "" {
+			property["label"] = label
+		}
+
+		if displayValue, ok := cfg["display_value"]; ok && displayValue != "" {
+			property["displayValue"] = displayValue
+		}
+
+		properties = append(properties, property)
+	}
+
+	return properties
+}
+
+func flattenNotificationProperties(properties []notificationProperty) []interface{} {
+	flattened := make([]interface{}, len(properties))
+
+	for i, p := range properties {
+		flattened[i] = map[string]interface{}{
+			"key":           p.Key,
+			"value":         p.Value,
+			"label":         p.Label,
+			"display_value": p.DisplayValue,
+		}
+	}
+
+	return flattened
+}
+
+// expandNotificationDestinationAuth returns the AiNotificationsCredentialsInput
+// of the destination, or nil when it has no credentials.
+func expandNotificationDestinationAuth(d *schema.ResourceData) map[string]interface{} {
+	if basic, ok := d.GetOk("auth_basic"); ok {
+		cfg := basic.([]interface{})[0].(map[string]interface{})
+
+		return map[string]interface{}{
+			"type": "BASIC",
+			"basic": map[string]interface{}{
+				"user":     cfg["user"],
+				"password": cfg["password"],
+			},
+		}
+	}
+
+	if token, ok := d.GetOk("auth_token"); ok {
+		cfg := token.([]interface{})[0].(map[string]interface{})
+
+		auth := map[string]interface{}{
+			"type": "TOKEN",
+			"token": map[string]interface{}{
+				"token": cfg["token"],
+			},
+		}
+
+		if prefix := cfg["prefix"].(string); prefix != "" {
+			auth["token"].(map[string]interface{})["prefix"] = prefix
+		}
+
+		return auth
+	}
+
+	return nil
+}
+
+func expandNotificationDestinationInput(d *schema.ResourceData) map[string]interface{} {
+	input := map[string]interface{}{
+		"name":       d.Get("name").(string),
+		"type":       d.Get("type").(string),
+		"properties": expandNotificationProperties(d.Get("property").(*schema.Set).List()),
+	}
+
+	if auth := expandNotificationDestinationAuth(d); auth != nil {
+		input["auth"] = auth
+	}
+
+	return input
+}
+
+// expandNotificationDestinationUpdate returns the AiNotificationsDestinationUpdate
+// of the destination. The credentials are only sent when they changed, as they
+// can't be read back.
+func expandNotificationDestinationUpdate(d *schema.ResourceData) map[string]interface{} {
+	input := map[string]interface{}{
+		"name":       d.Get("name").(string),
+		"active":     d.Get("active").(bool),
+		"properties": expandNotificationProperties(d.Get("property").(*schema.Set).List()),
+	}
+
+	if d.HasChanges("auth_basic", "auth_token") {
+		if auth := expandNotificationDestinationAuth(d); auth != nil {
+			input["auth"] = auth
+		} else {
+			input["disableAuth"] = true
+		}
+	}
+
+	return input
+}
+
+func flattenNotificationDestination(destination *notificationDestination, d *schema.ResourceData) error {
+	if err := d.Set("name", destination.Name); err != nil {
+		return err
+	}
+
+	if err := d.Set("type", destination.Type); err != nil {
+		return err
+	}
+
+	if err := d.Set("active", destination.Active); err != nil {
+		return err
+	}
+
+	if err := d.Set("property", flattenNotificationProperties(destination.Properties)); err != nil {
+		return err
+	}
+
+	// The password and token are write-only, they are kept from the state.
+	basic := []interface{}{}
+	token := []interface{}{}
+
+	if destination.Auth != nil {
+		switch destination.Auth.AuthType {
+		case "BASIC":
+			basic = append(basic, map[string]interface{}{
+				"user":     destination.Auth.User,
+				"password": d.Get("auth_basic.0.password").(string),
+			})
+		case "TOKEN":
+			token = append(token, map[string]interface{}{
+				"prefix": destination.Auth.Prefix,
+				"token":  d.Get("auth_token.0.token").(string),
+			})
+		}
+	}
+
+	if err := d.Set("auth_basic", basic); err != nil {
+		return err
+	}
+
+	return d.Set("auth_token", token)
+}
+
+func expandNotificationChannelInput(d *schema.ResourceData) map[string]interface{} {
+	return map[string]interface{}{
+		"name":          d.Get("name").(string),
+		"type":          d.Get("type").(string),
+		"product":       d.Get("product").(string),
+		"destinationId": d.Get("destination_id").(string),
+		"properties":    expandNotificationProperties(d.Get("property").(*schema.Set).List()),
+	}
+}
+
+func expandNotificationChannelUpdate(d *schema.ResourceData) map[string]interface{} {
+	return map[string]interface{}{
+		"name":       d.Get("name").(string),
+		"active":     d.Get("active").(bool),
+		"properties": expandNotificationProperties(d.Get("property").(*schema.Set).List()),
+	}
+}
+
+func flattenNotificationChannel(channel *notificationChannel, d *schema.ResourceData) error {
+	if err := d.Set("name", channel.Name); err != nil {
+		return err
+	}
+
+	if err := d.Set("type", channel.Type); err != nil {
+		return err
+	}
+
+	if err := d.Set("product", channel.Product); err != nil {
+		return err
+	}
+
+	if err := d.Set("destination_id", channel.DestinationID); err != nil {
+		return err
+	}
+
+	if err := d.Set("active", channel.Active); err != nil {
+		return err
+	}
+
+	return d.Set("property", flattenNotificationProperties(channel.Properties))
+}
@@ -147,13 +147,34 @@ func expandNrql(d *schema.ResourceData, condition alerts.NrqlConditionInput) (*a
 		nrql.EvaluationOffset = sv
 	} else if evalOffset, ok := d.GetOk("nrql.0.evaluation_offset"); ok {
 		nrql.EvaluationOffset = evalOffset.(int)
-	} else {
+	} else if !hasStreamingAggregation(d) {
 		return nil, fmt.Errorf("one of `since_value` or `evaluation_offset` must be configured for block `nrql`")
 	}
 
 	return &nrql, nil
 }
 
+func usesNrqlEvaluationOffset(d *schema.ResourceData) bool {
+	if _, ok := d.GetOk("nrql.0.since_value"); ok {
+		return true
+	}
+
+	_, ok := d.GetOk("nrql.0.evaluation_offset")
+
+	return ok
+}
+
+// Streaming aggregation replaces the evaluation offset of the query.
+func hasStreamingAggregation(d *schema.ResourceData) bool {
+	for _, attr := range []string{"aggregation_method", "aggregation_delay", "aggregation_timer"} {
+		if _, ok := d.GetOk(attr); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
 // NerdGraph
 func expandNrqlConditionTerm(term map[string]interface{}, conditionType, priority string) (*alerts.NrqlConditionTerm, error) {
 	var durationIn int
@@ -343,6 +364,68 @@ func expandSignal(d *schema.ResourceData) (*alerts.AlertsNrqlConditionSignal, er
 	return &signal, nil
 }
 
+// nrqlConditionStreamingSignal extends the client's signal with the streaming
+// aggregation settings, which newrelic-client-go does not model yet.
+type nrqlConditionStreamingSignal struct {
+	alerts.AlertsNrqlConditionSignal
+
+	AggregationMethod *string `json:"aggregationMethod,omitempty"`
+	AggregationDelay  *int    `json:"aggregationDelay,omitempty"`
+	AggregationTimer  *int    `json:"aggregationTimer,omitempty"`
+	SlideBy           *int    `json:"slideBy,omitempty"`
+}
+
+// NerdGraph
+// Returns nil when none of the streaming aggregation settings are configured.
+func expandStreamingSignal(d *schema.ResourceData) (*nrqlConditionStreamingSignal, error) {
+	var streaming nrqlConditionStreamingSignal
+	var configured bool
+
+	// The streaming settings are computed, so a condition evaluated with an
+	// offset may still carry the API defaults in state. Those must not be sent.
+	if usesNrqlEvaluationOffset(d) {
+		return nil, nil
+	}
+
+	if aggregationMethod, ok := d.GetOk("aggregation_method"); ok {
+		v := strings.ToUpper(aggregationMethod.(string))
+		streaming.AggregationMethod = &v
+		configured = true
+	}
+
+	// 0 is a valid aggregation delay
+	if aggregationDelay, ok := d.GetOkExists("aggregation_delay"); ok {
+		v := aggregationDelay.(int)
+		streaming.AggregationDelay = &v
+		configured = true
+	}
+
+	if aggregationTimer, ok := d.GetOk("aggregation_timer"); ok {
+		v := aggregationTimer.(int)
+		streaming.AggregationTimer = &v
+		configured = true
+	}
+
+	if slideBy, ok := d.GetOk("slide_by"); ok {
+		v := slideBy.(int)
+		streaming.SlideBy = &v
+		configured = true
+	}
+
+	if !configured {
+		return nil, nil
+	}
+
+	signal, err := expandSignal(d)
+	if err != nil {
+		return nil, err
+	}
+
+	streaming.AlertsNrqlConditionSignal = *signal
+
+	return &streaming, nil
+}
+
 // NerdGraph
 func flattenNrqlAlertCondition(accountID int, condition *alerts.NrqlAlertCondition, d *schema.ResourceData) error {
 	policyID, err := strconv.Atoi(condition.PolicyID)
@@ -481,6 +564,33 @@ func flattenSignal(d *schema.ResourceData, signal *alerts.AlertsNrqlConditionSig
 	return nil
 }
 
+// NerdGraph
+func flattenStreamingSignal(d *schema.ResourceData, signal *nrqlConditionStreamingSignal) error {
+	if signal == nil {
+		return nil
+	}
+
+	if signal.AggregationMethod != nil {
+		if err := d.Set("aggregation_method", strings.ToLower(*signal.AggregationMethod)); err != nil {
+			return fmt.Errorf("[DEBUG] Error setting nrql alert condition `aggregation_method`: %v", err)
+		}
+	}
+
+	if err := d.Set("aggregation_delay", signal.AggregationDelay); err != nil {
+		return fmt.Errorf("[DEBUG] Error setting nrql alert condition `aggregation_delay`: %v", err)
+	}
+
+	if err := d.Set("aggregation_timer", signal.AggregationTimer); err != nil {
+		return fmt.Errorf("[DEBUG] Error setting nrql alert condition `aggregation_timer`: %v", err)
+	}
+
+	if err := d.Set("slide_by", signal.SlideBy); err != nil {
+		return fmt.Errorf("[DEBUG] Error setting nrql alert condition `slide_by`: %v", err)
+	}
+
+	return nil
+}
+
 // NerdGraph
 func flattenNrql(nrql alerts.NrqlConditionQuery, configNrql map[string]interface{}) []interface{} {
 	out := map[string]interface{}{
@@ -13,14 +13,13 @@ import (
 	"github.com/newrelic/newrelic-client-go/pkg/nrdb"
 )
 
-// Assemble the *dashboards.DashboardInput struct.
+// Assemble the *dashboardInput struct.
 // Used by the newrelic_one_dashboard Create function.
-func expandDashboardInput(d *schema.ResourceData, meta interface{}) (*dashboards.DashboardInput, error) {
+func expandDashboardInput(d *schema.ResourceData, meta interface{}) (*dashboardInput, error) {
 	var err error
 
-	dash := dashboards.DashboardInput{
-		Name: d.Get("name").(string),
-	}
+	dash := dashboardInput{}
+	dash.Name = d.Get("name").(string)
 
 	dash.Pages, err = expandDashboardPageInput(d.Get("page").([]interface{}), meta)
 	if err != nil {
@@ -36,9 +35,60 @@ func expandDashboardInput(d *schema.ResourceData, meta interface{}) (*dashboards
 		dash.Description = e.(string)
 	}
 
+	dash.Variables = expandDashboardVariablesInput(d.Get("variable").([]interface{}), meta)
+
 	return &dash, nil
 }
 
+func expandDashboardVariablesInput(variables []interface{}, meta interface{}) []dashboardVariable {
+	expanded := make([]dashboardVariable, len(variables))
+
+	for i, v := range variables {
+		m := v.(map[string]interface{})
+
+		variable := dashboardVariable{
+			Name:                m["name"].(string),
+			Title:               m["title"].(string),
+			Type:                strings.ToUpper(m["type"].(string)),
+			IsMultiSelection:    m["is_multi_selection"].(bool),
+			ReplacementStrategy: strings.ToUpper(m["replacement_strategy"].(string)),
+		}
+
+		for _, value := range m["default_values"].([]interface{}) {
+			variable.DefaultValues = append(variable.DefaultValues, dashboardVariableDefaultItem{
+				Value: dashboardVariableDefaultValue{String: value.(string)},
+			})
+		}
+
+		for _, item := range m["item"].([]interface{}) {
+			it := item.(map[string]interface{})
+			variable.Items = append(variable.Items, dashboardVariableEnumItem{
+				Title: it["title"].(string),
+				Value: it["value"].(string),
+			})
+		}
+
+		if q := m["nrql_query"].([]interface{}); len(q) > 0 && q[0] != nil {
+			query := q[0].(map[string]interface{})
+			variable.NRQLQuery = &dashboardVariableNRQLQuery{
+				AccountIDs: expandIntList(query["account_ids"].([]interface{})),
+				Query:      query["query"].(string),
+			}
+
+			if len(variable.NRQLQuery.AccountIDs) == 0 {
+				defs := meta.(map[string]interface{})
+				if acct, ok := defs["account_id"]; ok {
+					variable.NRQLQuery.AccountIDs = []int{acct.(int)}
+				}
+			}
+		}
+
+		expanded[i] = variable
+	}
+
+	return expanded
+}
+
 // TODO: Reduce the cyclomatic complexity of this func
 // nolint:gocyclo
 func expandDashboardPageInput(pages []interface{}, meta interface{}) ([]dashboards.DashboardPageInput, error) {
@@ -46,6 +96,8 @@ func expandDashboardPageInput(pages []interface{}, meta interface{}) ([]dashboar
 		return []dashboards.DashboardPageInput{}, nil
 	}
 
+	defaults := meta.(map[string]interface{})
+
 	expanded := make([]dashboards.DashboardPageInput, len(pages))
 
 	for i, v := range pages {
@@ -67,19 +119,26 @@ func expandDashboardPageInput(pages []interface{}, meta interface{}) ([]dashboar
 			page.GUID = common.EntityGUID(guid.(string))
 		}
 
+		// Widgets filtering the current dashboard link to their page
+		pageMeta := map[string]interface{}{"page_guid": page.GUID}
+		for k, v := range defaults {
+			pageMeta[k] = v
+		}
+
 		// For each of the widget type, we need to expand them as well
 		if widgets, ok := p["widget_area"]; ok {
 			for _, v := range widgets.([]interface{}) {
 				// Get generic properties set
-				widget, err := expandDashboardWidgetInput(v.(map[string]interface{}), meta)
+				widget, err := expandDashboardWidgetInput(v.(map[string]interface{}), pageMeta)
 				if err != nil {
 					return nil, err
 				}
 
-				widget.Configuration.Area, err = expandDashboardAreaWidgetConfigurationInput(v.(map[string]interface{}), meta)
+				widget.RawConfiguration, err = expandDashboardWidgetRawConfigurationInput(v.(map[string]interface{}), pageMeta)
 				if err != nil {
 					return nil, err
 				}
+				widget.Visualization.ID = "viz.area"
 
 				page.Widgets = append(page.Widgets, widget)
 			}
@@ -87,15 +146,16 @@ func expandDashboardPageInput(pages []interface{}, meta interface{}) ([]dashboar
 		if widgets, ok := p["widget_bar"]; ok {
 			for _, v := range widgets.([]interface{}) {
 				// Get generic properties set
-				widget, err := expandDashboardWidgetInput(v.(map[string]interface{}), meta)
+				widget, err := expandDashboardWidgetInput(v.(map[string]interface{}), pageMeta)
 				if err != nil {
 					return nil, err
 				}
 
-				widget.Configuration.Bar, err = expandDashboardBarWidgetConfigurationInput(v.(map[string]interface{}), meta)
+				widget.RawConfiguration, err = expandDashboardWidgetRawConfigurationInput(v.(map[string]interface{}), pageMeta)
 				if err != nil {
 					return nil, err
 				}
+				widget.Visualization.ID = "viz.bar"
 
 				page.Widgets = append(page.Widgets, widget)
 			}
@@ -103,15 +163,16 @@ func expandDashboardPageInput(pages []interface{}, meta interface{}) ([]dashboar
 		if widgets, ok := p["widget_billboard"]; ok {
 			for _, v := range widgets.([]interface{}) {
 				// Get generic properties set
-				widget, err := expandDashboardWidgetInput(v.(map[string]interface{}), meta)
+				widget, err := expandDashboardWidgetInput(v.(map[string]interface{}), pageMeta)
 				if err != nil {
 					return nil, err
 				}
 
-				widget.Configuration.Billboard, err = expandDashboardBillboardWidgetConfigurationInput(v.(map[string]interface{}), meta)
+				widget.RawConfiguration, err = expandDashboardBillboardWidgetRawConfigurationInput(v.(map[string]interface{}), pageMeta)
 				if err != nil {
 					return nil, err
 				}
+				widget.Visualization.ID = "viz.billboard"
 
 				page.Widgets = append(page.Widgets, widget)
 			}
@@ -119,11 +180,11 @@ func expandDashboardPageInput(pages []interface{}, meta interface{}) ([]dashboar
 		if widgets, ok := p["widget_bullet"]; ok {
 			for _, v := range widgets.([]interface{}) {
 				// Get generic properties set
-				widget, err := expandDashboardWidgetInput(v.(map[string]interface{}), meta)
+				widget, err := expandDashboardWidgetInput(v.(map[string]interface{}), pageMeta)
 				if err != nil {
 					return nil, err
 				}
-				widget.RawConfiguration, err = expandDashboardBulletWidgetRawConfigurationInput(v.(map[string]interface{}), meta)
+				widget.RawConfiguration, err = expandDashboardBulletWidgetRawConfigurationInput(v.(map[string]interface{}), pageMeta)
 				if err != nil {
 					return nil, err
 				}
@@ -135,11 +196,11 @@ func expandDashboardPageInput(pages []interface{}, meta interface{}) ([]dashboar
 		if widgets, ok := p["widget_funnel"]; ok {
 			for _, v := range widgets.([]interface{}) {
 				// Get generic properties set
-				widget, err := expandDashboardWidgetInput(v.(map[string]interface{}), meta)
+				widget, err := expandDashboardWidgetInput(v.(map[string]interface{}), pageMeta)
 				if err != nil {
 					return nil, err
 				}
-				widget.RawConfiguration, err = expandDashboardFunnelWidgetRawConfigurationInput(v.(map[string]interface{}), meta)
+				widget.RawConfiguration, err = expandDashboardFunnelWidgetRawConfigurationInput(v.(map[string]interface{}), pageMeta)
 				if err != nil {
 					return nil, err
 				}
@@ -151,11 +212,11 @@ func expandDashboardPageInput(pages []interface{}, meta interface{}) ([]dashboar
 		if widgets, ok := p["widget_heatmap"]; ok {
 			for _, v := range widgets.([]interface{}) {
 				// Get generic properties set
-				widget, err := expandDashboardWidgetInput(v.(map[string]interface{}), meta)
+				widget, err := expandDashboardWidgetInput(v.(map[string]interface{}), pageMeta)
 				if err != nil {
 					return nil, err
 				}
-				widget.RawConfiguration, err = expandDashboardHeatmapWidgetRawConfigurationInput(v.(map[string]interface{}), meta)
+				widget.RawConfiguration, err = expandDashboardHeatmapWidgetRawConfigurationInput(v.(map[string]interface{}), pageMeta)
 				if err != nil {
 					return nil, err
 				}
@@ -167,11 +228,11 @@ func expandDashboardPageInput(pages []interface{}, meta interface{}) ([]dashboar
 		if widgets, ok := p["widget_histogram"]; ok {
 			for _, v := range widgets.([]interface{}) {
 				// Get generic properties set
-				widget, err := expandDashboardWidgetInput(v.(map[string]interface{}), meta)
+				widget, err := expandDashboardWidgetInput(v.(map[string]interface{}), pageMeta)
 				if err != nil {
 					return nil, err
 				}
-				widget.RawConfiguration, err = expandDashboardHistogramWidgetRawConfigurationInput(v.(map[string]interface{}), meta)
+				widget.RawConfiguration, err = expandDashboardHistogramWidgetRawConfigurationInput(v.(map[string]interface{}), pageMeta)
 				if err != nil {
 					return nil, err
 				}
@@ -183,15 +244,16 @@ func expandDashboardPageInput(pages []interface{}, meta interface{}) ([]dashboar
 		if widgets, ok := p["widget_line"]; ok {
 			for _, v := range widgets.([]interface{}) {
 				// Get generic properties set
-				widget, err := expandDashboardWidgetInput(v.(map[string]interface{}), meta)
+				widget, err := expandDashboardWidgetInput(v.(map[string]interface{}), pageMeta)
 				if err != nil {
 					return nil, err
 				}
 
-				widget.Configuration.Line, err = expandDashboardLineWidgetConfigurationInput(v.(map[string]interface{}), meta)
+				widget.RawConfiguration, err = expandDashboardWidgetRawConfigurationInput(v.(map[string]interface{}), pageMeta)
 				if err != nil {
 					return nil, err
 				}
+				widget.Visualization.ID = "viz.line"
 
 				page.Widgets = append(page.Widgets, widget)
 			}
@@ -199,12 +261,12 @@ func expandDashboardPageInput(pages []interface{}, meta interface{}) ([]dashboar
 		if widgets, ok := p["widget_markdown"]; ok {
 			for _, v := range widgets.([]interface{}) {
 				// Get generic properties set
-				widget, err := expandDashboardWidgetInput(v.(map[string]interface{}), meta)
+				widget, err := expandDashboardWidgetInput(v.(map[string]interface{}), pageMeta)
 				if err != nil {
 					return nil, err
 				}
 
-				widget.Configuration.Markdown, err = expandDashboardMarkdownWidgetConfigurationInput(v.(map[string]interface{}), meta)
+				widget.Configuration.Markdown, err = expandDashboardMarkdownWidgetConfigurationInput(v.(map[string]interface{}), pageMeta)
 				if err != nil {
 					return nil, err
 				}
@@ -215,15 +277,16 @@ func expandDashboardPageInput(pages []interface{}, meta interface{}) ([]dashboar
 		if widgets, ok := p["widget_pie"]; ok {
 			for _, v := range widgets.([]interface{}) {
 				// Get generic properties set
-				widget, err := expandDashboardWidgetInput(v.(map[string]interface{}), meta)
+				widget, err := expandDashboardWidgetInput(v.(map[string]interface{}), pageMeta)
 				if err != nil {
 					return nil, err
 				}
 
-				widget.Configuration.Pie, err = expandDashboardPieWidgetConfigurationInput(v.(map[string]interface{}), meta)
+				widget.RawConfiguration, err = expandDashboardWidgetRawConfigurationInput(v.(map[string]interface{}), pageMeta)
 				if err != nil {
 					return nil, err
 				}
+				widget.Visualization.ID = "viz.pie"
 
 				page.Widgets = append(page.Widgets, widget)
 			}
@@ -231,15 +294,16 @@ func expandDashboardPageInput(pages []interface{}, meta interface{}) ([]dashboar
 		if widgets, ok := p["widget_table"]; ok {
 			for _, v := range widgets.([]interface{}) {
 				// Get generic properties set
-				widget, err := expandDashboardWidgetInput(v.(map[string]interface{}), meta)
+				widget, err := expandDashboardWidgetInput(v.(map[string]interface{}), pageMeta)
 				if err != nil {
 					return nil, err
 				}
 
-				widget.Configuration.Table, err = expandDashboardTableWidgetConfigurationInput(v.(map[string]interface{}), meta)
+				widget.RawConfiguration, err = expandDashboardWidgetRawConfigurationInput(v.(map[string]interface{}), pageMeta)
 				if err != nil {
 					return nil, err
 				}
+				widget.Visualization.ID = "viz.table"
 
 				page.Widgets = append(page.Widgets, widget)
 			}
@@ -247,11 +311,11 @@ func expandDashboardPageInput(pages []interface{}, meta interface{}) ([]dashboar
 		if widgets, ok := p["widget_json"]; ok {
 			for _, v := range widgets.([]interface{}) {
 				// Get generic properties set
-				widget, err := expandDashboardWidgetInput(v.(map[string]interface{}), meta)
+				widget, err := expandDashboardWidgetInput(v.(map[string]interface{}), pageMeta)
 				if err != nil {
 					return nil, err
 				}
-				widget.RawConfiguration, err = expandDashboardJSONWidgetRawConfigurationInput(v.(map[string]interface{}), meta)
+				widget.RawConfiguration, err = expandDashboardJSONWidgetRawConfigurationInput(v.(map[string]interface{}), pageMeta)
 				if err != nil {
 					return nil, err
 				}
@@ -260,6 +324,61 @@ func expandDashboardPageInput(pages []interface{}, meta interface{}) ([]dashboar
 				page.Widgets = append(page.Widgets, widget)
 			}
 		}
+		if widgets, ok := p["widget_stacked_bar"]; ok {
+			for _, v := range widgets.([]interface{}) {
+				// Get generic properties set
+				widget, err := expandDashboardWidgetInput(v.(map[string]interface{}), pageMeta)
+				if err != nil {
+					return nil, err
+				}
+				widget.RawConfiguration, err = expandDashboardWidgetRawConfigurationInput(v.(map[string]interface{}), pageMeta)
+				if err != nil {
+					return nil, err
+				}
+				widget.Visualization.ID = "viz.stacked-bar"
+
+				page.Widgets = append(page.Widgets, widget)
+			}
+		}
+		for _, w := range dashboardQueriesOnlyWidgets {
+			widgets, ok := p[w.block]
+			if !ok {
+				continue
+			}
+
+			for _, v := range widgets.([]interface{}) {
+				// Get generic properties set
+				widget, err := expandDashboardWidgetInput(v.(map[string]interface{}), pageMeta)
+				if err != nil {
+					return nil, err
+				}
+				widget.RawConfiguration, err = expandDashboardQueriesOnlyWidgetRawConfigurationInput(v.(map[string]interface{}), pageMeta)
+				if err != nil {
+					return nil, err
+				}
+				widget.Visualization.ID = w.visualizationID
+
+				page.Widgets = append(page.Widgets, widget)
+			}
+		}
+		if widgets, ok := p["widget_passthrough"]; ok {
+			for _, v := range widgets.([]interface{}) {
+				// Get generic properties set
+				properties := v.(map[string]interface{})
+				widget, err := expandDashboardWidgetInput(properties, pageMeta)
+				if err != nil {
+					return nil, err
+				}
+				widget.RawConfiguration = entities.DashboardWidgetRawConfiguration(properties["configuration"].(string))
+				widget.Visualization.ID = properties["visualization_id"].(string)
+
+				page.Widgets = append(page.Widgets, widget)
+			}
+		}
+
+		if err := layoutDashboardWidgets(page.Widgets); err != nil {
+			return nil, err
+		}
 
 		expanded[i] = page
 	}
@@ -267,45 +386,192 @@ func expandDashboardPageInput(pages []interface{}, meta interface{}) ([]dashboar
 	return expanded, nil
 }
 
-func expandDashboardAreaWidgetConfigurationInput(i map[string]interface{}, meta interface{}) (*dashboards.DashboardAreaWidgetConfigurationInput, error) {
-	var cfg dashboards.DashboardAreaWidgetConfigurationInput
-	var err error
+// layoutDashboardWidgets places the widgets of a page without a row or a
+// column in the first free cells of the grid, from left to right and top to
+// bottom, after the widgets with a position.
+func layoutDashboardWidgets(widgets []dashboards.DashboardWidgetInput) error {
+	var placed []dashboards.DashboardWidgetLayoutInput
+	for _, w := range widgets {
+		if w.Layout.Row > 0 && w.Layout.Column > 0 {
+			placed = append(placed, w.Layout)
+		}
+	}
 
-	// just has queries
-	if q, ok := i["nrql_query"]; ok {
-		cfg.NRQLQueries, err = expandDashboardWidgetNRQLQueryInput(q.([]interface{}), meta)
-		if err != nil {
-			return nil, err
+	for i := range widgets {
+		layout := &widgets[i].Layout
+		if layout.Row > 0 && layout.Column > 0 {
+			continue
 		}
-		return &cfg, nil
+
+		if !placeDashboardWidget(layout, placed) {
+			return fmt.Errorf("no room left in row %d for widget %q", layout.Row, widgets[i].Title)
+		}
+		placed = append(placed, *layout)
 	}
-	return nil, nil
+
+	return nil
 }
-func expandDashboardBarWidgetConfigurationInput(i map[string]interface{}, meta interface{}) (*dashboards.DashboardBarWidgetConfigurationInput, error) {
-	var cfg dashboards.DashboardBarWidgetConfigurationInput
-	var err error
 
-	// just has queries
-	if q, ok := i["nrql_query"]; ok {
-		cfg.NRQLQueries, err = expandDashboardWidgetNRQLQueryInput(q.([]interface{}), meta)
-		if err != nil {
-			return nil, err
+// placeDashboardWidget sets the missing row and column of a widget to the
+// first free cells of the grid, keeping the row or the column it has. It
+// returns false when the widget has a row with no room left.
+func placeDashboardWidget(layout *dashboards.DashboardWidgetLayoutInput, placed []dashboards.DashboardWidgetLayoutInput) bool {
+	row, column := layout.Row, layout.Column
+
+	for r := 1; ; r++ {
+		if row > 0 {
+			r = row
+		}
+
+		for c := 1; c+layout.Width-1 <= dashboardGridColumns; c++ {
+			if column > 0 {
+				c = column
+			}
+
+			layout.Row, layout.Column = r, c
+			if !overlapsDashboardWidgets(*layout, placed) {
+				return true
+			}
+
+			if column > 0 {
+				break
+			}
+		}
+
+		if row > 0 {
+			layout.Row, layout.Column = row, 0
+			return false
 		}
-		return &cfg, nil
 	}
-	return nil, nil
 }
 
-func expandDashboardBillboardWidgetConfigurationInput(i map[string]interface{}, meta interface{}) (*dashboards.DashboardBillboardWidgetConfigurationInput, error) {
-	var cfg dashboards.DashboardBillboardWidgetConfigurationInput
-	var err error
+// unsetDashboardWidgetPositions drops the row and the column the API returns
+// for the widgets which have none in the resource data, so that they are placed
+// by layoutDashboardWidgets again on every apply instead of being validated as
+// positions of the configuration. Widgets are matched by their index in their
+// block, as their IDs are unknown until created.
+func unsetDashboardWidgetPositions(pages []interface{}, d *schema.ResourceData) {
+	current := d.Get("page").([]interface{})
+
+	for i, p := range pages {
+		if i >= len(current) || current[i] == nil {
+			continue
+		}
 
-	if q, ok := i["nrql_query"]; ok {
-		cfg.NRQLQueries, err = expandDashboardWidgetNRQLQueryInput(q.([]interface{}), meta)
-		if err != nil {
-			return nil, err
+		page := p.(map[string]interface{})
+		currentPage := current[i].(map[string]interface{})
+
+		for widgetType, widgets := range page {
+			if !strings.HasPrefix(widgetType, "widget") {
+				continue
+			}
+
+			currentWidgets, _ := currentPage[widgetType].([]interface{})
+
+			for j, w := range widgets.([]interface{}) {
+				if j >= len(currentWidgets) || currentWidgets[j] == nil {
+					continue
+				}
+
+				widget := w.(map[string]interface{})
+				currentWidget := currentWidgets[j].(map[string]interface{})
+
+				for _, k := range []string{"row", "column"} {
+					if currentWidget[k] == 0 {
+						widget[k] = 0
+					}
+				}
+			}
 		}
 	}
+}
+
+func overlapsDashboardWidgets(layout dashboards.DashboardWidgetLayoutInput, placed []dashboards.DashboardWidgetLayoutInput) bool {
+	for _, p := range placed {
+		if dashboardWidgetsOverlap(layout, p) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// dashboardWidgetsOverlap returns whether two widgets share a cell of the grid.
+func dashboardWidgetsOverlap(a, b dashboards.DashboardWidgetLayoutInput) bool {
+	return a.Column < b.Column+b.Width && b.Column < a.Column+a.Width &&
+		a.Row < b.Row+b.Height && b.Row < a.Row+a.Height
+}
+
+// dashboardWidgetRawConfiguration is the raw configuration of the widgets
+// with display settings, which their typed configuration can't hold.
+type dashboardWidgetRawConfiguration struct {
+	NRQLQueries []dashboards.DashboardWidgetNRQLQueryInput          `json:"nrqlQueries"`
+	Thresholds  []dashboards.DashboardBillboardWidgetThresholdInput `json:"thresholds,omitempty"`
+	YAxisLeft   *dashboardWidgetYAxisLeft                           `json:"yAxisLeft,omitempty"`
+	NullValues  *dashboardWidgetNullValues                          `json:"nullValues,omitempty"`
+	Units       *dashboardWidgetUnits                               `json:"units,omitempty"`
+	Colors      *dashboardWidgetColors                              `json:"colors,omitempty"`
+	Legend      *dashboardWidgetLegend                              `json:"legend,omitempty"`
+	Facet       *dashboardWidgetFacet                               `json:"facet,omitempty"`
+}
+
+type dashboardWidgetYAxisLeft struct {
+	Min float64 `json:"min"`
+	Max float64 `json:"max"`
+}
+
+type dashboardWidgetNullValues struct {
+	NullValue       string                                    `json:"nullValue,omitempty"`
+	SeriesOverrides []dashboardWidgetNullValuesSeriesOverride `json:"seriesOverrides,omitempty"`
+}
+
+type dashboardWidgetNullValuesSeriesOverride struct {
+	NullValue  string `json:"nullValue"`
+	SeriesName string `json:"seriesName"`
+}
+
+type dashboardWidgetUnits struct {
+	Unit            string                               `json:"unit,omitempty"`
+	SeriesOverrides []dashboardWidgetUnitsSeriesOverride `json:"seriesOverrides,omitempty"`
+}
+
+type dashboardWidgetUnitsSeriesOverride struct {
+	Unit       string `json:"unit"`
+	SeriesName string `json:"seriesName"`
+}
+
+type dashboardWidgetColors struct {
+	Color           string                                `json:"color,omitempty"`
+	SeriesOverrides []dashboardWidgetColorsSeriesOverride `json:"seriesOverrides,omitempty"`
+}
+
+type dashboardWidgetColorsSeriesOverride struct {
+	Color      string `json:"color"`
+	SeriesName string `json:"seriesName"`
+}
+
+type dashboardWidgetLegend struct {
+	Enabled bool `json:"enabled"`
+}
+
+type dashboardWidgetFacet struct {
+	ShowOtherSeries bool `json:"showOtherSeries"`
+}
+
+func expandDashboardWidgetRawConfigurationInput(i map[string]interface{}, meta interface{}) ([]byte, error) {
+	cfg, err := expandDashboardWidgetRawConfiguration(i, meta)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(cfg)
+}
+
+func expandDashboardBillboardWidgetRawConfigurationInput(i map[string]interface{}, meta interface{}) ([]byte, error) {
+	cfg, err := expandDashboardWidgetRawConfiguration(i, meta)
+	if err != nil {
+		return nil, err
+	}
 
 	// optional, order is important (API returns them sorted alpha)
 	cfg.Thresholds = []dashboards.DashboardBillboardWidgetThresholdInput{}
@@ -323,6 +589,80 @@ func expandDashboardBillboardWidgetConfigurationInput(i map[string]interface{},
 		})
 	}
 
+	return json.Marshal(cfg)
+}
+
+// expandDashboardWidgetRawConfiguration expands the queries and the display
+// settings supported by the widget, which are the ones in its schema.
+func expandDashboardWidgetRawConfiguration(i map[string]interface{}, meta interface{}) (*dashboardWidgetRawConfiguration, error) {
+	var cfg dashboardWidgetRawConfiguration
+	var err error
+
+	if q, ok := i["nrql_query"]; ok {
+		cfg.NRQLQueries, err = expandDashboardWidgetNRQLQueryInput(q.([]interface{}), meta)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if y, ok := i["y_axis_left"]; ok && len(y.([]interface{})) > 0 {
+		axis := y.([]interface{})[0].(map[string]interface{})
+		cfg.YAxisLeft = &dashboardWidgetYAxisLeft{
+			Min: axis["min"].(float64),
+			Max: axis["max"].(float64),
+		}
+	}
+
+	if n, ok := i["null_values"]; ok && len(n.([]interface{})) > 0 {
+		cfg.NullValues = &dashboardWidgetNullValues{}
+		if values, ok := n.([]interface{})[0].(map[string]interface{}); ok {
+			cfg.NullValues.NullValue = values["null_value"].(string)
+			for _, o := range values["series_override"].([]interface{}) {
+				override := o.(map[string]interface{})
+				cfg.NullValues.SeriesOverrides = append(cfg.NullValues.SeriesOverrides, dashboardWidgetNullValuesSeriesOverride{
+					NullValue:  override["null_value"].(string),
+					SeriesName: override["series_name"].(string),
+				})
+			}
+		}
+	}
+
+	if u, ok := i["units"]; ok && len(u.([]interface{})) > 0 {
+		cfg.Units = &dashboardWidgetUnits{}
+		if units, ok := u.([]interface{})[0].(map[string]interface{}); ok {
+			cfg.Units.Unit = units["unit"].(string)
+			for _, o := range units["series_override"].([]interface{}) {
+				override := o.(map[string]interface{})
+				cfg.Units.SeriesOverrides = append(cfg.Units.SeriesOverrides, dashboardWidgetUnitsSeriesOverride{
+					Unit:       override["unit"].(string),
+					SeriesName: override["series_name"].(string),
+				})
+			}
+		}
+	}
+
+	if c, ok := i["colors"]; ok && len(c.([]interface{})) > 0 {
+		cfg.Colors = &dashboardWidgetColors{}
+		if colors, ok := c.([]interface{})[0].(map[string]interface{}); ok {
+			cfg.Colors.Color = colors["color"].(string)
+			for _, o := range colors["series_override"].([]interface{}) {
+				override := o.(map[string]interface{})
+				cfg.Colors.SeriesOverrides = append(cfg.Colors.SeriesOverrides, dashboardWidgetColorsSeriesOverride{
+					Color:      override["color"].(string),
+					SeriesName: override["series_name"].(string),
+				})
+			}
+		}
+	}
+
+	if l, ok := i["legend_enabled"]; ok {
+		cfg.Legend = &dashboardWidgetLegend{Enabled: l.(bool)}
+	}
+
+	if f, ok := i["facet_show_other_series"]; ok {
+		cfg.Facet = &dashboardWidgetFacet{ShowOtherSeries: f.(bool)}
+	}
+
 	return &cfg, nil
 }
 
@@ -412,19 +752,22 @@ func expandDashboardJSONWidgetRawConfigurationInput(i map[string]interface{}, me
 	return json.Marshal(cfg)
 }
 
-func expandDashboardLineWidgetConfigurationInput(i map[string]interface{}, meta interface{}) (*dashboards.DashboardLineWidgetConfigurationInput, error) {
-	var cfg dashboards.DashboardLineWidgetConfigurationInput
+// expandDashboardQueriesOnlyWidgetRawConfigurationInput expands the raw
+// configuration of the widgets in dashboardQueriesOnlyWidgets.
+func expandDashboardQueriesOnlyWidgetRawConfigurationInput(i map[string]interface{}, meta interface{}) ([]byte, error) {
 	var err error
+	cfg := struct {
+		NRQLQueries []dashboards.DashboardWidgetNRQLQueryInput `json:"nrqlQueries"`
+	}{}
 
-	// just has queries
 	if q, ok := i["nrql_query"]; ok {
 		cfg.NRQLQueries, err = expandDashboardWidgetNRQLQueryInput(q.([]interface{}), meta)
 		if err != nil {
 			return nil, err
 		}
-		return &cfg, nil
 	}
-	return nil, nil
+
+	return json.Marshal(cfg)
 }
 
 func expandDashboardMarkdownWidgetConfigurationInput(i map[string]interface{}, meta interface{}) (*dashboards.DashboardMarkdownWidgetConfigurationInput, error) {
@@ -439,34 +782,6 @@ func expandDashboardMarkdownWidgetConfigurationInput(i map[string]interface{}, m
 	}
 	return nil, nil
 }
-func expandDashboardPieWidgetConfigurationInput(i map[string]interface{}, meta interface{}) (*dashboards.DashboardPieWidgetConfigurationInput, error) {
-	var cfg dashboards.DashboardPieWidgetConfigurationInput
-	var err error
-
-	// just has queries
-	if q, ok := i["nrql_query"]; ok {
-		cfg.NRQLQueries, err = expandDashboardWidgetNRQLQueryInput(q.([]interface{}), meta)
-		if err != nil {
-			return nil, err
-		}
-		return &cfg, nil
-	}
-	return nil, nil
-}
-func expandDashboardTableWidgetConfigurationInput(i map[string]interface{}, meta interface{}) (*dashboards.DashboardTableWidgetConfigurationInput, error) {
-	var cfg dashboards.DashboardTableWidgetConfigurationInput
-	var err error
-
-	// just has queries
-	if q, ok := i["nrql_query"]; ok {
-		cfg.NRQLQueries, err = expandDashboardWidgetNRQLQueryInput(q.([]interface{}), meta)
-		if err != nil {
-			return nil, err
-		}
-		return &cfg, nil
-	}
-	return nil, nil
-}
 
 // expandDashboardWidgetInput expands the common items in WidgetInput, but not the configuration
 // which is specific to the widgets
@@ -496,6 +811,14 @@ func expandDashboardWidgetInput(w map[string]interface{}, meta interface{}) (das
 		widget.LinkedEntityGUIDs = expandLinkedEntityGUIDs(i.([]interface{}))
 	}
 
+	// The page is only linked once it exists
+	if i, ok := w["filter_current_dashboard"]; ok && i.(bool) {
+		defs := meta.(map[string]interface{})
+		if guid, ok := defs["page_guid"]; ok && guid.(common.EntityGUID) != "" {
+			widget.LinkedEntityGUIDs = append(widget.LinkedEntityGUIDs, guid.(common.EntityGUID))
+		}
+	}
+
 	return widget, nil
 }
 
@@ -544,7 +867,7 @@ func expandDashboardWidgetNRQLQueryInput(queries []interface{}, meta interface{}
 // Unpack the *dashboards.Dashboard variable and set resource data.
 //
 // Used by the newrelic_one_dashboard Read function (resourceNewRelicOneDashboardRead)
-func flattenDashboardEntity(dashboard *entities.DashboardEntity, d *schema.ResourceData) error {
+func flattenDashboardEntity(dashboard *entities.DashboardEntity, variables []dashboardVariable, d *schema.ResourceData) error {
 	_ = d.Set("account_id", dashboard.AccountID)
 	_ = d.Set("guid", dashboard.GUID)
 	_ = d.Set("name", dashboard.Name)
@@ -557,18 +880,20 @@ func flattenDashboardEntity(dashboard *entities.DashboardEntity, d *schema.Resou
 
 	if dashboard.Pages != nil && len(dashboard.Pages) > 0 {
 		pages := flattenDashboardPage(&dashboard.Pages)
+		unsetDashboardWidgetPositions(pages, d)
 		if err := d.Set("page", pages); err != nil {
 			return err
 		}
 	}
 
-	return nil
+	return d.Set("variable", flattenDashboardVariables(variables))
 }
 
 // Unpack the *dashboards.Dashboard variable and set resource data.
 //
 // Used by the newrelic_one_dashboard Read function (resourceNewRelicOneDashboardRead)
-func flattenDashboardUpdateResult(result *dashboards.DashboardUpdateResult, d *schema.ResourceData) error {
+// The update result does not include the variables, those sent are set instead.
+func flattenDashboardUpdateResult(result *dashboards.DashboardUpdateResult, variables []dashboardVariable, d *schema.ResourceData) error {
 	if result == nil {
 		return fmt.Errorf("can not flatten nil DashboardUpdateResult")
 	}
@@ -587,17 +912,65 @@ func flattenDashboardUpdateResult(result *dashboards.DashboardUpdateResult, d *s
 
 	if dashboard.Pages != nil && len(dashboard.Pages) > 0 {
 		pages := flattenDashboardPage(&dashboard.Pages)
+		unsetDashboardWidgetPositions(pages, d)
 		if err := d.Set("page", pages); err != nil {
 			return err
 		}
 	}
 
-	return nil
+	return d.Set("variable", flattenDashboardVariables(variables))
+}
+
+func flattenDashboardVariables(in []dashboardVariable) []interface{} {
+	out := make([]interface{}, len(in))
+
+	for i, v := range in {
+		m := map[string]interface{}{
+			"name":                 v.Name,
+			"title":                v.Title,
+			"type":                 strings.ToLower(v.Type),
+			"is_multi_selection":   v.IsMultiSelection,
+			"replacement_strategy": strings.ToLower(v.ReplacementStrategy),
+		}
+
+		if len(v.DefaultValues) > 0 {
+			values := make([]interface{}, len(v.DefaultValues))
+			for j, value := range v.DefaultValues {
+				values[j] = value.Value.String
+			}
+			m["default_values"] = values
+		}
+
+		if len(v.Items) > 0 {
+			items := make([]interface{}, len(v.Items))
+			for j, item := range v.Items {
+				items[j] = map[string]interface{}{
+					"title": item.Title,
+					"value": item.Value,
+				}
+			}
+			m["item"] = items
+		}
+
+		if v.NRQLQuery != nil {
+			m["nrql_query"] = []interface{}{
+				map[string]interface{}{
+					"account_ids": v.NRQLQuery.AccountIDs,
+					"query":       v.NRQLQuery.Query,
+				},
+			}
+		}
+
+		out[i] = m
+	}
+
+	return out
 }
 
 // return []interface{} because Page is a SetList
 func flattenDashboardPage(in *[]entities.DashboardPage) []interface{} {
 	out := make([]interface{}, len(*in))
+	widgetSchemas := dashboardPageSchemaElem().Schema
 
 	for i, p := range *in {
 		m := make(map[string]interface{})
@@ -610,9 +983,17 @@ func flattenDashboardPage(in *[]entities.DashboardPage) []interface{} {
 		}
 
 		for _, widget := range p.Widgets {
-			widgetType, w := flattenDashboardWidget(&widget)
+			widgetType, w := flattenDashboardWidget(&widget, p.GUID)
 
 			if widgetType != "" {
+				// Only keep the settings supported by the widget type
+				widgetSchema := widgetSchemas[widgetType].Elem.(*schema.Resource).Schema
+				for k := range w {
+					if _, ok := widgetSchema[k]; !ok {
+						delete(w, k)
+					}
+				}
+
 				if _, ok := m[widgetType]; !ok {
 					m[widgetType] = []interface{}{}
 				}
@@ -638,7 +1019,7 @@ func flattenLinkedEntityGUIDs(linkedEntities []entities.EntityOutlineInterface)
 }
 
 // nolint:gocyclo
-func flattenDashboardWidget(in *entities.DashboardWidget) (string, map[string]interface{}) {
+func flattenDashboardWidget(in *entities.DashboardWidget, pageGUID common.EntityGUID) (string, map[string]interface{}) {
 	var widgetType string
 	out := make(map[string]interface{})
 
@@ -654,26 +1035,58 @@ func flattenDashboardWidget(in *entities.DashboardWidget) (string, map[string]in
 	// NOTE: The widget types that currently support linked entities
 	// are faceted widgets - i.e. bar, line, pie
 	if len(in.LinkedEntities) > 0 {
-		out["linked_entity_guids"] = flattenLinkedEntityGUIDs(in.LinkedEntities)
+		var guids []string
+		for _, guid := range flattenLinkedEntityGUIDs(in.LinkedEntities) {
+			if guid == string(pageGUID) {
+				out["filter_current_dashboard"] = true
+				continue
+			}
+			guids = append(guids, guid)
+		}
+		if len(guids) > 0 {
+			out["linked_entity_guids"] = guids
+		}
+	}
+
+	if block, ok := dashboardQueriesOnlyWidgetBlock(in.Visualization.ID); ok {
+		if len(in.RawConfiguration) > 0 {
+			cfg := struct {
+				NRQLQueries []entities.DashboardWidgetNRQLQuery `json:"nrqlQueries"`
+			}{}
+			if err := json.Unmarshal(in.RawConfiguration, &cfg); err == nil {
+				out["nrql_query"] = flattenDashboardWidgetNRQLQuery(&cfg.NRQLQueries)
+			}
+		}
+
+		return block, out
 	}
 
 	switch in.Visualization.ID {
 	case "viz.area":
 		widgetType = "widget_area"
-		if len(in.Configuration.Area.NRQLQueries) > 0 {
+		if cfg := flattenDashboardWidgetRawConfiguration(in.RawConfiguration, out); cfg == nil && len(in.Configuration.Area.NRQLQueries) > 0 {
 			out["nrql_query"] = flattenDashboardWidgetNRQLQuery(&in.Configuration.Area.NRQLQueries)
 		}
 	case "viz.bar":
 		widgetType = "widget_bar"
-		if len(in.Configuration.Bar.NRQLQueries) > 0 {
+		if cfg := flattenDashboardWidgetRawConfiguration(in.RawConfiguration, out); cfg == nil && len(in.Configuration.Bar.NRQLQueries) > 0 {
 			out["nrql_query"] = flattenDashboardWidgetNRQLQuery(&in.Configuration.Bar.NRQLQueries)
 		}
 	case "viz.billboard":
 		widgetType = "widget_billboard"
-		if len(in.Configuration.Billboard.NRQLQueries) > 0 {
-			out["nrql_query"] = flattenDashboardWidgetNRQLQuery(&in.Configuration.Billboard.NRQLQueries)
-		}
-		if len(in.Configuration.Billboard.Thresholds) > 0 {
+		if cfg := flattenDashboardWidgetRawConfiguration(in.RawConfiguration, out); cfg != nil {
+			for _, v := range cfg.Thresholds {
+				switch v.AlertSeverity {
+				case entities.DashboardAlertSeverityTypes.CRITICAL:
+					out["critical"] = v.Value
+				case entities.DashboardAlertSeverityTypes.WARNING:
+					out["warning"] = v.Value
+				}
+			}
+		} else {
+			if len(in.Configuration.Billboard.NRQLQueries) > 0 {
+				out["nrql_query"] = flattenDashboardWidgetNRQLQuery(&in.Configuration.Billboard.NRQLQueries)
+			}
 			for _, v := range in.Configuration.Billboard.Thresholds {
 				switch v.AlertSeverity {
 				case entities.DashboardAlertSeverityTypes.CRITICAL:
@@ -737,7 +1150,7 @@ func flattenDashboardWidget(in *entities.DashboardWidget) (string, map[string]in
 		}
 	case "viz.line":
 		widgetType = "widget_line"
-		if len(in.Configuration.Line.NRQLQueries) > 0 {
+		if cfg := flattenDashboardWidgetRawConfiguration(in.RawConfiguration, out); cfg == nil && len(in.Configuration.Line.NRQLQueries) > 0 {
 			out["nrql_query"] = flattenDashboardWidgetNRQLQuery(&in.Configuration.Line.NRQLQueries)
 		}
 	case "viz.markdown":
@@ -747,19 +1160,109 @@ func flattenDashboardWidget(in *entities.DashboardWidget) (string, map[string]in
 		}
 	case "viz.pie":
 		widgetType = "widget_pie"
-		if len(in.Configuration.Pie.NRQLQueries) > 0 {
+		if cfg := flattenDashboardWidgetRawConfiguration(in.RawConfiguration, out); cfg == nil && len(in.Configuration.Pie.NRQLQueries) > 0 {
 			out["nrql_query"] = flattenDashboardWidgetNRQLQuery(&in.Configuration.Pie.NRQLQueries)
 		}
 	case "viz.table":
 		widgetType = "widget_table"
-		if len(in.Configuration.Table.NRQLQueries) > 0 {
+		if cfg := flattenDashboardWidgetRawConfiguration(in.RawConfiguration, out); cfg == nil && len(in.Configuration.Table.NRQLQueries) > 0 {
 			out["nrql_query"] = flattenDashboardWidgetNRQLQuery(&in.Configuration.Table.NRQLQueries)
 		}
+	case "viz.stacked-bar":
+		widgetType = "widget_stacked_bar"
+		flattenDashboardWidgetRawConfiguration(in.RawConfiguration, out)
+	default:
+		// Visualizations without a block of their own are kept as they are
+		// instead of being dropped.
+		if in.Visualization.ID != "" {
+			widgetType = "widget_passthrough"
+			out["visualization_id"] = in.Visualization.ID
+			out["configuration"] = string(in.RawConfiguration)
+		}
 	}
 
 	return widgetType, out
 }
 
+// flattenDashboardWidgetRawConfiguration sets the queries and the display
+// settings of a widget from its raw configuration, which is returned unless
+// the widget has none.
+func flattenDashboardWidgetRawConfiguration(in entities.DashboardWidgetRawConfiguration, out map[string]interface{}) *dashboardWidgetRawConfiguration {
+	var cfg *dashboardWidgetRawConfiguration
+	if err := json.Unmarshal(in, &cfg); err != nil || cfg == nil {
+		return nil
+	}
+
+	queries := make([]entities.DashboardWidgetNRQLQuery, len(cfg.NRQLQueries))
+	for i, q := range cfg.NRQLQueries {
+		queries[i] = entities.DashboardWidgetNRQLQuery(q)
+	}
+	out["nrql_query"] = flattenDashboardWidgetNRQLQuery(&queries)
+
+	if cfg.YAxisLeft != nil {
+		out["y_axis_left"] = []interface{}{
+			map[string]interface{}{
+				"min": cfg.YAxisLeft.Min,
+				"max": cfg.YAxisLeft.Max,
+			},
+		}
+	}
+
+	if cfg.NullValues != nil {
+		overrides := make([]interface{}, len(cfg.NullValues.SeriesOverrides))
+		for i, o := range cfg.NullValues.SeriesOverrides {
+			overrides[i] = map[string]interface{}{
+				"null_value":  o.NullValue,
+				"series_name": o.SeriesName,
+			}
+		}
+		out["null_values"] = []interface{}{
+			map[string]interface{}{
+				"null_value":      cfg.NullValues.NullValue,
+				"series_override": overrides,
+			},
+		}
+	}
+
+	if cfg.Units != nil {
+		overrides := make([]interface{}, len(cfg.Units.SeriesOverrides))
+		for i, o := range cfg.Units.SeriesOverrides {
+			overrides[i] = map[string]interface{}{
+				"unit":        o.Unit,
+				"series_name": o.SeriesName,
+			}
+		}
+		out["units"] = []interface{}{
+			map[string]interface{}{
+				"unit":            cfg.Units.Unit,
+				"series_override": overrides,
+			},
+		}
+	}
+
+	if cfg.Colors != nil {
+		overrides := make([]interface{}, len(cfg.Colors.SeriesOverrides))
+		for i, o := range cfg.Colors.SeriesOverrides {
+			overrides[i] = map[string]interface{}{
+				"color":       o.Color,
+				"series_name": o.SeriesName,
+			}
+		}
+		out["colors"] = []interface{}{
+			map[string]interface{}{
+				"color":           cfg.Colors.Color,
+				"series_override": overrides,
+			},
+		}
+	}
+
+	// The legend is shown unless disabled
+	out["legend_enabled"] = cfg.Legend == nil || cfg.Legend.Enabled
+	out["facet_show_other_series"] = cfg.Facet != nil && cfg.Facet.ShowOtherSeries
+
+	return cfg
+}
+
 func flattenDashboardWidgetNRQLQuery(in *[]entities.DashboardWidgetNRQLQuery) []interface{} {
 	out := make([]interface{}, len(*in))
 
@@ -0,0 +1,281 @@
+package newrelic
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/newrelic/newrelic-client-go/pkg/common"
+	"github.com/newrelic/newrelic-client-go/pkg/dashboards"
+	"github.com/newrelic/newrelic-client-go/pkg/entities"
+)
+
+// The JSON export of a dashboard is its dashboardInput, with the
+// configuration of every widget, markdown included, in rawConfiguration and
+// without any page GUIDs or widget IDs.
+
+// expandDashboardJSONInput unmarshals a dashboard JSON export, filling in the
+// values the export may leave out the same way expandDashboardInput does.
+func expandDashboardJSONInput(raw string, accountID int) (*dashboardInput, error) {
+	var dashboard dashboardInput
+
+	if err := json.Unmarshal([]byte(raw), &dashboard); err != nil {
+		return nil, err
+	}
+
+	if dashboard.Permissions == "" {
+		dashboard.Permissions = entities.DashboardPermissionsTypes.PUBLIC_READ_ONLY
+	}
+
+	for i := range dashboard.Pages {
+		page := &dashboard.Pages[i]
+		page.GUID = ""
+
+		for j := range page.Widgets {
+			widget := &page.Widgets[j]
+			widget.ID = ""
+
+			cfg, err := expandDashboardJSONWidgetQueryAccounts(widget.RawConfiguration, accountID)
+			if err != nil {
+				return nil, err
+			}
+			widget.RawConfiguration = cfg
+		}
+	}
+
+	for i := range dashboard.Variables {
+		variable := &dashboard.Variables[i]
+
+		if variable.ReplacementStrategy == "" {
+			variable.ReplacementStrategy = "DEFAULT"
+		}
+
+		if variable.NRQLQuery != nil && len(variable.NRQLQuery.AccountIDs) == 0 {
+			variable.NRQLQuery.AccountIDs = []int{accountID}
+		}
+	}
+
+	return &dashboard, nil
+}
+
+// expandDashboardJSONWidgetQueryAccounts sets the account of the NRQL queries
+// of a widget's raw configuration that have none.
+func expandDashboardJSONWidgetQueryAccounts(raw entities.DashboardWidgetRawConfiguration, accountID int) (entities.DashboardWidgetRawConfiguration, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil
+	}
+
+	var cfg map[string]interface{}
+	if err := json.Unmarshal(raw, &cfg); err != nil {
+		return nil, err
+	}
+
+	queries, ok := cfg["nrqlQueries"].([]interface{})
+	if !ok {
+		return raw, nil
+	}
+
+	for _, q := range queries {
+		query, ok := q.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if id, ok := query["accountId"].(float64); !ok || id == 0 {
+			query["accountId"] = accountID
+		}
+	}
+
+	return json.Marshal(cfg)
+}
+
+// expandDashboardJSONMutationInput returns the input sent to NerdGraph for a
+// dashboard JSON export. Markdown widgets are sent with their typed
+// configuration, as expandDashboardPageInput does. The other widgets are sent
+// with their visualization ID and raw configuration as exported, which is how
+// expandDashboardPageInput sends them as well, so they need no mapping to a
+// typed configuration.
+func expandDashboardJSONMutationInput(in dashboardInput, pages []entities.DashboardPage) dashboardInput {
+	out := in
+	out.Pages = make([]dashboards.DashboardPageInput, len(in.Pages))
+
+	for i, p := range in.Pages {
+		page := p
+		page.Widgets = make([]dashboards.DashboardWidgetInput, len(p.Widgets))
+
+		// Pages keep their GUID, in order, when the dashboard is updated
+		if i < len(pages) {
+			page.GUID = pages[i].GUID
+		}
+
+		for j, w := range p.Widgets {
+			widget := w
+
+			if widget.Visualization.ID == "viz.markdown" {
+				var cfg dashboards.DashboardMarkdownWidgetConfigurationInput
+				if err := json.Unmarshal(widget.RawConfiguration, &cfg); err == nil {
+					widget.Configuration.Markdown = &cfg
+					widget.RawConfiguration = nil
+					widget.Visualization.ID = ""
+				}
+			}
+
+			page.Widgets[j] = widget
+		}
+
+		out.Pages[i] = page
+	}
+
+	return out
+}
+
+// flattenDashboardJSON returns a dashboard in the shape of its JSON export.
+func flattenDashboardJSON(dashboard *entities.DashboardEntity, variables []dashboardVariable) dashboardInput {
+	var out dashboardInput
+
+	out.Name = dashboard.Name
+	out.Description = dashboard.Description
+	out.Permissions = dashboard.Permissions
+	out.Variables = variables
+
+	for _, p := range dashboard.Pages {
+		page := dashboards.DashboardPageInput{
+			Name:        p.Name,
+			Description: p.Description,
+		}
+
+		for _, w := range p.Widgets {
+			widget := dashboards.DashboardWidgetInput{
+				Title: w.Title,
+				Layout: dashboards.DashboardWidgetLayoutInput{
+					Column: w.Layout.Column,
+					Height: w.Layout.Height,
+					Row:    w.Layout.Row,
+					Width:  w.Layout.Width,
+				},
+				Visualization: dashboards.DashboardWidgetVisualizationInput{
+					ID: w.Visualization.ID,
+				},
+				RawConfiguration: w.RawConfiguration,
+			}
+
+			for _, guid := range flattenLinkedEntityGUIDs(w.LinkedEntities) {
+				widget.LinkedEntityGUIDs = append(widget.LinkedEntityGUIDs, common.EntityGUID(guid))
+			}
+
+			// Markdown widgets created with a typed configuration
+			if len(widget.RawConfiguration) == 0 && w.Configuration.Markdown.Text != "" {
+				widget.Visualization.ID = "viz.markdown"
+				widget.RawConfiguration, _ = json.Marshal(dashboards.DashboardMarkdownWidgetConfigurationInput{
+					Text: w.Configuration.Markdown.Text,
+				})
+			}
+
+			page.Widgets = append(page.Widgets, widget)
+		}
+
+		out.Pages = append(out.Pages, page)
+	}
+
+	return out
+}
+
+// dashboardJSONWidgetDefaults are the settings New Relic fills in the raw
+// configuration of the widgets which leave them out.
+var dashboardJSONWidgetDefaults = map[string]interface{}{
+	"facet":           map[string]interface{}{"showOtherSeries": false},
+	"legend":          map[string]interface{}{"enabled": true},
+	"platformOptions": map[string]interface{}{"ignoreTimeRange": false},
+	"yAxisLeft":       map[string]interface{}{"zero": true},
+}
+
+// normalizeDashboardJSON returns the JSON of a dashboard with its keys sorted
+// and without the values which don't change the dashboard: its null or empty
+// values, its false values outside of the widgets' raw configuration and the
+// widget settings left to their default. Two exports of the same dashboard
+// compare equal.
+func normalizeDashboardJSON(dashboard dashboardInput) (string, error) {
+	b, err := json.Marshal(dashboard)
+	if err != nil {
+		return "", err
+	}
+
+	var v map[string]interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return "", err
+	}
+
+	pages, _ := v["pages"].([]interface{})
+	for _, p := range pages {
+		page, _ := p.(map[string]interface{})
+		widgets, _ := page["widgets"].([]interface{})
+
+		for _, w := range widgets {
+			widget, _ := w.(map[string]interface{})
+			if cfg, ok := widget["rawConfiguration"].(map[string]interface{}); ok {
+				removeDashboardJSONDefaults(cfg, dashboardJSONWidgetDefaults)
+			}
+		}
+	}
+
+	b, err = json.Marshal(pruneDashboardJSON(v, false))
+	if err != nil {
+		return "", err
+	}
+
+	return string(b), nil
+}
+
+// removeDashboardJSONDefaults deletes the settings of cfg which are set to
+// their default value.
+func removeDashboardJSONDefaults(cfg map[string]interface{}, defaults map[string]interface{}) {
+	for k, def := range defaults {
+		if nested, ok := def.(map[string]interface{}); ok {
+			if m, ok := cfg[k].(map[string]interface{}); ok {
+				removeDashboardJSONDefaults(m, nested)
+			}
+			continue
+		}
+
+		if reflect.DeepEqual(cfg[k], def) {
+			delete(cfg, k)
+		}
+	}
+}
+
+// pruneDashboardJSON drops the null and empty values of v, and its false
+// values unless keepFalse is set. The false values of a widget's raw
+// configuration are kept, as they turn off a setting which is on by default.
+func pruneDashboardJSON(v interface{}, keepFalse bool) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{})
+		for k, val := range t {
+			if pruned := pruneDashboardJSON(val, keepFalse || k == "rawConfiguration"); pruned != nil {
+				out[k] = pruned
+			}
+		}
+		if len(out) == 0 {
+			return nil
+		}
+		return out
+	case []interface{}:
+		if len(t) == 0 {
+			return nil
+		}
+		out := make([]interface{}, len(t))
+		for i, val := range t {
+			out[i] = pruneDashboardJSON(val, keepFalse)
+		}
+		return out
+	case string:
+		if t == "" {
+			return nil
+		}
+	case bool:
+		if !t && !keepFalse {
+			return nil
+		}
+	}
+
+	return v
+}
@@ -88,6 +88,10 @@ func expandDashboardRawPageInput(pages []interface{}, meta interface{}) ([]dashb
 			}
 		}
 
+		if err := layoutDashboardWidgets(page.Widgets); err != nil {
+			return nil, err
+		}
+
 		expanded[i] = page
 	}
 
@@ -141,6 +145,7 @@ func flattenDashboardRawEntity(dashboard *entities.DashboardEntity, d *schema.Re
 
 	if dashboard.Pages != nil && len(dashboard.Pages) > 0 {
 		pages := flattenDashboardRawPage(&dashboard.Pages)
+		unsetDashboardWidgetPositions(pages, d)
 		if err := d.Set("page", pages); err != nil {
 			return err
 		}
@@ -171,6 +176,7 @@ func flattenDashboardRawUpdateResult(result *dashboards.DashboardUpdateResult, d
 
 	if dashboard.Pages != nil && len(dashboard.Pages) > 0 {
 		pages := flattenDashboardRawPage(&dashboard.Pages)
+		unsetDashboardWidgetPositions(pages, d)
 		if err := d.Set("page", pages); err != nil {
 			return err
 		}
@@ -0,0 +1,179 @@
+package newrelic
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/newrelic/newrelic-client-go/pkg/servicelevel"
+)
+
+// expandServiceLevelCreateInput returns the ServiceLevelIndicatorCreateInput of
+// the service level. Its events are fetched from the provider's account unless
+// an account is set.
+func expandServiceLevelCreateInput(d *schema.ResourceData, defaultAccountID int) map[string]interface{} {
+	events := expandServiceLevelEvents(d.Get("events").([]interface{})[0].(map[string]interface{}))
+
+	accountID := d.Get("events.0.account_id").(int)
+	if accountID == 0 {
+		accountID = defaultAccountID
+	}
+
+	events["accountId"] = accountID
+
+	return map[string]interface{}{
+		"name":        d.Get("name").(string),
+		"description": d.Get("description").(string),
+		"events":      events,
+		"objectives":  expandServiceLevelObjectives(d.Get("objective").([]interface{})),
+	}
+}
+
+// expandServiceLevelUpdateInput returns the ServiceLevelIndicatorUpdateInput of
+// the service level, the account of its events can't be updated.
+func expandServiceLevelUpdateInput(d *schema.ResourceData) map[string]interface{} {
+	return map[string]interface{}{
+		"name":        d.Get("name").(string),
+		"description": d.Get("description").(string),
+		"events":      expandServiceLevelEvents(d.Get("events").([]interface{})[0].(map[string]interface{})),
+		"objectives":  expandServiceLevelObjectives(d.Get("objective").([]interface{})),
+	}
+}
+
+func expandServiceLevelEvents(cfg map[string]interface{}) map[string]interface{} {
+	events := map[string]interface{}{}
+
+	for attribute, field := range map[string]string{
+		"valid_events": "validEvents",
+		"good_events":  "goodEvents",
+		"bad_events":   "badEvents",
+	} {
+		if query := expandServiceLevelEventsQuery(cfg[attribute].([]interface{})); query != nil {
+			events[field] = query
+		}
+	}
+
+	return events
+}
+
+// expandServiceLevelEventsQuery returns the events query, or nil when the
+// events are not defined.
+func expandServiceLevelEventsQuery(cfg []interface{}) map[string]interface{} {
+	if len(cfg) == 0 || cfg[0] == nil {
+		return nil
+	}
+
+	query := cfg[0].(map[string]interface{})
+
+	input := map[string]interface{}{
+		"from": query["from"],
+	}
+
+	if where := query["where"].(string); where != "" {
+		input["where"] = where
+	}
+
+	return input
+}
+
+func expandServiceLevelObjectives(cfg []interface{}) []interface{} {
+	objectives := make([]interface{}, len(cfg))
+
+	for i, rawCfg := range cfg {
+		objective := rawCfg.(map[string]interface{})
+		rolling := objective["time_window"].([]interface{})[0].(map[string]interface{})["rolling"].([]interface{})[0].(map[string]interface{})
+
+		objectives[i] = map[string]interface{}{
+			"name":        objective["name"],
+			"description": objective["description"],
+			"target":      objective["target"],
+			"timeWindow": map[string]interface{}{
+				"rolling": map[string]interface{}{
+					"count": rolling["count"],
+					"unit":  rolling["unit"],
+				},
+			},
+		}
+	}
+
+	return objectives
+}
+
+func flattenServiceLevel(indicator *servicelevel.ServiceLevelIndicator, entityGUID string, d *schema.ResourceData) error {
+	accountID := indicator.Events.Account.ID
+
+	if err := d.Set("guid", entityGUID); err != nil {
+		return err
+	}
+
+	if err := d.Set("name", indicator.Name); err != nil {
+		return err
+	}
+
+	if err := d.Set("description", indicator.Description); err != nil {
+		return err
+	}
+
+	events := map[string]interface{}{
+		"account_id":   accountID,
+		"valid_events": flattenServiceLevelEventsQuery(indicator.Events.ValidEvents),
+		"good_events":  flattenServiceLevelEventsQuery(indicator.Events.GoodEvents),
+		"bad_events":   flattenServiceLevelEventsQuery(indicator.Events.BadEvents),
+	}
+
+	if err := d.Set("events", []interface{}{events}); err != nil {
+		return err
+	}
+
+	if err := d.Set("objective", flattenServiceLevelObjectives(indicator.Objectives)); err != nil {
+		return err
+	}
+
+	computed := map[string]string{
+		"sli_id":              indicator.ID,
+		"sli_guid":            serviceLevelGUID(accountID, indicator.ID),
+		"valid_events_metric": serviceLevelValidEventsMetric,
+		"good_events_metric":  serviceLevelGoodEventsMetric,
+		"bad_events_metric":   serviceLevelBadEventsMetric,
+		"indicator_query":     string(indicator.ResultQueries.Indicator.NRQL),
+		"valid_events_query":  string(indicator.ResultQueries.ValidEvents.NRQL),
+		"good_events_query":   string(indicator.ResultQueries.GoodEvents.NRQL),
+	}
+
+	for k, v := range computed {
+		if err := d.Set(k, v); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func flattenServiceLevelEventsQuery(query servicelevel.ServiceLevelEventsQuery) []interface{} {
+	if query.From == "" {
+		return []interface{}{}
+	}
+
+	return []interface{}{map[string]interface{}{
+		"from":  string(query.From),
+		"where": string(query.Where),
+	}}
+}
+
+func flattenServiceLevelObjectives(objectives []servicelevel.ServiceLevelObjective) []interface{} {
+	flattened := make([]interface{}, len(objectives))
+
+	for i, objective := range objectives {
+		flattened[i] = map[string]interface{}{
+			"name":        objective.Name,
+			"description": objective.Description,
+			"target":      objective.Target,
+			"time_window": []interface{}{map[string]interface{}{
+				"rolling": []interface{}{map[string]interface{}{
+					"count": objective.TimeWindow.Rolling.Count,
+					"unit":  string(objective.TimeWindow.Rolling.Unit),
+				}},
+			}},
+			"attainment_query": string(objective.ResultQueries.Attainment.NRQL),
+		}
+	}
+
+	return flattened
+}
@@ -0,0 +1,260 @@
+package newrelic
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// expandWorkflowCreateInput returns the AiWorkflowsCreateWorkflowInput of the
+// workflow.
+func expandWorkflowCreateInput(d *schema.ResourceData) map[string]interface{} {
+	input := expandWorkflowInput(d)
+	input["issuesFilter"] = expandWorkflowIssuesFilter(d.Get("issues_filter").([]interface{})[0].(map[string]interface{}))
+
+	if enrichments := expandWorkflowEnrichments(d.Get("enrichments").([]interface{}), false); enrichments != nil {
+		input["enrichments"] = enrichments
+	}
+
+	return input
+}
+
+// expandWorkflowUpdateInput returns the AiWorkflowsUpdateWorkflowInput of the
+// workflow. The issues filter and the enrichments keep their IDs, so that they
+// are updated in place.
+func expandWorkflowUpdateInput(d *schema.ResourceData) map[string]interface{} {
+	input := expandWorkflowInput(d)
+	input["id"] = d.Id()
+
+	filter := d.Get("issues_filter").([]interface{})[0].(map[string]interface{})
+	issuesFilter := map[string]interface{}{
+		"filterInput": expandWorkflowIssuesFilter(filter),
+	}
+
+	if id := filter["filter_id"].(string); id != "" {
+		issuesFilter["id"] = id
+	}
+
+	input["issuesFilter"] = issuesFilter
+
+	enrichments := expandWorkflowEnrichments(d.Get("enrichments").([]interface{}), true)
+	if enrichments == nil {
+		enrichments = map[string]interface{}{"nrql": []interface{}{}}
+	}
+
+	input["enrichments"] = enrichments
+
+	return input
+}
+
+func expandWorkflowInput(d *schema.ResourceData) map[string]interface{} {
+	destinations := d.Get("destination").(*schema.Set).List()
+	configurations := make([]interface{}, len(destinations))
+
+	for i, rawCfg := range destinations {
+		configurations[i] = map[string]interface{}{
+			"channelId": rawCfg.(map[string]interface{})["channel_id"],
+		}
+	}
+
+	return map[string]interface{}{
+		"name":                      d.Get("name").(string),
+		"workflowEnabled":           d.Get("enabled").(bool),
+		"destinationsEnabled":       d.Get("destinations_enabled").(bool),
+		"enrichmentsEnabled":        d.Get("enrichments_enabled").(bool),
+		"mutingRulesHandling":       d.Get("muting_rules_handling").(string),
+		"destinationConfigurations": configurations,
+	}
+}
+
+func expandWorkflowIssuesFilter(cfg map[string]interface{}) map[string]interface{} {
+	rawPredicates := cfg["predicate"].([]interface{})
+	predicates := make([]interface{}, len(rawPredicates))
+
+	for i, rawPredicate := range rawPredicates {
+		predicate := rawPredicate.(map[string]interface{})
+
+		predicates[i] = map[string]interface{}{
+			"attribute": predicate["attribute"],
+			"operator":  predicate["operator"],
+			"values":    predicate["values"],
+		}
+	}
+
+	return map[string]interface{}{
+		"name":       cfg["name"],
+		"type":       cfg["type"],
+		"predicates": predicates,
+	}
+}
+
+// expandWorkflowEnrichments returns the enrichments input of the workflow, or
+// nil when it has none.
+func expandWorkflowEnrichments(cfg []interface{}, withIDs bool) map[string]interface{} {
+	if len(cfg) == 0 || cfg[0] == nil {
+		return nil
+	}
+
+	rawNrql := cfg[0].(map[string]interface{})["nrql"].([]interface{})
+	nrql := make([]interface{}, len(rawNrql))
+
+	for i, rawEnrichment := range rawNrql {
+		enrichment := rawEnrichment.(map[string]interface{})
+
+		rawConfigurations := enrichment["configuration"].([]interface{})
+		configurations := make([]interface{}, len(rawConfigurations))
+
+		for j, rawConfiguration := range rawConfigurations {
+			configurations[j] = map[string]interface{}{
+				"query": rawConfiguration.(map[string]interface{})["query"],
+			}
+		}
+
+		input := map[string]interface{}{
+			"name":          enrichment["name"],
+			"configuration": configurations,
+		}
+
+		if id := enrichment["enrichment_id"].(string); withIDs && id != "" {
+			input["id"] = id
+		}
+
+		nrql[i] = input
+	}
+
+	return map[string]interface{}{"nrql": nrql}
+}
+
+func flattenWorkflow(w *workflow, d *schema.ResourceData) error {
+	if err := d.Set("name", w.Name); err != nil {
+		return err
+	}
+
+	if err := d.Set("enabled", w.WorkflowEnabled); err != nil {
+		return err
+	}
+
+	if err := d.Set("destinations_enabled", w.DestinationsEnabled); err != nil {
+		return err
+	}
+
+	if err := d.Set("enrichments_enabled", w.EnrichmentsEnabled); err != nil {
+		return err
+	}
+
+	if err := d.Set("muting_rules_handling", w.MutingRulesHandling); err != nil {
+		return err
+	}
+
+	if err := d.Set("last_run", w.LastRun); err != nil {
+		return err
+	}
+
+	if err := d.Set("destination", flattenWorkflowDestinations(w.DestinationConfigurations)); err != nil {
+		return err
+	}
+
+	if err := d.Set("enrichments", flattenWorkflowEnrichments(w.Enrichments)); err != nil {
+		return err
+	}
+
+	return d.Set("issues_filter", flattenWorkflowIssuesFilter(w.IssuesFilter))
+}
+
+func flattenWorkflowDestinations(destinations []workflowDestination) []interface{} {
+	flattened := make([]interface{}, len(destinations))
+
+	for i, destination := range destinations {
+		flattened[i] = map[string]interface{}{
+			"channel_id": destination.ChannelID,
+			"name":       destination.Name,
+			"type":       destination.Type,
+		}
+	}
+
+	return flattened
+}
+
+// flattenWorkflowEnrichments flattens the NRQL enrichments of a workflow, the
+// only type supported.
+func flattenWorkflowEnrichments(enrichments []workflowEnrichment) []interface{} {
+	nrql := []interface{}{}
+
+	for _, enrichment := range enrichments {
+		if enrichment.Type != "NRQL" {
+			continue
+		}
+
+		configurations := make([]interface{}, len(enrichment.Configurations))
+		for i, c := range enrichment.Configurations {
+			configurations[i] = map[string]interface{}{"query": c.Query}
+		}
+
+		nrql = append(nrql, map[string]interface{}{
+			"enrichment_id": enrichment.ID,
+			"name":          enrichment.Name,
+			"configuration": configurations,
+		})
+	}
+
+	if len(nrql) == 0 {
+		return []interface{}{}
+	}
+
+	return []interface{}{map[string]interface{}{"nrql": nrql}}
+}
+
+func flattenWorkflowIssuesFilter(filter *workflowIssuesFilter) []interface{} {
+	if filter == nil {
+		return []interface{}{}
+	}
+
+	predicates := make([]interface{}, len(filter.Predicates))
+	for i, p := range filter.Predicates {
+		predicates[i] = map[string]interface{}{
+			"attribute": p.Attribute,
+			"operator":  p.Operator,
+			"values":    p.Values,
+		}
+	}
+
+	return []interface{}{map[string]interface{}{
+		"filter_id": filter.ID,
+		"name":      filter.Name,
+		"type":      filter.Type,
+		"predicate": predicates,
+	}}
+}
+
+func flattenWorkflowDataSource(w *workflow, d *schema.ResourceData) error {
+	if err := d.Set("enabled", w.WorkflowEnabled); err != nil {
+		return err
+	}
+
+	if err := d.Set("destinations_enabled", w.DestinationsEnabled); err != nil {
+		return err
+	}
+
+	if err := d.Set("enrichments_enabled", w.EnrichmentsEnabled); err != nil {
+		return err
+	}
+
+	if err := d.Set("muting_rules_handling", w.MutingRulesHandling); err != nil {
+		return err
+	}
+
+	if err := d.Set("last_run", w.LastRun); err != nil {
+		return err
+	}
+
+	if w.IssuesFilter != nil {
+		if err := d.Set("issues_filter_id", w.IssuesFilter.ID); err != nil {
+			return err
+		}
+	}
+
+	channelIDs := make([]string, len(w.DestinationConfigurations))
+	for i, destination := range w.DestinationConfigurations {
+		channelIDs[i] = destination.ChannelID
+	}
+
+	return d.Set("channel_ids", channelIDs)
+}
@@ -0,0 +1,248 @@
+package newrelic
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	retryablehttp "github.com/hashicorp/go-retryablehttp"
+)
+
+const (
+	defaultMaxRetries      = 3
+	defaultMaxRetryBackoff = 30
+	minRetryBackoff        = 1 * time.Second
+)
+
+// newRetryTransport returns a transport which retries the requests made with
+// t. Each attempt times out after attemptTimeout, the client using the
+// transport should allow for the whole of retryTimeout.
+func newRetryTransport(t http.RoundTripper, attemptTimeout time.Duration, maxRetries int, maxBackoff time.Duration) http.RoundTripper {
+	rc := retryablehttp.NewClient()
+	rc.HTTPClient = &http.Client{Transport: t, Timeout: attemptTimeout}
+	rc.Logger = nil
+
+	// The last response is returned when giving up, like a plain transport would.
+	rc.ErrorHandler = retryablehttp.PassthroughErrorHandler
+
+	configureRetries(rc, maxRetries, maxBackoff)
+
+	return &retryablehttp.RoundTripper{Client: rc}
+}
+
+// retryTimeout returns the time needed by a request which is retried until it
+// gives up, to be used as the timeout of the clients using newRetryTransport.
+func retryTimeout(attemptTimeout time.Duration, maxRetries int, maxBackoff time.Duration) time.Duration {
+	maxRetries, maxBackoff = retrySettings(maxRetries, maxBackoff)
+
+	return time.Duration(maxRetries+1)*attemptTimeout + time.Duration(maxRetries)*maxBackoff
+}
+
+func retrySettings(maxRetries int, maxBackoff time.Duration) (int, time.Duration) {
+	if maxRetries < 0 {
+		maxRetries = 0
+	}
+
+	if maxBackoff < minRetryBackoff {
+		maxBackoff = minRetryBackoff
+	}
+
+	return maxRetries, maxBackoff
+}
+
+// configureRetries makes rc retry the requests which failed because of rate
+// limiting, a transient server error or a transient network error, using an
+// exponential backoff that honors the Retry-After header of the response.
+func configureRetries(rc *retryablehttp.Client, maxRetries int, maxBackoff time.Duration) {
+	maxRetries, maxBackoff = retrySettings(maxRetries, maxBackoff)
+
+	rc.RetryMax = maxRetries
+	rc.RetryWaitMin = minRetryBackoff
+	rc.RetryWaitMax = maxBackoff
+	rc.Backoff = retryBackoff
+	rc.CheckRetry = retryPolicy
+	rc.RequestLogHook = func(_ retryablehttp.Logger, req *http.Request, attempt int) {
+		if attempt > 0 {
+			log.Printf("[DEBUG] Retrying %s %s (attempt %d of %d)", req.Method, req.URL.Redacted(), attempt, maxRetries)
+		}
+	}
+}
+
+// retryBackoff returns how long to wait before the next attempt. A
+// Retry-After header takes precedence over the exponential backoff, and both
+// are capped by the maximum backoff.
+func retryBackoff(min, max time.Duration, attempt int, resp *http.Response) time.Duration {
+	wait := time.Duration(math.Pow(2, float64(attempt))) * min
+
+	if resp != nil {
+		if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			wait = retryAfter
+		}
+	}
+
+	if wait > max {
+		wait = max
+	}
+
+	return wait
+}
+
+// retryPolicy returns whether a request should be retried.
+//
+// 500 responses are not retried because the New Relic APIs use them to report
+// validation errors that should be passed back to the user. Most of the
+// requests are mutations which are not safe to send twice, so a request that
+// failed with a network error is only retried when it was never sent.
+func retryPolicy(ctx context.Context, resp *http.Response, err error) (bool, error) {
+	if ctx.Err() != nil {
+		return false, ctx.Err()
+	}
+
+	if err != nil {
+		return isTransientNetworkError(err), nil
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// isTransientNetworkError returns whether err is a network error which
+// happened before the request was sent, and which may not happen again, such
+// as a refused connection or a failed DNS lookup. TLS errors and malformed
+// URLs are never retried.
+func isTransientNetworkError(err error) bool {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return dnsErr.IsTemporary || dnsErr.IsTimeout
+	}
+
+	var opErr *net.OpError
+	if errors.As(err, &opErr) {
+		return opErr.Op == "dial"
+	}
+
+	return false
+}
+
+// parseRetryAfter parses a Retry-After header, which holds either a number of
+// seconds or an HTTP date.
+func parseRetryAfter(v string) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(v); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(v); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+
+		return wait, true
+	}
+
+	return 0, false
+}
+
+// requestLimiter is a semaphore shared by all of the provider's API clients
+// which bounds the number of concurrent requests made to New Relic. A nil
+// limiter does not limit anything.
+type requestLimiter chan struct{}
+
+func newRequestLimiter(maxConcurrentRequests int) requestLimiter {
+	if maxConcurrentRequests <= 0 {
+		return nil
+	}
+
+	return make(requestLimiter, maxConcurrentRequests)
+}
+
+// acquire blocks until a request slot is available or the context is done.
+func (l requestLimiter) acquire(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	select {
+	case l <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (l requestLimiter) release() {
+	if l == nil {
+		return
+	}
+
+	<-l
+}
+
+// limitTransport holds a request slot from the limiter until the response
+// body has been closed.
+type limitTransport struct {
+	transport http.RoundTripper
+	limiter   requestLimiter
+}
+
+func newLimitTransport(t http.RoundTripper, limiter requestLimiter) http.RoundTripper {
+	if limiter == nil {
+		return t
+	}
+
+	return &limitTransport{
+		transport: t,
+		limiter:   limiter,
+	}
+}
+
+func (t *limitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.acquire(req.Context()); err != nil {
+		return nil, err
+	}
+
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil {
+		t.limiter.release()
+		return nil, err
+	}
+
+	resp.Body = &limitedBody{ReadCloser: resp.Body, release: t.limiter.release}
+
+	return resp, nil
+}
+
+type limitedBody struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (b *limitedBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+
+	return err
+}
@@ -0,0 +1,341 @@
+//go:build unit
+// +build unit
+
+package newrelic
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	retryablehttp "github.com/hashicorp/go-retryablehttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testRetryClient(maxRetries int) *http.Client {
+	rc := retryablehttp.NewClient()
+	rc.Logger = nil
+	rc.ErrorHandler = retryablehttp.PassthroughErrorHandler
+
+	configureRetries(rc, maxRetries, time.Second)
+	rc.RetryWaitMin = time.Millisecond
+	rc.RetryWaitMax = 10 * time.Millisecond
+
+	return rc.StandardClient()
+}
+
+func TestRetryClient_RetriesRateLimitedRequests(t *testing.T) {
+	var attempts int32
+	var bodies []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+
+		if atomic.AddInt32(&attempts, 1) < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	resp, err := testRetryClient(3).Post(server.URL, "application/json", strings.NewReader(`{"query":"{}"}`))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(3), attempts)
+
+	// The request body is replayed on every attempt.
+	assert.Equal(t, []string{`{"query":"{}"}`, `{"query":"{}"}`, `{"query":"{}"}`}, bodies)
+}
+
+func TestRetryClient_GivesUpAfterMaxRetries(t *testing.T) {
+	var attempts int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	resp, err := testRetryClient(2).Get(server.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, int32(3), attempts)
+}
+
+func TestRetryClient_DoesNotRetryValidationErrors(t *testing.T) {
+	var attempts int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	resp, err := testRetryClient(3).Get(server.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	assert.Equal(t, int32(1), attempts)
+}
+
+func TestRetryClient_Disabled(t *testing.T) {
+	var attempts int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	resp, err := testRetryClient(0).Get(server.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, int32(1), attempts)
+}
+
+func TestRetryClient_StopsWhenContextIsCanceled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := testRetryClient(3)
+	client.Transport.(*retryablehttp.RoundTripper).Client.RetryWaitMax = time.Minute
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+
+	_, err = client.Do(req)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), context.DeadlineExceeded.Error())
+}
+
+func TestRetryBackoff(t *testing.T) {
+	assert.Equal(t, 1*time.Second, retryBackoff(time.Second, 10*time.Second, 0, nil))
+	assert.Equal(t, 4*time.Second, retryBackoff(time.Second, 10*time.Second, 2, nil))
+	assert.Equal(t, 10*time.Second, retryBackoff(time.Second, 10*time.Second, 5, nil))
+
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"7"}}}
+	assert.Equal(t, 7*time.Second, retryBackoff(time.Second, 10*time.Second, 0, resp))
+
+	resp.Header.Set("Retry-After", "120")
+	assert.Equal(t, 10*time.Second, retryBackoff(time.Second, 10*time.Second, 0, resp))
+}
+
+func TestRetryPolicy_Errors(t *testing.T) {
+	cases := map[string]struct {
+		err      error
+		expected bool
+	}{
+		"refused connection": {
+			err:      &url.Error{Op: "Post", URL: "https://api.newrelic.com/graphql", Err: &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}},
+			expected: true,
+		},
+		"temporary DNS failure": {
+			err:      &url.Error{Op: "Post", URL: "https://api.newrelic.com/graphql", Err: &net.OpError{Op: "dial", Net: "tcp", Err: &net.DNSError{Err: "server misbehaving", IsTemporary: true}}},
+			expected: true,
+		},
+		"unknown host": {
+			err:      &url.Error{Op: "Post", URL: "https://api.newrelic.example/graphql", Err: &net.OpError{Op: "dial", Net: "tcp", Err: &net.DNSError{Err: "no such host", IsNotFound: true}}},
+			expected: false,
+		},
+		"connection reset after sending": {
+			err:      &url.Error{Op: "Post", URL: "https://api.newrelic.com/graphql", Err: &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}},
+			expected: false,
+		},
+		"unknown certificate authority": {
+			err:      &url.Error{Op: "Post", URL: "https://api.newrelic.com/graphql", Err: x509.UnknownAuthorityError{}},
+			expected: false,
+		},
+		"unsupported scheme": {
+			err:      &url.Error{Op: "Post", URL: "ftp://api.newrelic.com/graphql", Err: errors.New(`unsupported protocol scheme "ftp"`)},
+			expected: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			retry, err := retryPolicy(context.Background(), nil, tc.err)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, retry)
+		})
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	retry, err := retryPolicy(ctx, nil, cases["refused connection"].err)
+	assert.Equal(t, context.Canceled, err)
+	assert.False(t, retry)
+}
+
+func TestConfigTransport_RetriesRequests(t *testing.T) {
+	for _, maxRetries := range []int{0, 1} {
+		var attempts int32
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&attempts, 1)
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}))
+
+		c := Config{
+			MaxRetries:      maxRetries,
+			MaxRetryBackoff: time.Second,
+		}
+
+		transport, err := c.transport("test", time.Second)
+		require.NoError(t, err)
+
+		resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+		require.NoError(t, err)
+		resp.Body.Close()
+
+		assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+		assert.Equal(t, int32(maxRetries+1), attempts)
+
+		server.Close()
+	}
+}
+
+func TestClient_RetriesInTransport(t *testing.T) {
+	var attempts int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":{"actor":{"user":{"id":1}}}}`))
+	}))
+	defer server.Close()
+
+	c := Config{
+		PersonalAPIKey:  "NRAK-TEST",
+		userAgent:       "terraform-provider-newrelic/test",
+		Region:          "US",
+		NerdGraphAPIURL: server.URL,
+		MaxRetries:      2,
+		MaxRetryBackoff: time.Second,
+	}
+
+	client, err := c.Client()
+	require.NoError(t, err)
+
+	var resp interface{}
+	err = client.NerdGraph.QueryWithResponseAndContext(context.Background(), `{ actor { user { id } } }`, nil, &resp)
+	require.NoError(t, err)
+
+	assert.Equal(t, int32(3), attempts)
+}
+
+func TestRetryTimeout(t *testing.T) {
+	assert.Equal(t, 30*time.Second, retryTimeout(30*time.Second, 0, 10*time.Second))
+	assert.Equal(t, 170*time.Second, retryTimeout(30*time.Second, 2, 40*time.Second))
+	assert.Equal(t, 21*time.Second, retryTimeout(10*time.Second, 1, 0))
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	wait, ok := parseRetryAfter("5")
+	assert.True(t, ok)
+	assert.Equal(t, 5*time.Second, wait)
+
+	wait, ok = parseRetryAfter(time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat))
+	assert.True(t, ok)
+	assert.Equal(t, time.Duration(0), wait)
+
+	_, ok = parseRetryAfter("soon")
+	assert.False(t, ok)
+
+	_, ok = parseRetryAfter("")
+	assert.False(t, ok)
+}
+
+func TestLimitTransport_BoundsConcurrentRequests(t *testing.T) {
+	var inFlight, maxInFlight int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+				break
+			}
+		}
+
+		time.Sleep(10 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: newLimitTransport(http.DefaultTransport, newRequestLimiter(2))}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			resp, err := client.Get(server.URL)
+			if assert.NoError(t, err) {
+				resp.Body.Close()
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.LessOrEqual(t, maxInFlight, int32(2))
+}
+
+func TestRequestLimiter_AcquireHonorsContext(t *testing.T) {
+	limiter := newRequestLimiter(1)
+	require.NoError(t, limiter.acquire(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	assert.Equal(t, context.DeadlineExceeded, limiter.acquire(ctx))
+
+	limiter.release()
+	assert.NoError(t, limiter.acquire(context.Background()))
+}
+
+func TestRequestLimiter_Unlimited(t *testing.T) {
+	limiter := newRequestLimiter(0)
+
+	assert.Nil(t, limiter)
+	assert.NoError(t, limiter.acquire(context.Background()))
+	limiter.release()
+	assert.Equal(t, http.DefaultTransport, newLimitTransport(http.DefaultTransport, limiter))
+}
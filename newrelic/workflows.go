@@ -0,0 +1,275 @@
+package newrelic
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/newrelic/newrelic-client-go/newrelic"
+	"github.com/newrelic/newrelic-client-go/pkg/errors"
+)
+
+const workflowFields = `
+	id
+	name
+	accountId
+	workflowEnabled
+	destinationsEnabled
+	enrichmentsEnabled
+	mutingRulesHandling
+	lastRun
+	destinationConfigurations {
+		channelId
+		name
+		type
+	}
+	enrichments {
+		id
+		name
+		type
+		configurations {
+			... on AiWorkflowsNrqlConfiguration {
+				query
+			}
+		}
+	}
+	issuesFilter {
+		id
+		name
+		type
+		predicates {
+			attribute
+			operator
+			values
+		}
+	}`
+
+const (
+	createWorkflowMutation = `mutation($accountId: Int!, $createWorkflowData: AiWorkflowsCreateWorkflowInput!) {
+		aiWorkflowsCreateWorkflow(accountId: $accountId, createWorkflowData: $createWorkflowData) {
+			workflow {` + workflowFields + `
+			}
+			errors {
+				description
+				type
+			}
+		}
+	}`
+
+	updateWorkflowMutation = `mutation($accountId: Int!, $updateWorkflowData: AiWorkflowsUpdateWorkflowInput!) {
+		aiWorkflowsUpdateWorkflow(accountId: $accountId, updateWorkflowData: $updateWorkflowData) {
+			workflow {` + workflowFields + `
+			}
+			errors {
+				description
+				type
+			}
+		}
+	}`
+
+	deleteWorkflowMutation = `mutation($accountId: Int!, $id: ID!) {
+		aiWorkflowsDeleteWorkflow(accountId: $accountId, id: $id, deleteChannels: false) {
+			id
+			errors {
+				description
+				type
+			}
+		}
+	}`
+
+	getWorkflowsQuery = `query($accountId: Int!, $filters: AiWorkflowsFilters, $cursor: String) {
+		actor {
+			account(id: $accountId) {
+				aiWorkflows {
+					workflows(filters: $filters, cursor: $cursor) {
+						entities {` + workflowFields + `
+						}
+						nextCursor
+					}
+				}
+			}
+		}
+	}`
+)
+
+type workflow struct {
+	ID                        string                `json:"id"`
+	Name                      string                `json:"name"`
+	AccountID                 int                   `json:"accountId"`
+	WorkflowEnabled           bool                  `json:"workflowEnabled"`
+	DestinationsEnabled       bool                  `json:"destinationsEnabled"`
+	EnrichmentsEnabled        bool                  `json:"enrichmentsEnabled"`
+	MutingRulesHandling       string                `json:"mutingRulesHandling"`
+	LastRun                   string                `json:"lastRun"`
+	DestinationConfigurations []workflowDestination `json:"destinationConfigurations"`
+	Enrichments               []workflowEnrichment  `json:"enrichments"`
+	IssuesFilter              *workflowIssuesFilter `json:"issuesFilter"`
+}
+
+type workflowDestination struct {
+	ChannelID string `json:"channelId"`
+	Name      string `json:"name"`
+	Type      string `json:"type"`
+}
+
+type workflowEnrichment struct {
+	ID             string `json:"id"`
+	Name           string `json:"name"`
+	Type           string `json:"type"`
+	Configurations []struct {
+		Query string `json:"query"`
+	} `json:"configurations"`
+}
+
+type workflowIssuesFilter struct {
+	ID         string              `json:"id"`
+	Name       string              `json:"name"`
+	Type       string              `json:"type"`
+	Predicates []workflowPredicate `json:"predicates"`
+}
+
+type workflowPredicate struct {
+	Attribute string   `json:"attribute"`
+	Operator  string   `json:"operator"`
+	Values    []string `json:"values"`
+}
+
+// workflowErrors are the errors of a workflow mutation.
+type workflowErrors []struct {
+	Description string `json:"description"`
+	Type        string `json:"type"`
+}
+
+func (e workflowErrors) err() error {
+	if len(e) == 0 {
+		return nil
+	}
+
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = fmt.Sprintf("%s: %s", err.Type, err.Description)
+	}
+
+	return fmt.Errorf("%s", strings.Join(messages, ", "))
+}
+
+func createWorkflow(ctx context.Context, client *newrelic.NewRelic, accountID int, input map[string]interface{}) (*workflow, error) {
+	var resp struct {
+		AiWorkflowsCreateWorkflow struct {
+			Workflow *workflow      `json:"workflow"`
+			Errors   workflowErrors `json:"errors"`
+		} `json:"aiWorkflowsCreateWorkflow"`
+	}
+
+	vars := map[string]interface{}{
+		"accountId":          accountID,
+		"createWorkflowData": input,
+	}
+
+	if err := client.NerdGraph.QueryWithResponseAndContext(ctx, createWorkflowMutation, vars, &resp); err != nil {
+		return nil, err
+	}
+
+	if err := resp.AiWorkflowsCreateWorkflow.Errors.err(); err != nil {
+		return nil, err
+	}
+
+	return resp.AiWorkflowsCreateWorkflow.Workflow, nil
+}
+
+func updateWorkflow(ctx context.Context, client *newrelic.NewRelic, accountID int, input map[string]interface{}) (*workflow, error) {
+	var resp struct {
+		AiWorkflowsUpdateWorkflow struct {
+			Workflow *workflow      `json:"workflow"`
+			Errors   workflowErrors `json:"errors"`
+		} `json:"aiWorkflowsUpdateWorkflow"`
+	}
+
+	vars := map[string]interface{}{
+		"accountId":          accountID,
+		"updateWorkflowData": input,
+	}
+
+	if err := client.NerdGraph.QueryWithResponseAndContext(ctx, updateWorkflowMutation, vars, &resp); err != nil {
+		return nil, err
+	}
+
+	if err := resp.AiWorkflowsUpdateWorkflow.Errors.err(); err != nil {
+		return nil, err
+	}
+
+	return resp.AiWorkflowsUpdateWorkflow.Workflow, nil
+}
+
+// deleteWorkflow deletes a workflow, leaving its channels, which are managed
+// by their own resources.
+func deleteWorkflow(ctx context.Context, client *newrelic.NewRelic, accountID int, id string) error {
+	var resp struct {
+		AiWorkflowsDeleteWorkflow struct {
+			Errors workflowErrors `json:"errors"`
+		} `json:"aiWorkflowsDeleteWorkflow"`
+	}
+
+	vars := map[string]interface{}{
+		"accountId": accountID,
+		"id":        id,
+	}
+
+	if err := client.NerdGraph.QueryWithResponseAndContext(ctx, deleteWorkflowMutation, vars, &resp); err != nil {
+		return err
+	}
+
+	return resp.AiWorkflowsDeleteWorkflow.Errors.err()
+}
+
+// getWorkflows returns the workflows matching filters, an AiWorkflowsFilters,
+// from all of the pages of results.
+func getWorkflows(ctx context.Context, client *newrelic.NewRelic, accountID int, filters map[string]interface{}) ([]workflow, error) {
+	workflows := []workflow{}
+	var nextCursor *string
+
+	for ok := true; ok; ok = nextCursor != nil {
+		var resp struct {
+			Actor struct {
+				Account struct {
+					AiWorkflows struct {
+						Workflows struct {
+							Entities   []workflow `json:"entities"`
+							NextCursor *string    `json:"nextCursor"`
+						} `json:"workflows"`
+					} `json:"aiWorkflows"`
+				} `json:"account"`
+			} `json:"actor"`
+		}
+
+		vars := map[string]interface{}{
+			"accountId": accountID,
+			"filters":   filters,
+			"cursor":    nextCursor,
+		}
+
+		if err := client.NerdGraph.QueryWithResponseAndContext(ctx, getWorkflowsQuery, vars, &resp); err != nil {
+			return nil, err
+		}
+
+		workflows = append(workflows, resp.Actor.Account.AiWorkflows.Workflows.Entities...)
+		nextCursor = resp.Actor.Account.AiWorkflows.Workflows.NextCursor
+	}
+
+	return workflows, nil
+}
+
+func getWorkflow(ctx context.Context, client *newrelic.NewRelic, accountID int, id string) (*workflow, error) {
+	workflows, err := getWorkflows(ctx, client, accountID, map[string]interface{}{"id": id})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range workflows {
+		if workflows[i].ID == id {
+			return &workflows[i], nil
+		}
+	}
+
+	return nil, errors.NewNotFoundf("no workflow found for id %s", id)
+}